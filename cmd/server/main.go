@@ -2,9 +2,12 @@ package main
 
 import (
 	"log"
+	"time"
 
 	"github.com/amangirdhar210/meeting-room/internal/adapters/auth"
 	httpAdapter "github.com/amangirdhar210/meeting-room/internal/adapters/http"
+	"github.com/amangirdhar210/meeting-room/internal/adapters/notification"
+	"github.com/amangirdhar210/meeting-room/internal/adapters/oidc"
 	repo "github.com/amangirdhar210/meeting-room/internal/adapters/repositories/sqlite"
 	"github.com/amangirdhar210/meeting-room/internal/config"
 	"github.com/amangirdhar210/meeting-room/internal/core/service"
@@ -18,8 +21,9 @@ func main() {
 
 	cfg := config.LoadConfig()
 
-	if cfg.JWT.Secret == "" {
-		log.Fatal("JWT_SECRET environment variable is required")
+	jwtGenerator, err := auth.NewJWTGenerator(cfg.JWT)
+	if err != nil {
+		log.Fatalf("Failed to configure JWT signing: %v", err)
 	}
 
 	dbCfg := repo.DBConfig{
@@ -43,21 +47,67 @@ func main() {
 	userRepo := repo.NewUserRepository(db)
 	roomRepo := repo.NewRoomRepository(db)
 	bookingRepo := repo.NewBookingRepository(db)
+	seriesRepo := repo.NewBookingSeriesRepository(db)
+	calendarTokenRepo := repo.NewCalendarTokenRepository(db)
+	roomBlockRepo := repo.NewRoomBlockRepository(db)
+	resetTokenRepo := repo.NewPasswordResetTokenRepository(db)
+	refreshTokenRepo := repo.NewRefreshTokenRepository(db)
+	revocationStore := repo.NewTokenRevocationStore(db)
+	waitlistRepo := repo.NewWaitlistRepository(db)
+	kioskTokenRepo := repo.NewKioskTokenRepository(db)
 
-	jwtGenerator := auth.NewJWTGenerator(cfg.JWT.Secret, cfg.JWT.ExpirationTime)
 	passwordHasher := auth.NewBcryptHasher()
+	notifier, err := notification.NewNotifier(cfg.Mail)
+	if err != nil {
+		log.Fatalf("Failed to configure mail: %v", err)
+	}
 
-	authService := service.NewAuthService(userRepo, jwtGenerator, passwordHasher)
-	userService := service.NewUserService(userRepo, passwordHasher)
-	roomService := service.NewRoomService(roomRepo)
-	bookingService := service.NewBookingService(bookingRepo, roomRepo, userRepo)
+	authService := service.NewAuthService(userRepo, refreshTokenRepo, revocationStore, jwtGenerator, passwordHasher, cfg.JWT.RefreshTokenTTL)
+	var oidcService service.OIDCService
+	if cfg.OIDC.Enabled() {
+		oidcService = service.NewOIDCService(
+			oidc.NewProvider(cfg.OIDC),
+			repo.NewOIDCLoginStateRepository(db),
+			repo.NewUserIdentityRepository(db),
+			userRepo,
+			authService,
+			cfg.OIDC.DefaultRole,
+			cfg.OIDC.GroupRoles,
+		)
+	}
+	resetService := service.NewPasswordResetService(resetTokenRepo, userRepo, passwordHasher, notifier, authService, cfg.PasswordReset.TokenTTL, cfg.PasswordReset.ResetURL)
+	bookingService := service.NewBookingService(bookingRepo, roomRepo, userRepo, roomBlockRepo, waitlistRepo, notifier, cfg.Waitlist.ClaimWindow, cfg.Approval.Timeout, cfg.BookingPolicy)
+	userService := service.NewUserService(userRepo, bookingRepo, roomRepo, seriesRepo, passwordHasher, bookingService, notifier, authService)
+	roomService := service.NewRoomService(roomRepo, bookingRepo, roomBlockRepo, userRepo, bookingService, notifier, cfg.Scheduling)
+	roomBlockService := service.NewRoomBlockService(roomBlockRepo, roomRepo, bookingRepo, userRepo)
+	seriesService := service.NewBookingSeriesService(seriesRepo, bookingRepo, roomRepo, userRepo, roomBlockRepo, bookingService, cfg.Approval.Timeout, cfg.BookingPolicy)
+	groupService := service.NewBookingGroupService(bookingRepo, roomRepo, userRepo, roomBlockRepo, bookingService, cfg.Approval.Timeout, cfg.BookingPolicy)
+	calendarService := service.NewCalendarService(calendarTokenRepo, bookingRepo, roomRepo, userRepo)
+	importService := service.NewBookingImportService(bookingService, bookingRepo, roomRepo, userRepo, roomBlockRepo)
+	waitlistService := service.NewWaitlistService(waitlistRepo, bookingService, bookingRepo, roomRepo, userRepo, roomBlockRepo)
+	approvalService := service.NewApprovalService(bookingRepo, roomRepo, userRepo, bookingService, notifier)
+	checkInService := service.NewCheckInService(bookingRepo, roomRepo, userRepo, kioskTokenRepo, bookingService, notifier, cfg.CheckIn.OpensBefore, cfg.CheckIn.Grace)
+
+	go expireApprovals(approvalService, cfg.Approval.SweepInterval)
+	go releaseNoShows(checkInService, cfg.CheckIn.SweepInterval)
+	go expireWaitlistOffers(waitlistService, cfg.Waitlist.SweepInterval)
 
 	server := httpAdapter.NewHTTPServer(
 		cfg,
 		userService,
 		authService,
+		resetService,
+		oidcService,
 		roomService,
+		roomBlockService,
 		bookingService,
+		seriesService,
+		groupService,
+		calendarService,
+		importService,
+		waitlistService,
+		approvalService,
+		checkInService,
 		jwtGenerator,
 	)
 
@@ -66,3 +116,58 @@ func main() {
 		log.Fatalf("Server error: %v", err)
 	}
 }
+
+// expireApprovals periodically releases the slots of pending bookings their
+// approvers did not answer in time.
+func expireApprovals(approvalService service.ApprovalService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		expired, err := approvalService.ExpireOverdue()
+		if err != nil {
+			log.Printf("Failed to expire pending approvals: %v", err)
+		}
+		if expired > 0 {
+			log.Printf("Expired %d unanswered booking requests", expired)
+		}
+	}
+}
+
+// releaseNoShows periodically frees the rooms of bookings nobody checked in
+// to and marks the bookings that are over as completed.
+func releaseNoShows(checkInService service.CheckInService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		released, err := checkInService.ReleaseNoShows()
+		if err != nil {
+			log.Printf("Failed to release no-show bookings: %v", err)
+		}
+		if released > 0 {
+			log.Printf("Released %d no-show bookings", released)
+		}
+		completed, err := checkInService.CompleteEnded()
+		if err != nil {
+			log.Printf("Failed to complete ended bookings: %v", err)
+		}
+		if completed > 0 {
+			log.Printf("Completed %d ended bookings", completed)
+		}
+	}
+}
+
+// expireWaitlistOffers periodically passes waitlist offers nobody claimed in
+// time on to the next entry.
+func expireWaitlistOffers(waitlistService service.WaitlistService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		expired, err := waitlistService.ExpireOffers()
+		if err != nil {
+			log.Printf("Failed to expire waitlist offers: %v", err)
+		}
+		if expired > 0 {
+			log.Printf("Expired %d unclaimed waitlist offers", expired)
+		}
+	}
+}
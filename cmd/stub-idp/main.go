@@ -0,0 +1,216 @@
+// Command stub-idp is a minimal OpenID Connect provider for trying single
+// sign-on locally. It signs everyone in without asking, as the user given by
+// STUB_IDP_EMAIL (or the login_hint parameter), and implements just enough of
+// the authorization code flow with PKCE for the meeting room server.
+//
+// Never expose it outside a development machine.
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/amangirdhar210/meeting-room/internal/adapters/auth"
+	"github.com/amangirdhar210/meeting-room/internal/pkg/utils"
+)
+
+type authorization struct {
+	clientID      string
+	redirectURI   string
+	codeChallenge string
+	nonce         string
+	email         string
+	expiresAt     time.Time
+}
+
+type stubIdP struct {
+	issuer   string
+	clientID string
+	name     string
+	email    string
+	groups   []string
+	key      *ecdsa.PrivateKey
+	jwk      auth.JWK
+
+	mu    sync.Mutex
+	codes map[string]authorization
+}
+
+func getenv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func main() {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		log.Fatalf("Failed to generate signing key: %v", err)
+	}
+	jwk, err := auth.PublicJWK(&key.PublicKey)
+	if err != nil {
+		log.Fatalf("Failed to describe signing key: %v", err)
+	}
+
+	addr := getenv("STUB_IDP_ADDR", ":9000")
+	idp := &stubIdP{
+		issuer:   strings.TrimSuffix(getenv("STUB_IDP_ISSUER", "http://localhost"+addr), "/"),
+		clientID: getenv("STUB_IDP_CLIENT_ID", "meeting-room"),
+		name:     getenv("STUB_IDP_NAME", "Jane Doe"),
+		email:    getenv("STUB_IDP_EMAIL", "jane@example.com"),
+		groups:   strings.FieldsFunc(os.Getenv("STUB_IDP_GROUPS"), func(r rune) bool { return r == ',' }),
+		key:      key,
+		jwk:      jwk,
+		codes:    make(map[string]authorization),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", idp.discovery)
+	mux.HandleFunc("GET /jwks", idp.jwks)
+	mux.HandleFunc("GET /authorize", idp.authorize)
+	mux.HandleFunc("POST /token", idp.token)
+
+	log.Printf("Stub identity provider %s signing in %s as client %s", idp.issuer, idp.email, idp.clientID)
+	log.Fatal(http.ListenAndServe(addr, mux))
+}
+
+func (idp *stubIdP) discovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                idp.issuer,
+		"authorization_endpoint":                idp.issuer + "/authorize",
+		"token_endpoint":                        idp.issuer + "/token",
+		"jwks_uri":                              idp.issuer + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"ES256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (idp *stubIdP) jwks(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, auth.JWKS{Keys: []auth.JWK{idp.jwk}})
+}
+
+func (idp *stubIdP) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	redirectURI, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil || !redirectURI.IsAbs() {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+	if query.Get("response_type") != "code" || query.Get("client_id") != idp.clientID ||
+		query.Get("code_challenge") == "" || query.Get("code_challenge_method") != "S256" {
+		http.Error(w, "expected a code request from "+idp.clientID+" with an S256 code_challenge", http.StatusBadRequest)
+		return
+	}
+
+	code, err := utils.GenerateSecureToken()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	email := query.Get("login_hint")
+	if email == "" {
+		email = idp.email
+	}
+
+	idp.mu.Lock()
+	idp.codes[code] = authorization{
+		clientID:      idp.clientID,
+		redirectURI:   redirectURI.String(),
+		codeChallenge: query.Get("code_challenge"),
+		nonce:         query.Get("nonce"),
+		email:         email,
+		expiresAt:     time.Now().Add(time.Minute),
+	}
+	idp.mu.Unlock()
+
+	params := redirectURI.Query()
+	params.Set("code", code)
+	params.Set("state", query.Get("state"))
+	redirectURI.RawQuery = params.Encode()
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
+
+func (idp *stubIdP) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		tokenError(w, "invalid_request")
+		return
+	}
+	clientID := r.PostForm.Get("client_id")
+	if user, _, ok := r.BasicAuth(); ok {
+		clientID, _ = url.QueryUnescape(user)
+	}
+
+	code := r.PostForm.Get("code")
+	idp.mu.Lock()
+	grant, found := idp.codes[code]
+	delete(idp.codes, code)
+	idp.mu.Unlock()
+
+	challenge := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	switch {
+	case r.PostForm.Get("grant_type") != "authorization_code":
+		tokenError(w, "unsupported_grant_type")
+		return
+	case !found || time.Now().After(grant.expiresAt) || clientID != grant.clientID ||
+		r.PostForm.Get("redirect_uri") != grant.redirectURI ||
+		base64.RawURLEncoding.EncodeToString(challenge[:]) != grant.codeChallenge:
+		tokenError(w, "invalid_grant")
+		return
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":            idp.issuer,
+		"sub":            "stub|" + grant.email,
+		"aud":            grant.clientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(5 * time.Minute).Unix(),
+		"nonce":          grant.nonce,
+		"email":          grant.email,
+		"email_verified": true,
+		"name":           idp.name,
+		"groups":         idp.groups,
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+	token.Header["kid"] = idp.jwk.KeyID
+	idToken, err := token.SignedString(idp.key)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": "stub-access-token",
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     idToken,
+	})
+}
+
+func tokenError(w http.ResponseWriter, code string) {
+	writeJSON(w, http.StatusBadRequest, map[string]string{"error": code})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Printf("Failed to write response: %v", err)
+	}
+}
@@ -1,54 +1,180 @@
 package auth
 
 import (
+	"crypto"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/amangirdhar210/meeting-room/internal/config"
 )
 
 type JWTGenerator struct {
+	method         jwt.SigningMethod
+	signingKey     any
+	keyID          string
 	secret         []byte
+	keys           map[string]verificationKey
+	keyOrder       []string
 	expirationTime time.Duration
 }
 
-func NewJWTGenerator(secret string, expirationTime time.Duration) *JWTGenerator {
-	return &JWTGenerator{
-		secret:         []byte(secret),
-		expirationTime: expirationTime,
+// NewJWTGenerator signs with the configured algorithm and verifies with the
+// signing key, the extra verification keys and, when set, the HS256 secret.
+// It fails when no signing key is configured.
+func NewJWTGenerator(cfg config.JWTConfig) (*JWTGenerator, error) {
+	j := &JWTGenerator{
+		keys:           make(map[string]verificationKey),
+		expirationTime: cfg.ExpirationTime,
+	}
+	if cfg.Secret != "" {
+		j.secret = []byte(cfg.Secret)
+	}
+
+	switch cfg.Algorithm {
+	case "HS256":
+		if j.secret == nil {
+			return nil, errors.New("JWT_SECRET is required for HS256")
+		}
+		j.method = jwt.SigningMethodHS256
+		j.signingKey = j.secret
+	case "RS256", "ES256":
+		data, err := readPEM(cfg.PrivateKey, cfg.PrivateKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		if data == nil {
+			return nil, fmt.Errorf("JWT_PRIVATE_KEY or JWT_PRIVATE_KEY_FILE is required for %s", cfg.Algorithm)
+		}
+		signer, err := parsePrivateKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWT private key: %w", err)
+		}
+		key, err := j.addKey(signer.Public())
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWT private key: %w", err)
+		}
+		if key.method.Alg() != cfg.Algorithm {
+			return nil, fmt.Errorf("JWT private key is a %s key, not %s", key.method.Alg(), cfg.Algorithm)
+		}
+		j.method = key.method
+		j.signingKey = signer
+		j.keyID = key.jwk.KeyID
+	default:
+		return nil, fmt.Errorf("unsupported JWT_ALGORITHM %q", cfg.Algorithm)
+	}
+
+	data, err := readPEM(cfg.VerificationKeys, cfg.VerificationKeysFile)
+	if err != nil {
+		return nil, err
+	}
+	publicKeys, err := parsePublicKeys(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWT verification keys: %w", err)
+	}
+	for _, publicKey := range publicKeys {
+		if _, err := j.addKey(publicKey); err != nil {
+			return nil, fmt.Errorf("invalid JWT verification key: %w", err)
+		}
+	}
+
+	return j, nil
+}
+
+func (j *JWTGenerator) addKey(publicKey crypto.PublicKey) (verificationKey, error) {
+	key, err := newVerificationKey(publicKey)
+	if err != nil {
+		return verificationKey{}, err
+	}
+	if _, exists := j.keys[key.jwk.KeyID]; !exists {
+		j.keys[key.jwk.KeyID] = key
+		j.keyOrder = append(j.keyOrder, key.jwk.KeyID)
 	}
+	return key, nil
 }
 
+// Claims carries the session ID (sid) next to the token's own ID (jti) so a
+// token can be revoked on its own or together with its whole session.
 type Claims struct {
-	UserID string `json:"user_id"`
-	Role   string `json:"role"`
+	UserID    string `json:"user_id"`
+	Role      string `json:"role"`
+	SessionID string `json:"sid"`
 	jwt.RegisteredClaims
 }
 
-func (j *JWTGenerator) GenerateToken(userID string, role string) (string, error) {
-	expirationTime := time.Now().Add(j.expirationTime)
+func (j *JWTGenerator) GenerateToken(userID, role, sessionID string) (string, time.Time, error) {
+	now := time.Now()
+	expirationTime := now.Add(j.expirationTime)
 	claims := &Claims{
-		UserID: userID,
-		Role:   role,
+		UserID:    userID,
+		Role:      role,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			IssuedAt:  jwt.NewNumericDate(now),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(j.secret)
+	token := jwt.NewWithClaims(j.method, claims)
+	if j.keyID != "" {
+		token.Header["kid"] = j.keyID
+	}
+	signed, err := token.SignedString(j.signingKey)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return signed, expirationTime, nil
 }
 
 func (j *JWTGenerator) ValidateToken(tokenStr string) (*Claims, error) {
 	claims := &Claims{}
 
-	token, err := jwt.ParseWithClaims(tokenStr, claims, func(token *jwt.Token) (any, error) {
-		return j.secret, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenStr, claims, j.verificationKey,
+		jwt.WithValidMethods([]string{"HS256", "RS256", "ES256"}), jwt.WithExpirationRequired())
 
 	if err != nil || !token.Valid {
 		return nil, err
 	}
+	// Tokens without an ID or session cannot be revoked, so they are refused.
+	if claims.ID == "" || claims.SessionID == "" {
+		return nil, errors.New("token has no jti or sid claim")
+	}
 
 	return claims, nil
 }
+
+// verificationKey picks the key by kid and insists the token uses that
+// key's algorithm, so a public key can never be used as an HMAC secret.
+// Tokens without a kid are HS256 tokens signed with the shared secret.
+func (j *JWTGenerator) verificationKey(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		if j.secret == nil || token.Method.Alg() != jwt.SigningMethodHS256.Alg() {
+			return nil, errors.New("token has no usable kid")
+		}
+		return j.secret, nil
+	}
+
+	key, ok := j.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown kid %q", kid)
+	}
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("kid %q does not sign with %s", kid, token.Method.Alg())
+	}
+	return key.key, nil
+}
+
+// JWKS lists the public keys tokens may be verified with. It is empty when
+// tokens are signed with the HS256 secret.
+func (j *JWTGenerator) JWKS() JWKS {
+	jwks := JWKS{Keys: make([]JWK, 0, len(j.keyOrder))}
+	for _, kid := range j.keyOrder {
+		jwks.Keys = append(jwks.Keys, j.keys[kid].jwk)
+	}
+	return jwks
+}
@@ -0,0 +1,217 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// JWK is a public key as published at /.well-known/jwks.json (RFC 7517).
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	Y         string `json:"y,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+type verificationKey struct {
+	method jwt.SigningMethod
+	key    crypto.PublicKey
+	jwk    JWK
+}
+
+// readPEM returns the inline PEM value, falling back to the file. Inline
+// values may use literal \n, since multi-line environment variables are
+// awkward to set.
+func readPEM(value, file string) ([]byte, error) {
+	if strings.TrimSpace(value) != "" {
+		if !strings.Contains(value, "\n") {
+			value = strings.ReplaceAll(value, `\n`, "\n")
+		}
+		return []byte(value), nil
+	}
+	if file == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+	return data, nil
+}
+
+func parsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("private key is not PEM encoded")
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported private key type %T", key)
+		}
+		return signer, nil
+	}
+	return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+}
+
+func parsePublicKeys(data []byte) ([]crypto.PublicKey, error) {
+	var keys []crypto.PublicKey
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		switch block.Type {
+		case "PUBLIC KEY":
+			key, err := x509.ParsePKIXPublicKey(block.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, key)
+		case "RSA PUBLIC KEY":
+			key, err := x509.ParsePKCS1PublicKey(block.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, key)
+		default:
+			return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+		}
+	}
+	if strings.TrimSpace(string(data)) != "" {
+		return nil, errors.New("verification keys contain data that is not PEM encoded")
+	}
+	return keys, nil
+}
+
+// newVerificationKey derives the signing method and JWK of a public key.
+// The key ID is the RFC 7638 thumbprint, so it stays the same when a key
+// moves from signing to verification-only during rotation.
+func newVerificationKey(key crypto.PublicKey) (verificationKey, error) {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		if k.N.BitLen() < 2048 {
+			return verificationKey{}, errors.New("RSA keys must be at least 2048 bits")
+		}
+		jwk := JWK{
+			KeyType:   "RSA",
+			Use:       "sig",
+			Algorithm: jwt.SigningMethodRS256.Alg(),
+			N:         base64.RawURLEncoding.EncodeToString(k.N.Bytes()),
+			E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes()),
+		}
+		jwk.KeyID = thumbprint(map[string]string{"e": jwk.E, "kty": jwk.KeyType, "n": jwk.N})
+		return verificationKey{method: jwt.SigningMethodRS256, key: k, jwk: jwk}, nil
+	case *ecdsa.PublicKey:
+		if k.Curve != elliptic.P256() {
+			return verificationKey{}, errors.New("EC keys must use the P-256 curve")
+		}
+		jwk := JWK{
+			KeyType:   "EC",
+			Use:       "sig",
+			Algorithm: jwt.SigningMethodES256.Alg(),
+			Curve:     "P-256",
+			X:         base64.RawURLEncoding.EncodeToString(k.X.FillBytes(make([]byte, 32))),
+			Y:         base64.RawURLEncoding.EncodeToString(k.Y.FillBytes(make([]byte, 32))),
+		}
+		jwk.KeyID = thumbprint(map[string]string{"crv": jwk.Curve, "kty": jwk.KeyType, "x": jwk.X, "y": jwk.Y})
+		return verificationKey{method: jwt.SigningMethodES256, key: k, jwk: jwk}, nil
+	}
+	return verificationKey{}, fmt.Errorf("unsupported public key type %T", key)
+}
+
+func thumbprint(members map[string]string) string {
+	// encoding/json sorts map keys, which gives the canonical form.
+	data, _ := json.Marshal(members)
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// PublicJWK describes an RSA (2048 bits or more) or P-256 public key as a
+// JWK, with its thumbprint as the key ID.
+func PublicJWK(key crypto.PublicKey) (JWK, error) {
+	verification, err := newVerificationKey(key)
+	if err != nil {
+		return JWK{}, err
+	}
+	return verification.jwk, nil
+}
+
+// PublicKey decodes an RSA or EC (P-256) JWK.
+func (k JWK) PublicKey() (crypto.PublicKey, error) {
+	decode := base64.RawURLEncoding.DecodeString
+
+	switch k.KeyType {
+	case "RSA":
+		n, err := decode(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA modulus: %w", err)
+		}
+		e, err := decode(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA exponent: %w", err)
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() > 1<<31-1 || exponent.Int64() < 3 {
+			return nil, errors.New("invalid RSA exponent")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+	case "EC":
+		if k.Curve != "P-256" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Curve)
+		}
+		x, err := decode(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EC x coordinate: %w", err)
+		}
+		y, err := decode(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EC y coordinate: %w", err)
+		}
+		if len(x) != 32 || len(y) != 32 {
+			return nil, errors.New("invalid EC coordinates")
+		}
+		point := append(append([]byte{4}, x...), y...)
+		if _, err := ecdh.P256().NewPublicKey(point); err != nil {
+			return nil, fmt.Errorf("invalid EC point: %w", err)
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	}
+	return nil, fmt.Errorf("unsupported key type %q", k.KeyType)
+}
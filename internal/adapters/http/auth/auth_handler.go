@@ -5,16 +5,20 @@ import (
 	"net/http"
 
 	httputil "github.com/amangirdhar210/meeting-room/internal/adapters/httpUtils"
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
 	"github.com/amangirdhar210/meeting-room/internal/core/service"
 	"github.com/amangirdhar210/meeting-room/internal/http/dto"
 )
 
 type Handler struct {
-	authService service.AuthService
+	authService  service.AuthService
+	resetService service.PasswordResetService
+	oidcService  service.OIDCService
 }
 
-func NewHandler(authService service.AuthService) *Handler {
-	return &Handler{authService: authService}
+// NewHandler takes a nil oidcService when single sign-on is not configured.
+func NewHandler(authService service.AuthService, resetService service.PasswordResetService, oidcService service.OIDCService) *Handler {
+	return &Handler{authService: authService, resetService: resetService, oidcService: oidcService}
 }
 
 func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
@@ -24,14 +28,52 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token, user, err := h.authService.Login(req.Email, req.Password)
+	tokens, user, err := h.authService.Login(req.Email, req.Password)
 	if err != nil {
 		httputil.HandleError(w, err)
 		return
 	}
 
-	resp := dto.LoginUserResponse{
-		Token: token,
+	httputil.RespondWithJSON(w, http.StatusOK, loginResponse(tokens, user))
+}
+
+// OIDCAuthorize starts a single sign-on. The client sends the user to the
+// returned URL and, once the identity provider redirects back, passes the
+// code and state to OIDCCallback.
+func (h *Handler) OIDCAuthorize(w http.ResponseWriter, r *http.Request) {
+	authURL, state, err := h.oidcService.BeginLogin()
+	if err != nil {
+		httputil.HandleError(w, err)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, dto.OIDCAuthorizeResponse{
+		AuthorizationURL: authURL,
+		State:            state,
+	})
+}
+
+func (h *Handler) OIDCCallback(w http.ResponseWriter, r *http.Request) {
+	var req dto.OIDCCallbackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	tokens, user, err := h.oidcService.CompleteLogin(req.State, req.Code)
+	if err != nil {
+		httputil.HandleError(w, err)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, loginResponse(tokens, user))
+}
+
+func loginResponse(tokens *domain.AuthTokens, user *domain.User) dto.LoginUserResponse {
+	return dto.LoginUserResponse{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    tokens.ExpiresIn,
 		User: dto.UserDTO{
 			ID:    user.ID,
 			Name:  user.Name,
@@ -39,6 +81,71 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 			Role:  user.Role,
 		},
 	}
+}
+
+func (h *Handler) Refresh(w http.ResponseWriter, r *http.Request) {
+	var req dto.RefreshTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	tokens, err := h.authService.Refresh(req.RefreshToken)
+	if err != nil {
+		httputil.HandleError(w, err)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, dto.RefreshTokenResponse{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    tokens.ExpiresIn,
+	})
+}
+
+func (h *Handler) Logout(w http.ResponseWriter, r *http.Request) {
+	claims, ok := httputil.GetAccessTokenClaims(r.Context())
+	if !ok {
+		httputil.RespondWithError(w, http.StatusUnauthorized, "unauthorized access")
+		return
+	}
+
+	if err := h.authService.Logout(claims); err != nil {
+		httputil.HandleError(w, err)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, dto.GenericResponse{Message: "logged out"})
+}
+
+func (h *Handler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	var req dto.ForgotPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.resetService.RequestReset(req.Email); err != nil {
+		httputil.HandleError(w, err)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusAccepted, dto.GenericResponse{
+		Message: "if an account exists for that email, a reset link has been sent",
+	})
+}
+
+func (h *Handler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var req dto.ResetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.resetService.ResetPassword(req.Token, req.NewPassword); err != nil {
+		httputil.HandleError(w, err)
+		return
+	}
 
-	httputil.RespondWithJSON(w, http.StatusOK, resp)
+	httputil.RespondWithJSON(w, http.StatusOK, dto.GenericResponse{Message: "password has been reset"})
 }
@@ -2,7 +2,10 @@ package booking
 
 import (
 	"encoding/json"
+	"io"
+	"mime"
 	"net/http"
+	"strconv"
 	"time"
 
 	httputil "github.com/amangirdhar210/meeting-room/internal/adapters/httpUtils"
@@ -12,16 +15,48 @@ import (
 	"github.com/gorilla/mux"
 )
 
+// maxImportBytes limits the size of an uploaded .ics file.
+const maxImportBytes = 5 << 20
+
 type Handler struct {
-	bookingService service.BookingService
+	bookingService  service.BookingService
+	seriesService   service.BookingSeriesService
+	groupService    service.BookingGroupService
+	importService   service.BookingImportService
+	waitlistService service.WaitlistService
+	approvalService service.ApprovalService
+	checkInService  service.CheckInService
+}
+
+func NewHandler(bookingService service.BookingService, seriesService service.BookingSeriesService, groupService service.BookingGroupService, importService service.BookingImportService, waitlistService service.WaitlistService, approvalService service.ApprovalService, checkInService service.CheckInService) *Handler {
+	return &Handler{bookingService: bookingService, seriesService: seriesService, groupService: groupService, importService: importService, waitlistService: waitlistService, approvalService: approvalService, checkInService: checkInService}
 }
 
-func NewHandler(bookingService service.BookingService) *Handler {
-	return &Handler{bookingService: bookingService}
+func toBookingDTO(b domain.Booking) dto.BookingDTO {
+	return dto.BookingDTO{
+		ID:                 b.ID,
+		UserID:             b.UserID,
+		RoomID:             b.RoomID,
+		StartTime:          b.StartTime,
+		EndTime:            b.EndTime,
+		Purpose:            b.Purpose,
+		Status:             b.Status,
+		CancelledAt:        b.CancelledAt,
+		CancelledBy:        b.CancelledBy,
+		CancellationReason: b.CancellationReason,
+		SeriesID:           b.SeriesID,
+		GroupID:            b.GroupID,
+		ApprovalExpiresAt:  b.ApprovalExpiresAt,
+		ReviewedBy:         b.ReviewedBy,
+		ReviewedAt:         b.ReviewedAt,
+		ReviewNote:         b.ReviewNote,
+		CheckedInAt:        b.CheckedInAt,
+		ReleasedAt:         b.ReleasedAt,
+	}
 }
 
 func (h *Handler) CreateBooking(w http.ResponseWriter, r *http.Request) {
-	userID, _, ok := httputil.GetUserIDRole(r.Context())
+	userID, role, ok := httputil.GetUserIDRole(r.Context())
 	if !ok {
 		httputil.RespondWithError(w, http.StatusUnauthorized, "unauthorized")
 		return
@@ -52,11 +87,15 @@ func (h *Handler) CreateBooking(w http.ResponseWriter, r *http.Request) {
 		Purpose:   req.Purpose,
 	}
 
-	if err := h.bookingService.CreateBooking(booking); err != nil {
+	if err := h.bookingService.CreateBooking(booking, role); err != nil {
 		httputil.HandleError(w, err)
 		return
 	}
 
+	if booking.Status == domain.BookingStatusPending {
+		httputil.RespondWithJSON(w, http.StatusCreated, dto.GenericResponse{Message: "booking request sent for approval"})
+		return
+	}
 	httputil.RespondWithJSON(w, http.StatusCreated, dto.GenericResponse{Message: "booking created successfully"})
 }
 
@@ -67,12 +106,23 @@ func (h *Handler) GetAllBookings(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	status := r.URL.Query().Get("status")
+	if status != "" && !domain.IsValidBookingStatus(status) {
+		httputil.RespondWithError(w, http.StatusBadRequest, "invalid status, use confirmed, pending, cancelled, completed, no_show, rejected or expired")
+		return
+	}
+
 	var bookings []domain.Booking
 	var err error
 
-	if role == "admin" {
+	switch {
+	case domain.HasPermission(role, domain.PermBookingsViewAny) && status != "":
+		bookings, err = h.bookingService.GetBookingsByStatus(status)
+	case domain.HasPermission(role, domain.PermBookingsViewAny):
 		bookings, err = h.bookingService.GetAllBookings()
-	} else {
+	case status != "":
+		bookings, err = h.bookingService.GetBookingsByUserIDAndStatus(userID, status)
+	default:
 		bookings, err = h.bookingService.GetBookingsByUserID(userID)
 	}
 
@@ -85,21 +135,97 @@ func (h *Handler) GetAllBookings(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var resp []dto.BookingDTO
+	resp := make([]dto.BookingDTO, 0, len(bookings))
 	for _, b := range bookings {
-		resp = append(resp, dto.BookingDTO{
-			ID:        b.ID,
-			UserID:    b.UserID,
-			RoomID:    b.RoomID,
-			StartTime: b.StartTime,
-			EndTime:   b.EndTime,
-			Purpose:   b.Purpose,
-		})
+		resp = append(resp, toBookingDTO(b))
 	}
 
 	httputil.RespondWithJSON(w, http.StatusOK, resp)
 }
 
+func (h *Handler) UpdateBooking(w http.ResponseWriter, r *http.Request) {
+	userID, role, ok := httputil.GetUserIDRole(r.Context())
+	if !ok {
+		httputil.RespondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	vars := mux.Vars(r)
+	bookingID := vars["id"]
+	if bookingID == "" {
+		httputil.RespondWithError(w, http.StatusBadRequest, "invalid booking id")
+		return
+	}
+
+	var req dto.UpdateBookingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	update := domain.BookingUpdate{RoomID: req.RoomID, Purpose: req.Purpose}
+	if req.StartTime != nil {
+		startTime, err := time.Parse(time.RFC3339, *req.StartTime)
+		if err != nil {
+			httputil.RespondWithError(w, http.StatusBadRequest, "invalid start_time format")
+			return
+		}
+		start := startTime.Unix()
+		update.StartTime = &start
+	}
+	if req.EndTime != nil {
+		endTime, err := time.Parse(time.RFC3339, *req.EndTime)
+		if err != nil {
+			httputil.RespondWithError(w, http.StatusBadRequest, "invalid end_time format")
+			return
+		}
+		end := endTime.Unix()
+		update.EndTime = &end
+	}
+
+	if !domain.HasPermission(role, domain.PermBookingsManageAny) {
+		booking, err := h.bookingService.GetBookingByID(bookingID)
+		if err != nil {
+			if err == domain.ErrNotFound {
+				httputil.RespondWithError(w, http.StatusNotFound, "booking not found")
+			} else {
+				httputil.HandleError(w, err)
+			}
+			return
+		}
+		if booking.UserID != userID {
+			httputil.RespondWithError(w, http.StatusForbidden, "forbidden: you can only modify your own bookings")
+			return
+		}
+	}
+
+	if scope := r.URL.Query().Get("scope"); scope != "" {
+		if !domain.IsValidSeriesScope(scope) {
+			httputil.RespondWithError(w, http.StatusBadRequest, "invalid scope, use this, following or all")
+			return
+		}
+		result, err := h.seriesService.UpdateOccurrences(bookingID, scope, update, role)
+		if err == domain.ErrRoomUnavailable && result != nil {
+			httputil.RespondWithJSON(w, http.StatusConflict, toSeriesResponse(result))
+			return
+		}
+		if err != nil {
+			httputil.HandleError(w, err)
+			return
+		}
+		httputil.RespondWithJSON(w, http.StatusOK, toSeriesResponse(result))
+		return
+	}
+
+	booking, err := h.bookingService.UpdateBooking(bookingID, update, role)
+	if err != nil {
+		httputil.HandleError(w, err)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, toBookingDTO(*booking))
+}
+
 func (h *Handler) CancelBooking(w http.ResponseWriter, r *http.Request) {
 	userID, role, ok := httputil.GetUserIDRole(r.Context())
 	if !ok {
@@ -114,7 +240,7 @@ func (h *Handler) CancelBooking(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if role != "admin" {
+	if !domain.HasPermission(role, domain.PermBookingsManageAny) {
 		booking, err := h.bookingService.GetBookingByID(bookingID)
 		if err != nil {
 			if err == domain.ErrNotFound {
@@ -130,7 +256,31 @@ func (h *Handler) CancelBooking(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	if err := h.bookingService.CancelBooking(bookingID); err != nil {
+	var req dto.CancelBookingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		httputil.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if scope := r.URL.Query().Get("scope"); scope != "" {
+		if !domain.IsValidSeriesScope(scope) {
+			httputil.RespondWithError(w, http.StatusBadRequest, "invalid scope, use this, following or all")
+			return
+		}
+		cancelled, err := h.seriesService.CancelOccurrences(bookingID, scope, userID, req.Reason)
+		if err != nil {
+			httputil.HandleError(w, err)
+			return
+		}
+		resp := make([]dto.BookingDTO, 0, len(cancelled))
+		for _, b := range cancelled {
+			resp = append(resp, toBookingDTO(b))
+		}
+		httputil.RespondWithJSON(w, http.StatusOK, resp)
+		return
+	}
+
+	if err := h.bookingService.CancelBooking(bookingID, userID, req.Reason); err != nil {
 		httputil.HandleError(w, err)
 		return
 	}
@@ -138,6 +288,364 @@ func (h *Handler) CancelBooking(w http.ResponseWriter, r *http.Request) {
 	httputil.RespondWithJSON(w, http.StatusOK, dto.GenericResponse{Message: "booking canceled successfully"})
 }
 
+func toSeriesResponse(result *domain.SeriesResult) dto.BookingSeriesResponse {
+	resp := dto.BookingSeriesResponse{
+		Bookings:  make([]dto.BookingDTO, 0, len(result.Bookings)),
+		Conflicts: make([]dto.OccurrenceConflictDTO, 0, len(result.Conflicts)),
+	}
+	if series := result.Series; series != nil && series.ID != "" {
+		resp.Series = &dto.BookingSeriesDTO{
+			ID:        series.ID,
+			UserID:    series.UserID,
+			RoomID:    series.RoomID,
+			Purpose:   series.Purpose,
+			RRule:     series.RRule,
+			Timezone:  series.Timezone,
+			StartTime: series.StartTime,
+			EndTime:   series.EndTime,
+			ExDates:   series.ExDates,
+			Status:    series.Status,
+		}
+	}
+	for _, b := range result.Bookings {
+		resp.Bookings = append(resp.Bookings, toBookingDTO(b))
+	}
+	for _, c := range result.Conflicts {
+		resp.Conflicts = append(resp.Conflicts, dto.OccurrenceConflictDTO{
+			StartTime:             c.StartTime,
+			EndTime:               c.EndTime,
+			ConflictingBookingIDs: c.ConflictingBookingIDs,
+			ConflictingBlockIDs:   c.ConflictingBlockIDs,
+		})
+	}
+	return resp
+}
+
+func (h *Handler) CreateSeries(w http.ResponseWriter, r *http.Request) {
+	userID, _, ok := httputil.GetUserIDRole(r.Context())
+	if !ok {
+		httputil.RespondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req dto.CreateBookingSeriesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	startTime, err := time.Parse(time.RFC3339, req.StartTime)
+	if err != nil {
+		httputil.RespondWithError(w, http.StatusBadRequest, "invalid start_time format")
+		return
+	}
+	endTime, err := time.Parse(time.RFC3339, req.EndTime)
+	if err != nil {
+		httputil.RespondWithError(w, http.StatusBadRequest, "invalid end_time format")
+		return
+	}
+
+	exdates := make([]int64, 0, len(req.ExDates))
+	for _, value := range req.ExDates {
+		exdate, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			httputil.RespondWithError(w, http.StatusBadRequest, "invalid exdates format")
+			return
+		}
+		exdates = append(exdates, exdate.Unix())
+	}
+
+	series := &domain.BookingSeries{
+		UserID:    userID,
+		RoomID:    req.RoomID,
+		Purpose:   req.Purpose,
+		RRule:     req.RRule,
+		Timezone:  req.Timezone,
+		StartTime: startTime.Unix(),
+		EndTime:   endTime.Unix(),
+		ExDates:   exdates,
+	}
+
+	result, err := h.seriesService.CreateSeries(series, req.SkipConflicts)
+	if err == domain.ErrRoomUnavailable && result != nil {
+		httputil.RespondWithJSON(w, http.StatusConflict, toSeriesResponse(result))
+		return
+	}
+	if err != nil {
+		httputil.HandleError(w, err)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusCreated, toSeriesResponse(result))
+}
+
+func (h *Handler) GetSeries(w http.ResponseWriter, r *http.Request) {
+	userID, role, ok := httputil.GetUserIDRole(r.Context())
+	if !ok {
+		httputil.RespondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	vars := mux.Vars(r)
+	seriesID := vars["id"]
+	if seriesID == "" {
+		httputil.RespondWithError(w, http.StatusBadRequest, "invalid series id")
+		return
+	}
+
+	series, occurrences, err := h.seriesService.GetSeries(seriesID)
+	if err != nil {
+		httputil.HandleError(w, err)
+		return
+	}
+	if !domain.HasPermission(role, domain.PermBookingsViewAny) && series.UserID != userID {
+		httputil.RespondWithError(w, http.StatusForbidden, "forbidden: you can only view your own series")
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, toSeriesResponse(&domain.SeriesResult{Series: series, Bookings: occurrences}))
+}
+
+func toGroupResponse(result *domain.GroupResult) dto.BookingGroupResponse {
+	resp := dto.BookingGroupResponse{
+		GroupID:   result.GroupID,
+		Bookings:  make([]dto.BookingDTO, 0, len(result.Bookings)),
+		Conflicts: make([]dto.RoomConflictDTO, 0, len(result.Conflicts)),
+	}
+	for _, b := range result.Bookings {
+		resp.Bookings = append(resp.Bookings, toBookingDTO(b))
+	}
+	for _, c := range result.Conflicts {
+		resp.Conflicts = append(resp.Conflicts, dto.RoomConflictDTO{
+			RoomID:                c.RoomID,
+			Reason:                c.Reason.Error(),
+			ConflictingBookingIDs: c.ConflictingBookingIDs,
+			ConflictingBlockIDs:   c.ConflictingBlockIDs,
+		})
+	}
+	return resp
+}
+
+func (h *Handler) CreateGroup(w http.ResponseWriter, r *http.Request) {
+	userID, _, ok := httputil.GetUserIDRole(r.Context())
+	if !ok {
+		httputil.RespondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req dto.CreateBookingGroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	startTime, err := time.Parse(time.RFC3339, req.StartTime)
+	if err != nil {
+		httputil.RespondWithError(w, http.StatusBadRequest, "invalid start_time format")
+		return
+	}
+	endTime, err := time.Parse(time.RFC3339, req.EndTime)
+	if err != nil {
+		httputil.RespondWithError(w, http.StatusBadRequest, "invalid end_time format")
+		return
+	}
+
+	group := &domain.BookingGroup{
+		UserID:    userID,
+		RoomIDs:   req.RoomIDs,
+		StartTime: startTime.Unix(),
+		EndTime:   endTime.Unix(),
+		Purpose:   req.Purpose,
+	}
+
+	result, err := h.groupService.CreateGroup(group)
+	if err == domain.ErrRoomUnavailable && result != nil {
+		httputil.RespondWithJSON(w, http.StatusConflict, toGroupResponse(result))
+		return
+	}
+	if err != nil {
+		httputil.HandleError(w, err)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusCreated, toGroupResponse(result))
+}
+
+func (h *Handler) GetGroup(w http.ResponseWriter, r *http.Request) {
+	userID, role, ok := httputil.GetUserIDRole(r.Context())
+	if !ok {
+		httputil.RespondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	groupID := mux.Vars(r)["id"]
+	bookings, ok := h.loadGroup(w, groupID, userID, role, domain.PermBookingsViewAny, "forbidden: you can only view your own group bookings")
+	if !ok {
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, toGroupResponse(&domain.GroupResult{GroupID: groupID, Bookings: bookings}))
+}
+
+func (h *Handler) UpdateGroup(w http.ResponseWriter, r *http.Request) {
+	userID, role, ok := httputil.GetUserIDRole(r.Context())
+	if !ok {
+		httputil.RespondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	groupID := mux.Vars(r)["id"]
+	if _, ok := h.loadGroup(w, groupID, userID, role, domain.PermBookingsManageAny, "forbidden: you can only modify your own group bookings"); !ok {
+		return
+	}
+
+	var req dto.UpdateBookingGroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	update := domain.BookingUpdate{Purpose: req.Purpose}
+	if req.StartTime != nil {
+		startTime, err := time.Parse(time.RFC3339, *req.StartTime)
+		if err != nil {
+			httputil.RespondWithError(w, http.StatusBadRequest, "invalid start_time format")
+			return
+		}
+		start := startTime.Unix()
+		update.StartTime = &start
+	}
+	if req.EndTime != nil {
+		endTime, err := time.Parse(time.RFC3339, *req.EndTime)
+		if err != nil {
+			httputil.RespondWithError(w, http.StatusBadRequest, "invalid end_time format")
+			return
+		}
+		end := endTime.Unix()
+		update.EndTime = &end
+	}
+
+	result, err := h.groupService.UpdateGroup(groupID, update, role)
+	if err == domain.ErrRoomUnavailable && result != nil {
+		httputil.RespondWithJSON(w, http.StatusConflict, toGroupResponse(result))
+		return
+	}
+	if err != nil {
+		httputil.HandleError(w, err)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, toGroupResponse(result))
+}
+
+func (h *Handler) CancelGroup(w http.ResponseWriter, r *http.Request) {
+	userID, role, ok := httputil.GetUserIDRole(r.Context())
+	if !ok {
+		httputil.RespondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	groupID := mux.Vars(r)["id"]
+	if _, ok := h.loadGroup(w, groupID, userID, role, domain.PermBookingsManageAny, "forbidden: you can only cancel your own group bookings"); !ok {
+		return
+	}
+
+	var req dto.CancelBookingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		httputil.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	cancelled, err := h.groupService.CancelGroup(groupID, userID, req.Reason)
+	if err != nil {
+		httputil.HandleError(w, err)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, toGroupResponse(&domain.GroupResult{GroupID: groupID, Bookings: cancelled}))
+}
+
+// loadGroup returns the bookings of a group the caller owns or, with
+// permission, any group, and responds with the error otherwise.
+func (h *Handler) loadGroup(w http.ResponseWriter, groupID, userID, role string, permission domain.Permission, forbidden string) ([]domain.Booking, bool) {
+	if groupID == "" {
+		httputil.RespondWithError(w, http.StatusBadRequest, "invalid group id")
+		return nil, false
+	}
+
+	bookings, err := h.groupService.GetGroup(groupID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			httputil.RespondWithError(w, http.StatusNotFound, "group booking not found")
+		} else {
+			httputil.HandleError(w, err)
+		}
+		return nil, false
+	}
+	if !domain.HasPermission(role, permission) && bookings[0].UserID != userID {
+		httputil.RespondWithError(w, http.StatusForbidden, forbidden)
+		return nil, false
+	}
+	return bookings, true
+}
+
+func (h *Handler) ImportBookings(w http.ResponseWriter, r *http.Request) {
+	_, role, ok := httputil.GetUserIDRole(r.Context())
+	if !ok {
+		httputil.RespondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	dryRun := false
+	if value := r.URL.Query().Get("dry_run"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			httputil.RespondWithError(w, http.StatusBadRequest, "invalid dry_run value")
+			return
+		}
+		dryRun = parsed
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportBytes)
+	var data io.Reader = r.Body
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "multipart/form-data" {
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			httputil.RespondWithError(w, http.StatusBadRequest, "missing .ics file in form field \"file\"")
+			return
+		}
+		defer file.Close()
+		data = file
+	}
+
+	report, err := h.importService.ImportICS(data, dryRun, role)
+	if err != nil {
+		httputil.HandleError(w, err)
+		return
+	}
+
+	resp := dto.ImportReportDTO{
+		DryRun:  report.DryRun,
+		Summary: report.Counts,
+		Results: make([]dto.ImportResultDTO, 0, len(report.Results)),
+	}
+	for _, result := range report.Results {
+		resp.Results = append(resp.Results, dto.ImportResultDTO{
+			UID:       result.UID,
+			Summary:   result.Summary,
+			StartTime: result.StartTime,
+			EndTime:   result.EndTime,
+			RoomID:    result.RoomID,
+			UserID:    result.UserID,
+			BookingID: result.BookingID,
+			Status:    result.Status,
+			Message:   result.Message,
+		})
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, resp)
+}
+
 func (h *Handler) GetMyBookings(w http.ResponseWriter, r *http.Request) {
 	userID, _, ok := httputil.GetUserIDRole(r.Context())
 	if !ok {
@@ -145,7 +653,19 @@ func (h *Handler) GetMyBookings(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	bookings, err := h.bookingService.GetBookingsByUserID(userID)
+	status := r.URL.Query().Get("status")
+	if status != "" && !domain.IsValidBookingStatus(status) {
+		httputil.RespondWithError(w, http.StatusBadRequest, "invalid status, use confirmed, pending, cancelled, completed, no_show, rejected or expired")
+		return
+	}
+
+	var bookings []domain.Booking
+	var err error
+	if status != "" {
+		bookings, err = h.bookingService.GetBookingsByUserIDAndStatus(userID, status)
+	} else {
+		bookings, err = h.bookingService.GetBookingsByUserID(userID)
+	}
 	if err != nil {
 		if err == domain.ErrNotFound {
 			httputil.RespondWithJSON(w, http.StatusOK, []dto.BookingDTO{})
@@ -155,16 +675,9 @@ func (h *Handler) GetMyBookings(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var resp []dto.BookingDTO
+	resp := make([]dto.BookingDTO, 0, len(bookings))
 	for _, b := range bookings {
-		resp = append(resp, dto.BookingDTO{
-			ID:        b.ID,
-			UserID:    b.UserID,
-			RoomID:    b.RoomID,
-			StartTime: b.StartTime,
-			EndTime:   b.EndTime,
-			Purpose:   b.Purpose,
-		})
+		resp = append(resp, toBookingDTO(b))
 	}
 
 	httputil.RespondWithJSON(w, http.StatusOK, resp)
@@ -238,3 +751,210 @@ func (h *Handler) GetScheduleByDate(w http.ResponseWriter, r *http.Request) {
 
 	httputil.RespondWithJSON(w, http.StatusOK, schedule)
 }
+
+func toWaitlistEntryDTO(e domain.WaitlistEntry) dto.WaitlistEntryDTO {
+	return dto.WaitlistEntryDTO{
+		ID:             e.ID,
+		UserID:         e.UserID,
+		RoomID:         e.RoomID,
+		StartTime:      e.StartTime,
+		EndTime:        e.EndTime,
+		Purpose:        e.Purpose,
+		AutoBook:       e.AutoBook,
+		Status:         e.Status,
+		BookingID:      e.BookingID,
+		OfferExpiresAt: e.OfferExpiresAt,
+		CreatedAt:      e.CreatedAt,
+	}
+}
+
+func (h *Handler) JoinWaitlist(w http.ResponseWriter, r *http.Request) {
+	userID, _, ok := httputil.GetUserIDRole(r.Context())
+	if !ok {
+		httputil.RespondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req dto.JoinWaitlistRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	startTime, err := time.Parse(time.RFC3339, req.StartTime)
+	if err != nil {
+		httputil.RespondWithError(w, http.StatusBadRequest, "invalid start_time format")
+		return
+	}
+	endTime, err := time.Parse(time.RFC3339, req.EndTime)
+	if err != nil {
+		httputil.RespondWithError(w, http.StatusBadRequest, "invalid end_time format")
+		return
+	}
+
+	entry := &domain.WaitlistEntry{
+		UserID:    userID,
+		RoomID:    req.RoomID,
+		StartTime: startTime.Unix(),
+		EndTime:   endTime.Unix(),
+		Purpose:   req.Purpose,
+		AutoBook:  req.AutoBook,
+	}
+	if err := h.waitlistService.Join(entry); err != nil {
+		httputil.HandleError(w, err)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusCreated, toWaitlistEntryDTO(*entry))
+}
+
+func (h *Handler) GetMyWaitlist(w http.ResponseWriter, r *http.Request) {
+	userID, _, ok := httputil.GetUserIDRole(r.Context())
+	if !ok {
+		httputil.RespondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	entries, err := h.waitlistService.GetByUserID(userID)
+	if err != nil {
+		httputil.HandleError(w, err)
+		return
+	}
+
+	resp := make([]dto.WaitlistEntryDTO, 0, len(entries))
+	for _, entry := range entries {
+		resp = append(resp, toWaitlistEntryDTO(entry))
+	}
+	httputil.RespondWithJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) ClaimWaitlistEntry(w http.ResponseWriter, r *http.Request) {
+	userID, _, ok := httputil.GetUserIDRole(r.Context())
+	if !ok {
+		httputil.RespondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	booking, err := h.waitlistService.Claim(mux.Vars(r)["id"], userID)
+	if err != nil {
+		httputil.HandleError(w, err)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusCreated, toBookingDTO(*booking))
+}
+
+func (h *Handler) LeaveWaitlist(w http.ResponseWriter, r *http.Request) {
+	userID, _, ok := httputil.GetUserIDRole(r.Context())
+	if !ok {
+		httputil.RespondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	if err := h.waitlistService.Leave(mux.Vars(r)["id"], userID); err != nil {
+		httputil.HandleError(w, err)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, dto.GenericResponse{Message: "left the waitlist"})
+}
+
+// GetPendingApprovals lists the pending bookings the caller may approve or
+// reject, the ones closest to expiring first.
+func (h *Handler) GetPendingApprovals(w http.ResponseWriter, r *http.Request) {
+	userID, _, ok := httputil.GetUserIDRole(r.Context())
+	if !ok {
+		httputil.RespondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	pending, err := h.approvalService.GetPending(userID)
+	if err != nil {
+		httputil.HandleError(w, err)
+		return
+	}
+
+	resp := make([]dto.PendingApprovalDTO, 0, len(pending))
+	for _, booking := range pending {
+		resp = append(resp, dto.PendingApprovalDTO{
+			DetailedBookingDTO: dto.DetailedBookingDTO{
+				ID:         booking.ID,
+				UserID:     booking.UserID,
+				UserName:   booking.UserName,
+				UserEmail:  booking.UserEmail,
+				RoomID:     booking.RoomID,
+				RoomName:   booking.RoomName,
+				RoomNumber: booking.RoomNumber,
+				StartTime:  booking.StartTime,
+				EndTime:    booking.EndTime,
+				Duration:   int((booking.EndTime - booking.StartTime) / 60),
+				Purpose:    booking.Purpose,
+				Status:     booking.Status,
+			},
+			ApprovalExpiresAt: booking.ApprovalExpiresAt,
+		})
+	}
+	httputil.RespondWithJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) ApproveBooking(w http.ResponseWriter, r *http.Request) {
+	h.reviewBooking(w, r, h.approvalService.Approve)
+}
+
+func (h *Handler) RejectBooking(w http.ResponseWriter, r *http.Request) {
+	h.reviewBooking(w, r, h.approvalService.Reject)
+}
+
+func (h *Handler) reviewBooking(w http.ResponseWriter, r *http.Request, decide func(bookingID, approverID, note string) (*domain.Booking, error)) {
+	userID, _, ok := httputil.GetUserIDRole(r.Context())
+	if !ok {
+		httputil.RespondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req dto.ReviewBookingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		httputil.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	booking, err := decide(mux.Vars(r)["id"], userID, req.Note)
+	if err != nil {
+		httputil.HandleError(w, err)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, toBookingDTO(*booking))
+}
+
+func (h *Handler) CheckIn(w http.ResponseWriter, r *http.Request) {
+	userID, _, ok := httputil.GetUserIDRole(r.Context())
+	if !ok {
+		httputil.RespondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	booking, err := h.checkInService.CheckIn(mux.Vars(r)["id"], userID)
+	if err != nil {
+		httputil.HandleError(w, err)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, toBookingDTO(*booking))
+}
+
+// KioskCheckIn serves check-ins from a room's kiosk, which authenticates
+// with its kiosk token rather than a JWT.
+func (h *Handler) KioskCheckIn(w http.ResponseWriter, r *http.Request) {
+	booking, err := h.checkInService.KioskCheckIn(mux.Vars(r)["id"], r.Header.Get(dto.KioskTokenHeader))
+	if err != nil {
+		if err == domain.ErrUnauthorized {
+			httputil.RespondWithError(w, http.StatusUnauthorized, "invalid or revoked kiosk token")
+			return
+		}
+		httputil.HandleError(w, err)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, toBookingDTO(*booking))
+}
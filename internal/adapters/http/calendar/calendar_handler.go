@@ -0,0 +1,141 @@
+package calendar
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	httputil "github.com/amangirdhar210/meeting-room/internal/adapters/httpUtils"
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/service"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+	"github.com/amangirdhar210/meeting-room/internal/pkg/ical"
+	"github.com/gorilla/mux"
+)
+
+const prodID = "-//Meeting Room Booking//EN"
+
+type Handler struct {
+	calendarService service.CalendarService
+}
+
+func NewHandler(calendarService service.CalendarService) *Handler {
+	return &Handler{calendarService: calendarService}
+}
+
+func toCalendar(feed *domain.CalendarFeed) ical.Calendar {
+	cal := ical.Calendar{ProdID: prodID, Name: feed.Name}
+	for _, b := range feed.Bookings {
+		event := ical.Event{
+			UID:          b.ID + "@meeting-room",
+			Sequence:     b.Sequence,
+			Start:        time.Unix(b.StartTime, 0),
+			End:          time.Unix(b.EndTime, 0),
+			Summary:      b.Purpose,
+			Status:       ical.StatusConfirmed,
+			Created:      time.Unix(b.CreatedAt, 0),
+			LastModified: time.Unix(b.UpdatedAt, 0),
+		}
+		if event.Summary == "" {
+			event.Summary = "Meeting room booking"
+		}
+		if b.Status == domain.BookingStatusCancelled {
+			event.Status = ical.StatusCancelled
+		}
+		if b.RoomName != "" {
+			event.Location = fmt.Sprintf("%s (Room %d)", b.RoomName, b.RoomNumber)
+		}
+		if b.UserName != "" {
+			event.Description = fmt.Sprintf("Booked by %s <%s>", b.UserName, b.UserEmail)
+		}
+		cal.Events = append(cal.Events, event)
+	}
+	return cal
+}
+
+func respondWithCalendar(w http.ResponseWriter, feed *domain.CalendarFeed) {
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(toCalendar(feed).Marshal())
+}
+
+func (h *Handler) CreateToken(w http.ResponseWriter, r *http.Request) {
+	userID, _, ok := httputil.GetUserIDRole(r.Context())
+	if !ok {
+		httputil.RespondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	secret, token, err := h.calendarService.CreateToken(userID)
+	if err != nil {
+		httputil.HandleError(w, err)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusCreated, dto.CreateCalendarTokenResponse{
+		ID:        token.ID,
+		Token:     secret,
+		UserFeed:  fmt.Sprintf("/api/users/%s/calendar.ics?token=%s", url.PathEscape(userID), url.QueryEscape(secret)),
+		CreatedAt: token.CreatedAt,
+	})
+}
+
+func (h *Handler) ListTokens(w http.ResponseWriter, r *http.Request) {
+	userID, _, ok := httputil.GetUserIDRole(r.Context())
+	if !ok {
+		httputil.RespondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	tokens, err := h.calendarService.ListTokens(userID)
+	if err != nil {
+		httputil.HandleError(w, err)
+		return
+	}
+
+	resp := make([]dto.CalendarTokenDTO, 0, len(tokens))
+	for _, token := range tokens {
+		resp = append(resp, dto.CalendarTokenDTO{ID: token.ID, CreatedAt: token.CreatedAt})
+	}
+	httputil.RespondWithJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	userID, _, ok := httputil.GetUserIDRole(r.Context())
+	if !ok {
+		httputil.RespondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	tokenID := mux.Vars(r)["id"]
+	if tokenID == "" {
+		httputil.RespondWithError(w, http.StatusBadRequest, "invalid token id")
+		return
+	}
+
+	if err := h.calendarService.RevokeToken(userID, tokenID); err != nil {
+		httputil.HandleError(w, err)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, dto.GenericResponse{Message: "calendar token revoked successfully"})
+}
+
+func (h *Handler) GetUserFeed(w http.ResponseWriter, r *http.Request) {
+	feed, err := h.calendarService.GetUserFeed(mux.Vars(r)["id"], r.URL.Query().Get("token"))
+	if err != nil {
+		httputil.HandleError(w, err)
+		return
+	}
+	respondWithCalendar(w, feed)
+}
+
+func (h *Handler) GetRoomFeed(w http.ResponseWriter, r *http.Request) {
+	feed, err := h.calendarService.GetRoomFeed(mux.Vars(r)["id"], r.URL.Query().Get("token"))
+	if err != nil {
+		httputil.HandleError(w, err)
+		return
+	}
+	respondWithCalendar(w, feed)
+}
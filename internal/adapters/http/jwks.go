@@ -0,0 +1,17 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/amangirdhar210/meeting-room/internal/adapters/auth"
+	httputil "github.com/amangirdhar210/meeting-room/internal/adapters/httpUtils"
+)
+
+// JWKSHandler publishes the token verification keys so other services can
+// check access tokens without sharing a secret.
+func JWKSHandler(jwtGen *auth.JWTGenerator) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		httputil.RespondWithJSON(w, http.StatusOK, jwtGen.JWKS())
+	}
+}
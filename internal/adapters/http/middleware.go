@@ -9,6 +9,8 @@ import (
 
 	"github.com/amangirdhar210/meeting-room/internal/adapters/auth"
 	httputil "github.com/amangirdhar210/meeting-room/internal/adapters/httpUtils"
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/service"
 )
 
 func CORSMiddleware(next http.Handler, allowedOrigins []string) http.Handler {
@@ -23,8 +25,8 @@ func CORSMiddleware(next http.Handler, allowedOrigins []string) http.Handler {
 			w.Header().Set("Access-Control-Allow-Origin", origin)
 			w.Header().Set("Vary", "Origin")
 		}
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Kiosk-Token")
 		w.Header().Set("Access-Control-Allow-Credentials", "true")
 
 		if r.Method == "OPTIONS" {
@@ -51,7 +53,7 @@ func LoggingMiddleware(next http.Handler) http.Handler {
 	})
 }
 
-func JWTAuthMiddleware(jwtGen *auth.JWTGenerator) func(http.Handler) http.Handler {
+func JWTAuthMiddleware(jwtGen *auth.JWTGenerator, authService service.AuthService) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			authHeader := r.Header.Get("Authorization")
@@ -67,9 +69,42 @@ func JWTAuthMiddleware(jwtGen *auth.JWTGenerator) func(http.Handler) http.Handle
 				return
 			}
 
+			tokenClaims := domain.AccessTokenClaims{
+				UserID:    claims.UserID,
+				TokenID:   claims.ID,
+				SessionID: claims.SessionID,
+				ExpiresAt: claims.ExpiresAt.Unix(),
+			}
+			revoked, err := authService.IsRevoked(tokenClaims)
+			if err != nil {
+				log.Printf("Failed to check token revocation: %v", err)
+				http.Error(w, `{"error":"internal server error"}`, http.StatusInternalServerError)
+				return
+			}
+			if revoked {
+				http.Error(w, `{"error":"token has been revoked"}`, http.StatusUnauthorized)
+				return
+			}
+
 			ctx := context.WithValue(r.Context(), httputil.UserIDKey, claims.UserID)
 			ctx = context.WithValue(ctx, httputil.UserRoleKey, claims.Role)
+			ctx = context.WithValue(ctx, httputil.TokenIDKey, tokenClaims.TokenID)
+			ctx = context.WithValue(ctx, httputil.SessionIDKey, tokenClaims.SessionID)
+			ctx = context.WithValue(ctx, httputil.TokenExpiresAtKey, tokenClaims.ExpiresAt)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
+
+// RequirePermission lets a request through only when the caller's role
+// grants permission. It must run after JWTAuthMiddleware.
+func RequirePermission(permission domain.Permission, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, role, ok := httputil.GetUserIDRole(r.Context())
+		if !ok || !domain.HasPermission(role, permission) {
+			httputil.RespondWithError(w, http.StatusForbidden, "forbidden")
+			return
+		}
+		next(w, r)
+	}
+}
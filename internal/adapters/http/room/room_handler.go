@@ -3,8 +3,10 @@ package room
 import (
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	httputil "github.com/amangirdhar210/meeting-room/internal/adapters/httpUtils"
@@ -14,21 +16,19 @@ import (
 	"github.com/gorilla/mux"
 )
 
+const defaultSlotDuration = 30
+
 type Handler struct {
-	roomService service.RoomService
+	roomService    service.RoomService
+	blockService   service.RoomBlockService
+	checkInService service.CheckInService
 }
 
-func NewHandler(roomService service.RoomService) *Handler {
-	return &Handler{roomService: roomService}
+func NewHandler(roomService service.RoomService, blockService service.RoomBlockService, checkInService service.CheckInService) *Handler {
+	return &Handler{roomService: roomService, blockService: blockService, checkInService: checkInService}
 }
 
 func (h *Handler) AddRoom(w http.ResponseWriter, r *http.Request) {
-	_, role, ok := httputil.GetUserIDRole(r.Context())
-	if !ok || role != "admin" {
-		httputil.RespondWithError(w, http.StatusForbidden, "forbidden")
-		return
-	}
-
 	var req dto.AddRoomRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		httputil.RespondWithError(w, http.StatusBadRequest, "invalid request body")
@@ -44,13 +44,12 @@ func (h *Handler) AddRoom(w http.ResponseWriter, r *http.Request) {
 		Status:      req.Status,
 		Location:    req.Location,
 		Description: req.Description,
-	}
 
-	if room.Status == "" {
-		room.Status = "Available"
+		RequiresApproval: req.RequiresApproval,
+		Approvers:        req.Approvers,
 	}
-	if room.Amenities == nil {
-		room.Amenities = []string{}
+	if req.BookingPolicy != nil {
+		room.BookingPolicy = domain.RoomPolicy(*req.BookingPolicy)
 	}
 
 	if err := h.roomService.AddRoom(room); err != nil {
@@ -80,6 +79,10 @@ func (h *Handler) GetAllRooms(w http.ResponseWriter, r *http.Request) {
 			Status:      room.Status,
 			Location:    room.Location,
 			Description: room.Description,
+
+			RequiresApproval: room.RequiresApproval,
+			Approvers:        room.Approvers,
+			BookingPolicy:    toRoomPolicyDTO(room.BookingPolicy),
 		})
 	}
 
@@ -110,15 +113,111 @@ func (h *Handler) GetRoomByID(w http.ResponseWriter, r *http.Request) {
 		Status:      room.Status,
 		Location:    room.Location,
 		Description: room.Description,
+
+		RequiresApproval: room.RequiresApproval,
+		Approvers:        room.Approvers,
+		BookingPolicy:    toRoomPolicyDTO(room.BookingPolicy),
 	}
 
 	httputil.RespondWithJSON(w, http.StatusOK, response)
 }
 
+// UpdateRoom handles both PUT (full replacement) and PATCH (partial update).
+// A PUT that omits status keeps the room's current status.
+func (h *Handler) UpdateRoom(w http.ResponseWriter, r *http.Request) {
+	roomID := mux.Vars(r)["id"]
+	if roomID == "" {
+		httputil.RespondWithError(w, http.StatusBadRequest, "invalid room id")
+		return
+	}
+
+	var req dto.UpdateRoomRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if r.Method == http.MethodPut {
+		if req.Name == nil || req.RoomNumber == nil || req.Capacity == nil || req.Floor == nil || req.Location == nil {
+			httputil.RespondWithError(w, http.StatusBadRequest, "name, roomNumber, capacity, floor and location are required")
+			return
+		}
+		if req.Amenities == nil {
+			req.Amenities = &[]string{}
+		}
+		if req.Description == nil {
+			req.Description = new(string)
+		}
+		if req.RequiresApproval == nil {
+			req.RequiresApproval = new(bool)
+		}
+		if req.Approvers == nil {
+			req.Approvers = &[]string{}
+		}
+		if req.BookingPolicy == nil {
+			req.BookingPolicy = &dto.RoomPolicyDTO{}
+		}
+	}
+
+	var bookingPolicy *domain.RoomPolicy
+	if req.BookingPolicy != nil {
+		policy := domain.RoomPolicy(*req.BookingPolicy)
+		bookingPolicy = &policy
+	}
+
+	room, err := h.roomService.UpdateRoom(roomID, domain.RoomUpdate{
+		Name:        req.Name,
+		RoomNumber:  req.RoomNumber,
+		Capacity:    req.Capacity,
+		Floor:       req.Floor,
+		Amenities:   req.Amenities,
+		Status:      req.Status,
+		Location:    req.Location,
+		Description: req.Description,
+
+		RequiresApproval: req.RequiresApproval,
+		Approvers:        req.Approvers,
+		BookingPolicy:    bookingPolicy,
+	})
+	if err != nil {
+		httputil.HandleError(w, err)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, dto.RoomDTO{
+		ID:          room.ID,
+		Name:        room.Name,
+		RoomNumber:  room.RoomNumber,
+		Capacity:    room.Capacity,
+		Floor:       room.Floor,
+		Amenities:   room.Amenities,
+		Status:      room.Status,
+		Location:    room.Location,
+		Description: room.Description,
+
+		RequiresApproval: room.RequiresApproval,
+		Approvers:        room.Approvers,
+		BookingPolicy:    toRoomPolicyDTO(room.BookingPolicy),
+	})
+}
+
+// toRoomPolicyDTO leaves the policy out for rooms that only follow the global
+// one.
+func toRoomPolicyDTO(policy domain.RoomPolicy) *dto.RoomPolicyDTO {
+	if policy.IsZero() {
+		return nil
+	}
+	override := dto.RoomPolicyDTO(policy)
+	return &override
+}
+
+// DeleteRoomByID soft-deletes a room. Upcoming bookings make it fail with 409
+// unless ?force=true is given, in which case they are cancelled with the
+// optional body reason and their owners notified.
 func (h *Handler) DeleteRoomByID(w http.ResponseWriter, r *http.Request) {
-	_, userRole, ok := httputil.GetUserIDRole(r.Context())
-	if !ok || userRole != "admin" {
-		httputil.RespondWithError(w, http.StatusForbidden, "forbidden")
+	userID, _, ok := httputil.GetUserIDRole(r.Context())
+	if !ok {
+		httputil.RespondWithError(w, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
@@ -129,70 +228,159 @@ func (h *Handler) DeleteRoomByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.roomService.DeleteRoomByID(roomID); err != nil {
+	force := false
+	if forceStr := r.URL.Query().Get("force"); forceStr != "" {
+		val, err := strconv.ParseBool(forceStr)
+		if err != nil {
+			httputil.RespondWithError(w, http.StatusBadRequest, "force must be true or false")
+			return
+		}
+		force = val
+	}
+
+	var req dto.DeleteRoomRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		httputil.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	bookings, err := h.roomService.DeleteRoomByID(roomID, domain.RoomDeletion{
+		Force:     force,
+		DeletedBy: userID,
+		Reason:    req.Reason,
+	})
+	if err == domain.ErrRoomHasBookings {
+		httputil.RespondWithJSON(w, http.StatusConflict, dto.RoomHasBookingsResponse{
+			Error:            "room has upcoming bookings, retry with force=true to cancel them",
+			UpcomingBookings: toBookingDTOs(bookings),
+		})
+		return
+	}
+	if err != nil {
 		httputil.HandleError(w, err)
 		return
 	}
 
-	httputil.RespondWithJSON(w, http.StatusOK, dto.GenericResponse{Message: "room deleted successfully"})
+	httputil.RespondWithJSON(w, http.StatusOK, dto.DeleteRoomResponse{
+		Message:           "room deleted successfully",
+		CancelledBookings: toBookingDTOs(bookings),
+	})
+}
+
+func toBookingDTOs(bookings []domain.Booking) []dto.BookingDTO {
+	result := make([]dto.BookingDTO, 0, len(bookings))
+	for _, b := range bookings {
+		result = append(result, dto.BookingDTO{
+			ID:                 b.ID,
+			UserID:             b.UserID,
+			RoomID:             b.RoomID,
+			StartTime:          b.StartTime,
+			EndTime:            b.EndTime,
+			Purpose:            b.Purpose,
+			Status:             b.Status,
+			CancelledAt:        b.CancelledAt,
+			CancelledBy:        b.CancelledBy,
+			CancellationReason: b.CancellationReason,
+			SeriesID:           b.SeriesID,
+			GroupID:            b.GroupID,
+			ApprovalExpiresAt:  b.ApprovalExpiresAt,
+			ReviewedBy:         b.ReviewedBy,
+			ReviewedAt:         b.ReviewedAt,
+			ReviewNote:         b.ReviewNote,
+			CheckedInAt:        b.CheckedInAt,
+			ReleasedAt:         b.ReleasedAt,
+		})
+	}
+	return result
 }
 
 func (h *Handler) SearchRooms(w http.ResponseWriter, r *http.Request) {
 	queryParams := r.URL.Query()
 
-	minCapacity := 0
+	var filters dto.RoomSearchFilters
 	if minCapStr := queryParams.Get("minCapacity"); minCapStr != "" {
 		if val, err := strconv.Atoi(minCapStr); err == nil {
-			minCapacity = val
+			filters.MinCapacity = val
 		}
 	}
-
-	maxCapacity := 0
 	if maxCapStr := queryParams.Get("maxCapacity"); maxCapStr != "" {
 		if val, err := strconv.Atoi(maxCapStr); err == nil {
-			maxCapacity = val
+			filters.MaxCapacity = val
 		}
 	}
-
-	var floor *int
 	if floorStr := queryParams.Get("floor"); floorStr != "" {
 		if val, err := strconv.Atoi(floorStr); err == nil {
-			floor = &val
+			filters.Floor = &val
 		}
 	}
-
-	var startTime, endTime *int64
-	if startStr := queryParams.Get("startTime"); startStr != "" {
-		if t, err := time.Parse(time.RFC3339, startStr); err == nil {
-			unix := t.Unix()
-			startTime = &unix
+	for _, amenitiesStr := range queryParams["amenities"] {
+		for _, amenity := range strings.Split(amenitiesStr, ",") {
+			if amenity = strings.TrimSpace(amenity); amenity != "" {
+				filters.Amenities = append(filters.Amenities, amenity)
+			}
+		}
+	}
+	if availableStr := queryParams.Get("available"); availableStr != "" {
+		val, err := strconv.ParseBool(availableStr)
+		if err != nil {
+			httputil.RespondWithError(w, http.StatusBadRequest, "available must be true or false")
+			return
+		}
+		filters.Available = &val
+	}
+	filters.Status = strings.TrimSpace(queryParams.Get("status"))
+	filters.StartTime = queryParams.Get("startTime")
+	filters.EndTime = queryParams.Get("endTime")
+
+	searchFilter := domain.RoomSearchFilter{
+		MinCapacity: filters.MinCapacity,
+		MaxCapacity: filters.MaxCapacity,
+		Floor:       filters.Floor,
+		Amenities:   filters.Amenities,
+		Status:      filters.Status,
+		Available:   filters.Available,
+	}
+	if filters.StartTime != "" {
+		t, err := time.Parse(time.RFC3339, filters.StartTime)
+		if err != nil {
+			httputil.RespondWithError(w, http.StatusBadRequest, "invalid startTime format")
+			return
 		}
+		unix := t.Unix()
+		searchFilter.StartTime = &unix
 	}
-	if endStr := queryParams.Get("endTime"); endStr != "" {
-		if t, err := time.Parse(time.RFC3339, endStr); err == nil {
-			unix := t.Unix()
-			endTime = &unix
+	if filters.EndTime != "" {
+		t, err := time.Parse(time.RFC3339, filters.EndTime)
+		if err != nil {
+			httputil.RespondWithError(w, http.StatusBadRequest, "invalid endTime format")
+			return
 		}
+		unix := t.Unix()
+		searchFilter.EndTime = &unix
 	}
 
-	rooms, err := h.roomService.SearchRooms(minCapacity, maxCapacity, floor, startTime, endTime)
+	rooms, err := h.roomService.SearchRooms(searchFilter)
 	if err != nil {
 		httputil.HandleError(w, err)
 		return
 	}
 
-	var response []dto.RoomDTO
+	response := []dto.RoomWithAvailabilityDTO{}
 	for _, room := range rooms {
-		response = append(response, dto.RoomDTO{
-			ID:          room.ID,
-			Name:        room.Name,
-			RoomNumber:  room.RoomNumber,
-			Capacity:    room.Capacity,
-			Floor:       room.Floor,
-			Amenities:   room.Amenities,
-			Status:      room.Status,
-			Location:    room.Location,
-			Description: room.Description,
+		response = append(response, dto.RoomWithAvailabilityDTO{
+			ID:               room.ID,
+			Name:             room.Name,
+			RoomNumber:       room.RoomNumber,
+			Capacity:         room.Capacity,
+			Floor:            room.Floor,
+			Amenities:        room.Amenities,
+			Status:           room.Status,
+			Location:         room.Location,
+			Description:      room.Description,
+			RequiresApproval: room.RequiresApproval,
+			IsAvailable:      room.IsAvailable,
+			NextAvailableAt:  room.NextAvailableAt,
+			CurrentBookingID: room.CurrentBookingID,
 		})
 	}
 
@@ -218,7 +406,7 @@ func (h *Handler) CheckAvailability(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	isAvailable, conflictingBookings, err := h.roomService.CheckAvailability(request.RoomID, startTime.Unix(), endTime.Unix())
+	availability, err := h.roomService.CheckAvailability(request.RoomID, startTime.Unix(), endTime.Unix())
 	if err != nil {
 		httputil.HandleError(w, err)
 		return
@@ -231,7 +419,7 @@ func (h *Handler) CheckAvailability(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var conflictingSlots []dto.ConflictingBookingDTO
-	for _, conflictBooking := range conflictingBookings {
+	for _, conflictBooking := range availability.ConflictingBookings {
 		conflictingSlots = append(conflictingSlots, dto.ConflictingBookingDTO{
 			BookingID: conflictBooking.ID,
 			StartTime: conflictBooking.StartTime,
@@ -240,15 +428,258 @@ func (h *Handler) CheckAvailability(w http.ResponseWriter, r *http.Request) {
 		})
 	}
 
+	suggestedSlots := []dto.TimeSlotDTO{}
+	for _, slot := range availability.SuggestedSlots {
+		suggestedSlots = append(suggestedSlots, dto.TimeSlotDTO{
+			StartTime: slot.StartTime,
+			EndTime:   slot.EndTime,
+			Duration:  slot.Duration,
+		})
+	}
+
+	var conflictingBlocks []dto.RoomBlockDTO
+	for _, block := range availability.ConflictingBlocks {
+		conflictingBlocks = append(conflictingBlocks, toRoomBlockDTO(block))
+	}
+
 	response := dto.AvailabilityCheckResponse{
-		Available:        isAvailable,
-		RoomID:           request.RoomID,
-		RoomName:         room.Name,
-		RequestedStart:   startTime.Unix(),
-		RequestedEnd:     endTime.Unix(),
-		ConflictingSlots: conflictingSlots,
-		SuggestedSlots:   []dto.TimeSlotDTO{},
+		Available:         availability.Available,
+		RoomID:            request.RoomID,
+		RoomName:          room.Name,
+		RequestedStart:    startTime.Unix(),
+		RequestedEnd:      endTime.Unix(),
+		ConflictingSlots:  conflictingSlots,
+		ConflictingBlocks: conflictingBlocks,
+		SuggestedSlots:    suggestedSlots,
 	}
 
 	httputil.RespondWithJSON(w, http.StatusOK, response)
 }
+
+func (h *Handler) GetAvailableSlots(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	roomID := vars["id"]
+	if roomID == "" {
+		httputil.RespondWithError(w, http.StatusBadRequest, "invalid room id")
+		return
+	}
+
+	queryParams := r.URL.Query()
+
+	dateStr := queryParams.Get("date")
+	if dateStr == "" {
+		httputil.RespondWithError(w, http.StatusBadRequest, "date parameter is required (format: YYYY-MM-DD)")
+		return
+	}
+	targetDate, err := time.Parse("2006-01-02", dateStr)
+	if err != nil {
+		httputil.RespondWithError(w, http.StatusBadRequest, "invalid date format, use YYYY-MM-DD")
+		return
+	}
+
+	duration := defaultSlotDuration
+	if durationStr := queryParams.Get("duration"); durationStr != "" {
+		val, err := strconv.Atoi(durationStr)
+		if err != nil || val <= 0 {
+			httputil.RespondWithError(w, http.StatusBadRequest, "duration must be a positive number of minutes")
+			return
+		}
+		duration = val
+	}
+
+	slots, err := h.roomService.GetAvailableSlots(roomID, targetDate.Unix(), duration)
+	if err != nil {
+		httputil.HandleError(w, err)
+		return
+	}
+
+	room, err := h.roomService.GetRoomByID(roomID)
+	if err != nil {
+		httputil.HandleError(w, err)
+		return
+	}
+
+	response := dto.AvailableSlotsResponse{
+		RoomID:   room.ID,
+		RoomName: room.Name,
+		Date:     dateStr,
+		Duration: duration,
+		Slots:    []dto.TimeSlotDTO{},
+	}
+	for _, slot := range slots {
+		response.Slots = append(response.Slots, dto.TimeSlotDTO{
+			StartTime: slot.StartTime,
+			EndTime:   slot.EndTime,
+			Duration:  slot.Duration,
+		})
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, response)
+}
+
+func toRoomBlockDTO(block domain.RoomBlock) dto.RoomBlockDTO {
+	return dto.RoomBlockDTO{
+		ID:        block.ID,
+		RoomID:    block.RoomID,
+		StartTime: block.StartTime,
+		EndTime:   block.EndTime,
+		Reason:    block.Reason,
+		CreatedBy: block.CreatedBy,
+		CreatedAt: block.CreatedAt,
+	}
+}
+
+func (h *Handler) CreateBlock(w http.ResponseWriter, r *http.Request) {
+	userID, _, ok := httputil.GetUserIDRole(r.Context())
+	if !ok {
+		httputil.RespondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	roomID := mux.Vars(r)["id"]
+	if roomID == "" {
+		httputil.RespondWithError(w, http.StatusBadRequest, "invalid room id")
+		return
+	}
+
+	var req dto.CreateRoomBlockRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	startTime, err := time.Parse(time.RFC3339, req.StartTime)
+	if err != nil {
+		httputil.RespondWithError(w, http.StatusBadRequest, "invalid start_time format")
+		return
+	}
+	endTime, err := time.Parse(time.RFC3339, req.EndTime)
+	if err != nil {
+		httputil.RespondWithError(w, http.StatusBadRequest, "invalid end_time format")
+		return
+	}
+
+	block := &domain.RoomBlock{
+		RoomID:    roomID,
+		StartTime: startTime.Unix(),
+		EndTime:   endTime.Unix(),
+		Reason:    req.Reason,
+		CreatedBy: userID,
+	}
+	colliding, err := h.blockService.CreateBlock(block)
+	if err != nil {
+		httputil.HandleError(w, err)
+		return
+	}
+
+	response := dto.CreateRoomBlockResponse{
+		Block:             toRoomBlockDTO(*block),
+		CollidingBookings: []dto.DetailedBookingDTO{},
+	}
+	for _, booking := range colliding {
+		response.CollidingBookings = append(response.CollidingBookings, dto.DetailedBookingDTO{
+			ID:         booking.ID,
+			UserID:     booking.UserID,
+			UserName:   booking.UserName,
+			UserEmail:  booking.UserEmail,
+			RoomID:     booking.RoomID,
+			RoomName:   booking.RoomName,
+			RoomNumber: booking.RoomNumber,
+			StartTime:  booking.StartTime,
+			EndTime:    booking.EndTime,
+			Duration:   int((booking.EndTime - booking.StartTime) / 60),
+			Purpose:    booking.Purpose,
+			Status:     booking.Status,
+		})
+	}
+
+	httputil.RespondWithJSON(w, http.StatusCreated, response)
+}
+
+func (h *Handler) GetBlocks(w http.ResponseWriter, r *http.Request) {
+	roomID := mux.Vars(r)["id"]
+	if roomID == "" {
+		httputil.RespondWithError(w, http.StatusBadRequest, "invalid room id")
+		return
+	}
+
+	blocks, err := h.blockService.GetBlocksByRoomID(roomID)
+	if err != nil {
+		httputil.HandleError(w, err)
+		return
+	}
+
+	response := make([]dto.RoomBlockDTO, 0, len(blocks))
+	for _, block := range blocks {
+		response = append(response, toRoomBlockDTO(block))
+	}
+	httputil.RespondWithJSON(w, http.StatusOK, response)
+}
+
+func (h *Handler) DeleteBlock(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	if err := h.blockService.DeleteBlock(vars["id"], vars["blockId"]); err != nil {
+		httputil.HandleError(w, err)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, dto.GenericResponse{Message: "room block deleted successfully"})
+}
+
+func toKioskTokenDTO(token domain.KioskToken) dto.KioskTokenDTO {
+	return dto.KioskTokenDTO{
+		ID:        token.ID,
+		RoomID:    token.RoomID,
+		Name:      token.Name,
+		CreatedBy: token.CreatedBy,
+		CreatedAt: token.CreatedAt,
+	}
+}
+
+func (h *Handler) CreateKioskToken(w http.ResponseWriter, r *http.Request) {
+	userID, _, ok := httputil.GetUserIDRole(r.Context())
+	if !ok {
+		httputil.RespondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req dto.CreateKioskTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		httputil.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	secret, token, err := h.checkInService.CreateKioskToken(mux.Vars(r)["id"], req.Name, userID)
+	if err != nil {
+		httputil.HandleError(w, err)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusCreated, dto.CreateKioskTokenResponse{
+		KioskTokenDTO: toKioskTokenDTO(*token),
+		Token:         secret,
+	})
+}
+
+func (h *Handler) GetKioskTokens(w http.ResponseWriter, r *http.Request) {
+	tokens, err := h.checkInService.ListKioskTokens(mux.Vars(r)["id"])
+	if err != nil {
+		httputil.HandleError(w, err)
+		return
+	}
+
+	response := make([]dto.KioskTokenDTO, 0, len(tokens))
+	for _, token := range tokens {
+		response = append(response, toKioskTokenDTO(token))
+	}
+	httputil.RespondWithJSON(w, http.StatusOK, response)
+}
+
+func (h *Handler) RevokeKioskToken(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	if err := h.checkInService.RevokeKioskToken(vars["id"], vars["tokenId"]); err != nil {
+		httputil.HandleError(w, err)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, dto.GenericResponse{Message: "kiosk token revoked successfully"})
+}
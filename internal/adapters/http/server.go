@@ -6,18 +6,22 @@ import (
 	"github.com/amangirdhar210/meeting-room/internal/adapters/auth"
 	authHandler "github.com/amangirdhar210/meeting-room/internal/adapters/http/auth"
 	bookingHandler "github.com/amangirdhar210/meeting-room/internal/adapters/http/booking"
+	calendarHandler "github.com/amangirdhar210/meeting-room/internal/adapters/http/calendar"
 	roomHandler "github.com/amangirdhar210/meeting-room/internal/adapters/http/room"
 	userHandler "github.com/amangirdhar210/meeting-room/internal/adapters/http/user"
 	"github.com/amangirdhar210/meeting-room/internal/config"
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
 	"github.com/amangirdhar210/meeting-room/internal/core/service"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
 	"github.com/gorilla/mux"
 )
 
-func NewHTTPServer(cfg *config.Config, userService service.UserService, authService service.AuthService, roomService service.RoomService, bookingService service.BookingService, jwtGenerator *auth.JWTGenerator) *http.Server {
-	authH := authHandler.NewHandler(authService)
+func NewHTTPServer(cfg *config.Config, userService service.UserService, authService service.AuthService, resetService service.PasswordResetService, oidcService service.OIDCService, roomService service.RoomService, blockService service.RoomBlockService, bookingService service.BookingService, seriesService service.BookingSeriesService, groupService service.BookingGroupService, calendarService service.CalendarService, importService service.BookingImportService, waitlistService service.WaitlistService, approvalService service.ApprovalService, checkInService service.CheckInService, jwtGenerator *auth.JWTGenerator) *http.Server {
+	authH := authHandler.NewHandler(authService, resetService, oidcService)
 	userH := userHandler.NewHandler(userService)
-	roomH := roomHandler.NewHandler(roomService)
-	bookingH := bookingHandler.NewHandler(bookingService)
+	roomH := roomHandler.NewHandler(roomService, blockService, checkInService)
+	bookingH := bookingHandler.NewHandler(bookingService, seriesService, groupService, importService, waitlistService, approvalService, checkInService)
+	calendarH := calendarHandler.NewHandler(calendarService)
 
 	router := mux.NewRouter()
 
@@ -29,29 +33,89 @@ func NewHTTPServer(cfg *config.Config, userService service.UserService, authServ
 		}
 	}).Methods("GET")
 
+	router.HandleFunc("/.well-known/jwks.json", JWKSHandler(jwtGenerator)).Methods("GET")
+
 	router.HandleFunc("/api/login", authH.Login).Methods("POST")
+	router.HandleFunc("/api/token/refresh", authH.Refresh).Methods("POST")
+	router.HandleFunc("/api/password/forgot", authH.ForgotPassword).Methods("POST")
+	router.HandleFunc("/api/password/reset", authH.ResetPassword).Methods("POST")
+	if oidcService != nil {
+		router.HandleFunc("/api/oidc/authorize", authH.OIDCAuthorize).Methods("POST")
+		router.HandleFunc("/api/oidc/callback", authH.OIDCCallback).Methods("POST")
+	}
+
+	// Calendar clients cannot send a JWT, so feeds authenticate with the
+	// ?token= secret and are registered outside the authenticated subrouter.
+	router.HandleFunc("/api/users/{id}/calendar.ics", calendarH.GetUserFeed).Methods("GET")
+	router.HandleFunc("/api/rooms/{id}/calendar.ics", calendarH.GetRoomFeed).Methods("GET")
+
+	// Room kiosks check in with their kiosk token instead of a JWT, so the
+	// check-in route picks how to authenticate before the subrouter would.
+	ownerCheckIn := JWTAuthMiddleware(jwtGenerator, authService)(http.HandlerFunc(bookingH.CheckIn))
+	router.Handle("/api/bookings/{id}/check-in", LoggingMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(dto.KioskTokenHeader) != "" {
+			bookingH.KioskCheckIn(w, r)
+			return
+		}
+		ownerCheckIn.ServeHTTP(w, r)
+	}))).Methods("POST")
 
 	api := router.PathPrefix("/api").Subrouter()
 	api.Use(LoggingMiddleware)
-	api.Use(JWTAuthMiddleware(jwtGenerator))
+	api.Use(JWTAuthMiddleware(jwtGenerator, authService))
+
+	api.HandleFunc("/logout", authH.Logout).Methods("POST")
 
-	api.HandleFunc("/users", userH.GetAllUsers).Methods("GET")
-	api.HandleFunc("/users/{id}", userH.DeleteUser).Methods("DELETE")
-	api.HandleFunc("/register", userH.RegisterUser).Methods("POST")
+	api.HandleFunc("/users", RequirePermission(domain.PermUsersAdmin, userH.GetAllUsers)).Methods("GET")
+	api.HandleFunc("/users/me", userH.GetMe).Methods("GET")
+	api.HandleFunc("/users/me", userH.UpdateMe).Methods("PATCH")
+	api.HandleFunc("/users/me/password", userH.ChangePassword).Methods("POST")
+	api.HandleFunc("/users/{id}", RequirePermission(domain.PermUsersAdmin, userH.UpdateUser)).Methods("PATCH")
+	api.HandleFunc("/users/{id}", RequirePermission(domain.PermUsersAdmin, userH.DeleteUser)).Methods("DELETE")
+	api.HandleFunc("/register", RequirePermission(domain.PermUsersAdmin, userH.RegisterUser)).Methods("POST")
 
-	api.HandleFunc("/rooms", roomH.AddRoom).Methods("POST")
+	api.HandleFunc("/rooms", RequirePermission(domain.PermRoomsWrite, roomH.AddRoom)).Methods("POST")
 	api.HandleFunc("/rooms", roomH.GetAllRooms).Methods("GET")
 	api.HandleFunc("/rooms/search", roomH.SearchRooms).Methods("GET")
 	api.HandleFunc("/rooms/check-availability", roomH.CheckAvailability).Methods("POST")
 	api.HandleFunc("/rooms/{id}", roomH.GetRoomByID).Methods("GET")
-	api.HandleFunc("/rooms/{id}/delete", roomH.DeleteRoomByID).Methods("DELETE")
+	api.HandleFunc("/rooms/{id}", RequirePermission(domain.PermRoomsWrite, roomH.UpdateRoom)).Methods("PUT", "PATCH")
+	api.HandleFunc("/rooms/{id}", RequirePermission(domain.PermRoomsWrite, roomH.DeleteRoomByID)).Methods("DELETE")
+	api.HandleFunc("/rooms/{id}/delete", RequirePermission(domain.PermRoomsWrite, roomH.DeleteRoomByID)).Methods("DELETE")
 	api.HandleFunc("/rooms/{id}/schedule", bookingH.GetSchedule).Methods("GET")
 	api.HandleFunc("/rooms/{id}/schedule/date", bookingH.GetScheduleByDate).Methods("GET")
+	api.HandleFunc("/rooms/{id}/available-slots", roomH.GetAvailableSlots).Methods("GET")
+	api.HandleFunc("/rooms/{id}/blocks", RequirePermission(domain.PermRoomsWrite, roomH.CreateBlock)).Methods("POST")
+	api.HandleFunc("/rooms/{id}/blocks", roomH.GetBlocks).Methods("GET")
+	api.HandleFunc("/rooms/{id}/blocks/{blockId}", RequirePermission(domain.PermRoomsWrite, roomH.DeleteBlock)).Methods("DELETE")
+	api.HandleFunc("/rooms/{id}/kiosk-tokens", RequirePermission(domain.PermRoomsWrite, roomH.CreateKioskToken)).Methods("POST")
+	api.HandleFunc("/rooms/{id}/kiosk-tokens", RequirePermission(domain.PermRoomsWrite, roomH.GetKioskTokens)).Methods("GET")
+	api.HandleFunc("/rooms/{id}/kiosk-tokens/{tokenId}", RequirePermission(domain.PermRoomsWrite, roomH.RevokeKioskToken)).Methods("DELETE")
 
 	api.HandleFunc("/bookings", bookingH.CreateBooking).Methods("POST")
 	api.HandleFunc("/bookings", bookingH.GetAllBookings).Methods("GET")
 	api.HandleFunc("/bookings/my", bookingH.GetMyBookings).Methods("GET")
+	api.HandleFunc("/bookings/series", bookingH.CreateSeries).Methods("POST")
+	api.HandleFunc("/bookings/series/{id}", bookingH.GetSeries).Methods("GET")
+	api.HandleFunc("/bookings/group", bookingH.CreateGroup).Methods("POST")
+	api.HandleFunc("/bookings/group/{id}", bookingH.GetGroup).Methods("GET")
+	api.HandleFunc("/bookings/group/{id}", bookingH.UpdateGroup).Methods("PATCH")
+	api.HandleFunc("/bookings/group/{id}", bookingH.CancelGroup).Methods("DELETE")
+	api.HandleFunc("/bookings/waitlist", bookingH.JoinWaitlist).Methods("POST")
+	api.HandleFunc("/bookings/waitlist", bookingH.GetMyWaitlist).Methods("GET")
+	api.HandleFunc("/bookings/waitlist/{id}/claim", bookingH.ClaimWaitlistEntry).Methods("POST")
+	api.HandleFunc("/bookings/waitlist/{id}", bookingH.LeaveWaitlist).Methods("DELETE")
+	api.HandleFunc("/bookings/{id}", bookingH.UpdateBooking).Methods("PATCH")
 	api.HandleFunc("/bookings/{id}", bookingH.CancelBooking).Methods("DELETE")
+	api.HandleFunc("/bookings/{id}/approve", bookingH.ApproveBooking).Methods("POST")
+	api.HandleFunc("/bookings/{id}/reject", bookingH.RejectBooking).Methods("POST")
+	api.HandleFunc("/approvals/pending", bookingH.GetPendingApprovals).Methods("GET")
+
+	api.HandleFunc("/admin/bookings/import", RequirePermission(domain.PermBookingsImport, bookingH.ImportBookings)).Methods("POST")
+
+	api.HandleFunc("/calendar/tokens", calendarH.CreateToken).Methods("POST")
+	api.HandleFunc("/calendar/tokens", calendarH.ListTokens).Methods("GET")
+	api.HandleFunc("/calendar/tokens/{id}", calendarH.RevokeToken).Methods("DELETE")
 
 	wrappedRouter := CORSMiddleware(router, cfg.CORS.AllowedOrigins)
 
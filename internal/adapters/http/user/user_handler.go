@@ -2,6 +2,7 @@ package user
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
 
 	httputil "github.com/amangirdhar210/meeting-room/internal/adapters/httpUtils"
@@ -20,12 +21,6 @@ func NewHandler(userService service.UserService) *Handler {
 }
 
 func (h *Handler) RegisterUser(w http.ResponseWriter, r *http.Request) {
-	_, role, ok := httputil.GetUserIDRole(r.Context())
-	if !ok || role != "admin" {
-		httputil.RespondWithError(w, http.StatusForbidden, "forbidden")
-		return
-	}
-
 	var req dto.RegisterUserRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		httputil.RespondWithError(w, http.StatusBadRequest, "invalid request body")
@@ -48,12 +43,6 @@ func (h *Handler) RegisterUser(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handler) GetAllUsers(w http.ResponseWriter, r *http.Request) {
-	_, role, ok := httputil.GetUserIDRole(r.Context())
-	if !ok || role != "admin" {
-		httputil.RespondWithError(w, http.StatusForbidden, "forbidden")
-		return
-	}
-
 	users, err := h.userService.GetAllUsers()
 	if err != nil {
 		httputil.HandleError(w, err)
@@ -62,21 +51,114 @@ func (h *Handler) GetAllUsers(w http.ResponseWriter, r *http.Request) {
 
 	var resp []dto.UserDTO
 	for _, u := range users {
-		resp = append(resp, dto.UserDTO{
-			ID:    u.ID,
-			Name:  u.Name,
-			Email: u.Email,
-			Role:  u.Role,
-		})
+		resp = append(resp, toUserDTO(u))
 	}
 
 	httputil.RespondWithJSON(w, http.StatusOK, resp)
 }
 
+func (h *Handler) UpdateUser(w http.ResponseWriter, r *http.Request) {
+	userId, _, ok := httputil.GetUserIDRole(r.Context())
+	if !ok {
+		httputil.RespondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+	if id == "" {
+		httputil.RespondWithError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	var req dto.UpdateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if id == userId && req.Role != nil && !domain.HasPermission(*req.Role, domain.PermUsersAdmin) {
+		httputil.RespondWithError(w, http.StatusForbidden, "cannot change your own role")
+		return
+	}
+
+	user, err := h.userService.UpdateUser(id, domain.UserUpdate{
+		Name:  req.Name,
+		Email: req.Email,
+		Role:  req.Role,
+	})
+	if err != nil {
+		httputil.HandleError(w, err)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, toUserDTO(*user))
+}
+
+func (h *Handler) GetMe(w http.ResponseWriter, r *http.Request) {
+	userId, _, ok := httputil.GetUserIDRole(r.Context())
+	if !ok {
+		httputil.RespondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	user, err := h.userService.GetUserByID(userId)
+	if err != nil {
+		httputil.HandleError(w, err)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, toUserDTO(*user))
+}
+
+func (h *Handler) UpdateMe(w http.ResponseWriter, r *http.Request) {
+	userId, _, ok := httputil.GetUserIDRole(r.Context())
+	if !ok {
+		httputil.RespondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req dto.UpdateProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	user, err := h.userService.UpdateUser(userId, domain.UserUpdate{
+		Name:  req.Name,
+		Email: req.Email,
+	})
+	if err != nil {
+		httputil.HandleError(w, err)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, toUserDTO(*user))
+}
+
+func (h *Handler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	claims, ok := httputil.GetAccessTokenClaims(r.Context())
+	if !ok {
+		httputil.RespondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req dto.ChangePasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.userService.ChangePassword(claims.UserID, claims.SessionID, req.CurrentPassword, req.NewPassword); err != nil {
+		httputil.HandleError(w, err)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, dto.GenericResponse{Message: "password changed successfully"})
+}
+
 func (h *Handler) DeleteUser(w http.ResponseWriter, r *http.Request) {
-	userId, role, ok := httputil.GetUserIDRole(r.Context())
-	if !ok || role != "admin" {
-		httputil.RespondWithError(w, http.StatusForbidden, "forbidden")
+	userId, _, ok := httputil.GetUserIDRole(r.Context())
+	if !ok {
+		httputil.RespondWithError(w, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
@@ -100,10 +182,65 @@ func (h *Handler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.userService.DeleteUserByID(id); err != nil {
+	var req dto.DeactivateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		httputil.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	result, err := h.userService.DeactivateUser(id, domain.UserDeactivation{
+		DelegateID: req.DelegateID,
+		DisabledBy: userId,
+		Reason:     req.Reason,
+	})
+	if err != nil {
 		httputil.HandleError(w, err)
 		return
 	}
 
-	httputil.RespondWithJSON(w, http.StatusOK, dto.GenericResponse{Message: "user deleted successfully"})
+	httputil.RespondWithJSON(w, http.StatusOK, dto.DeactivateUserResponse{
+		Message:            "user disabled successfully",
+		CancelledBookings:  toBookingDTOs(result.Cancelled),
+		ReassignedBookings: toBookingDTOs(result.Reassigned),
+	})
+}
+
+func toBookingDTOs(bookings []domain.Booking) []dto.BookingDTO {
+	result := make([]dto.BookingDTO, 0, len(bookings))
+	for _, b := range bookings {
+		result = append(result, dto.BookingDTO{
+			ID:                 b.ID,
+			UserID:             b.UserID,
+			RoomID:             b.RoomID,
+			StartTime:          b.StartTime,
+			EndTime:            b.EndTime,
+			Purpose:            b.Purpose,
+			Status:             b.Status,
+			CancelledAt:        b.CancelledAt,
+			CancelledBy:        b.CancelledBy,
+			CancellationReason: b.CancellationReason,
+			SeriesID:           b.SeriesID,
+			GroupID:            b.GroupID,
+			ApprovalExpiresAt:  b.ApprovalExpiresAt,
+			ReviewedBy:         b.ReviewedBy,
+			ReviewedAt:         b.ReviewedAt,
+			ReviewNote:         b.ReviewNote,
+			CheckedInAt:        b.CheckedInAt,
+			ReleasedAt:         b.ReleasedAt,
+		})
+	}
+	return result
+}
+
+func toUserDTO(u domain.User) dto.UserDTO {
+	return dto.UserDTO{
+		ID:         u.ID,
+		Name:       u.Name,
+		Email:      u.Email,
+		Role:       u.Role,
+		Status:     u.Status,
+		DisabledAt: u.DisabledAt,
+		CreatedAt:  u.CreatedAt,
+		UpdatedAt:  u.UpdatedAt,
+	}
 }
@@ -1,12 +1,19 @@
 package httputil
 
-import "context"
+import (
+	"context"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+)
 
 type ContextKey string
 
 const (
-	UserIDKey   ContextKey = "userID"
-	UserRoleKey ContextKey = "userRole"
+	UserIDKey         ContextKey = "userID"
+	UserRoleKey       ContextKey = "userRole"
+	TokenIDKey        ContextKey = "tokenID"
+	SessionIDKey      ContextKey = "sessionID"
+	TokenExpiresAtKey ContextKey = "tokenExpiresAt"
 )
 
 func GetUserIDRole(ctx context.Context) (string, string, bool) {
@@ -14,3 +21,16 @@ func GetUserIDRole(ctx context.Context) (string, string, bool) {
 	role, ok2 := ctx.Value(UserRoleKey).(string)
 	return userID, role, ok1 && ok2
 }
+
+func GetAccessTokenClaims(ctx context.Context) (domain.AccessTokenClaims, bool) {
+	userID, ok1 := ctx.Value(UserIDKey).(string)
+	tokenID, ok2 := ctx.Value(TokenIDKey).(string)
+	sessionID, ok3 := ctx.Value(SessionIDKey).(string)
+	expiresAt, ok4 := ctx.Value(TokenExpiresAtKey).(int64)
+	return domain.AccessTokenClaims{
+		UserID:    userID,
+		TokenID:   tokenID,
+		SessionID: sessionID,
+		ExpiresAt: expiresAt,
+	}, ok1 && ok2 && ok3 && ok4
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
 )
 
 func RespondWithJSON(w http.ResponseWriter, code int, payload any) {
@@ -19,6 +20,15 @@ func RespondWithError(w http.ResponseWriter, code int, message string) {
 }
 
 func HandleError(w http.ResponseWriter, err error) {
+	if policyErr, ok := domain.AsPolicyError(err); ok {
+		resp := dto.PolicyViolationResponse{Error: "booking violates the booking policy"}
+		for _, v := range policyErr.Violations {
+			resp.Violations = append(resp.Violations, dto.PolicyViolationDTO(v))
+		}
+		RespondWithJSON(w, http.StatusUnprocessableEntity, resp)
+		return
+	}
+
 	switch err {
 	case domain.ErrNotFound:
 		RespondWithError(w, http.StatusNotFound, "resource not found")
@@ -26,12 +36,58 @@ func HandleError(w http.ResponseWriter, err error) {
 		RespondWithError(w, http.StatusBadRequest, "invalid input data")
 	case domain.ErrUnauthorized:
 		RespondWithError(w, http.StatusUnauthorized, "unauthorized access")
+	case domain.ErrUserDisabled:
+		RespondWithError(w, http.StatusForbidden, "user account is disabled")
+	case domain.ErrIncorrectPassword:
+		RespondWithError(w, http.StatusForbidden, "current password is incorrect")
+	case domain.ErrInvalidResetToken:
+		RespondWithError(w, http.StatusBadRequest, "password reset token is invalid or expired")
+	case domain.ErrInvalidRefresh:
+		RespondWithError(w, http.StatusUnauthorized, "refresh token is invalid or expired")
+	case domain.ErrInvalidOIDCState:
+		RespondWithError(w, http.StatusBadRequest, "single sign-on request is invalid or expired")
+	case domain.ErrOIDCLoginFailed:
+		RespondWithError(w, http.StatusUnauthorized, "single sign-on login failed")
 	case domain.ErrConflict:
 		RespondWithError(w, http.StatusConflict, "resource conflict")
 	case domain.ErrRoomUnavailable:
 		RespondWithError(w, http.StatusConflict, "room not available for the selected time slot")
+	case domain.ErrRoomNotBookable:
+		RespondWithError(w, http.StatusConflict, "room is not open for bookings")
+	case domain.ErrRoomBlocked:
+		RespondWithError(w, http.StatusConflict, "room is blocked for maintenance during the selected time")
+	case domain.ErrRoomHasBookings:
+		RespondWithError(w, http.StatusConflict, "room has upcoming bookings")
 	case domain.ErrTimeRangeInvalid:
 		RespondWithError(w, http.StatusBadRequest, "invalid start or end time for booking")
+	case domain.ErrBookingTooLong:
+		RespondWithError(w, http.StatusBadRequest, "booking is too long to be stored")
+	case domain.ErrBookingNotActive:
+		RespondWithError(w, http.StatusConflict, "booking is no longer active")
+	case domain.ErrBookingNotPending:
+		RespondWithError(w, http.StatusConflict, "booking is not awaiting approval")
+	case domain.ErrNotApprover:
+		RespondWithError(w, http.StatusForbidden, "only the room's approvers can decide on this booking")
+	case domain.ErrNotBookingOwner:
+		RespondWithError(w, http.StatusForbidden, "only the booking's owner can check in")
+	case domain.ErrCheckInNotOpen:
+		RespondWithError(w, http.StatusConflict, "check-in for this booking has not opened yet")
+	case domain.ErrCheckInClosed:
+		RespondWithError(w, http.StatusConflict, "check-in for this booking has closed")
+	case domain.ErrAlreadyCheckedIn:
+		RespondWithError(w, http.StatusConflict, "booking is already checked in")
+	case domain.ErrNotInSeries:
+		RespondWithError(w, http.StatusBadRequest, "booking is not part of a recurring series")
+	case domain.ErrRoomAvailable:
+		RespondWithError(w, http.StatusConflict, "room is available for the selected time slot, book it instead")
+	case domain.ErrWaitlistNotOffered:
+		RespondWithError(w, http.StatusConflict, "waitlist entry has no open offer")
+	case domain.ErrInvalidRecurrence:
+		RespondWithError(w, http.StatusBadRequest, "invalid or unbounded recurrence rule")
+	case domain.ErrInvalidGroup:
+		RespondWithError(w, http.StatusBadRequest, "a group booking needs between 2 and 10 distinct rooms")
+	case domain.ErrGroupMember:
+		RespondWithError(w, http.StatusConflict, "booking is part of a group booking, change it through /api/bookings/group/{id}")
 	default:
 		log.Printf("Unhandled error: %v", err)
 		RespondWithError(w, http.StatusInternalServerError, "internal server error")
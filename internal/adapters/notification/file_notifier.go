@@ -0,0 +1,39 @@
+package notification
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+)
+
+// FileNotifier appends notifications to a local file, so mails such as
+// password reset links can be read back during development and tests.
+type FileNotifier struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewFileNotifier(path string) *FileNotifier {
+	return &FileNotifier{path: path}
+}
+
+func (n *FileNotifier) Notify(notification domain.Notification) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	f, err := os.OpenFile(n.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open mail file: %w", err)
+	}
+	defer f.Close()
+
+	_, err = fmt.Fprintf(f, "Date: %s\nTo: %s <%s>\nSubject: %s\n\n%s\n\n",
+		time.Now().UTC().Format(time.RFC1123Z), notification.Name, notification.Email, notification.Subject, notification.Message)
+	if err != nil {
+		return fmt.Errorf("failed to write mail file: %w", err)
+	}
+	return nil
+}
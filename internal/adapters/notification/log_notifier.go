@@ -0,0 +1,20 @@
+package notification
+
+import (
+	"log"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+)
+
+// LogNotifier writes notifications to the application log. It stands in for
+// a mail or chat integration until one is configured.
+type LogNotifier struct{}
+
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+func (n *LogNotifier) Notify(notification domain.Notification) error {
+	log.Printf("Notify %s <%s>: %s - %s", notification.Name, notification.Email, notification.Subject, notification.Message)
+	return nil
+}
@@ -0,0 +1,25 @@
+package notification
+
+import (
+	"fmt"
+
+	"github.com/amangirdhar210/meeting-room/internal/config"
+	"github.com/amangirdhar210/meeting-room/internal/core/ports"
+)
+
+// NewNotifier builds the notifier selected by cfg.Driver.
+func NewNotifier(cfg config.MailConfig) (ports.Notifier, error) {
+	switch cfg.Driver {
+	case "log":
+		return NewLogNotifier(), nil
+	case "file":
+		return NewFileNotifier(cfg.FilePath), nil
+	case "smtp":
+		if cfg.SMTPHost == "" {
+			return nil, fmt.Errorf("SMTP_HOST is required for the smtp mail driver")
+		}
+		return NewSMTPNotifier(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.From), nil
+	default:
+		return nil, fmt.Errorf("unknown mail driver %q", cfg.Driver)
+	}
+}
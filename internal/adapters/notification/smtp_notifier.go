@@ -0,0 +1,63 @@
+package notification
+
+import (
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+)
+
+// SMTPNotifier delivers notifications as plain-text email. net/smtp upgrades
+// to STARTTLS when the server offers it.
+type SMTPNotifier struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+func NewSMTPNotifier(host, port, username, password, from string) *SMTPNotifier {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &SMTPNotifier{
+		addr: net.JoinHostPort(host, port),
+		auth: auth,
+		from: from,
+	}
+}
+
+func (n *SMTPNotifier) Notify(notification domain.Notification) error {
+	if notification.Email == "" {
+		return domain.ErrInvalidInput
+	}
+
+	to := mail.Address{Name: notification.Name, Address: notification.Email}
+	from := mail.Address{Address: n.from}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", from.String())
+	fmt.Fprintf(&msg, "To: %s\r\n", to.String())
+	fmt.Fprintf(&msg, "Subject: %s\r\n", headerValue(notification.Subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(strings.ReplaceAll(notification.Message, "\n", "\r\n"))
+	msg.WriteString("\r\n")
+
+	if err := smtp.SendMail(n.addr, n.auth, n.from, []string{notification.Email}, []byte(msg.String())); err != nil {
+		return fmt.Errorf("failed to send mail to %s: %w", notification.Email, err)
+	}
+	return nil
+}
+
+// headerValue strips line breaks so user-controlled text cannot inject
+// extra headers.
+func headerValue(value string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(value)
+}
@@ -0,0 +1,295 @@
+package oidc
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/amangirdhar210/meeting-room/internal/adapters/auth"
+	"github.com/amangirdhar210/meeting-room/internal/config"
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+)
+
+// keyRefreshInterval limits how often an unknown kid triggers a JWKS fetch.
+const keyRefreshInterval = time.Minute
+
+type discoveryDocument struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type tokenResponse struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Provider is an OpenID Connect relying party for the authorization code
+// flow with PKCE. The discovery document and signing keys are fetched on
+// first use and cached.
+type Provider struct {
+	cfg    config.OIDCConfig
+	client *http.Client
+
+	mu            sync.Mutex
+	discovery     *discoveryDocument
+	keys          map[string]crypto.PublicKey
+	keysFetchedAt time.Time
+}
+
+func NewProvider(cfg config.OIDCConfig) *Provider {
+	return &Provider{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (p *Provider) AuthCodeURL(state, nonce, codeChallenge string) (string, error) {
+	doc, err := p.discover()
+	if err != nil {
+		return "", err
+	}
+
+	authURL, err := url.Parse(doc.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid authorization endpoint: %w", err)
+	}
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.cfg.ClientID)
+	query.Set("redirect_uri", p.cfg.RedirectURL)
+	query.Set("scope", strings.Join(p.cfg.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+	authURL.RawQuery = query.Encode()
+	return authURL.String(), nil
+}
+
+func (p *Provider) Exchange(code, codeVerifier, nonce string) (*domain.OIDCIdentity, error) {
+	doc, err := p.discover()
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.cfg.RedirectURL},
+		"code_verifier": {codeVerifier},
+	}
+	if p.cfg.ClientSecret == "" {
+		form.Set("client_id", p.cfg.ClientID)
+	}
+	req, err := http.NewRequest(http.MethodPost, doc.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.cfg.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var token tokenResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&token); err != nil {
+		return nil, fmt.Errorf("invalid token response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK || token.Error != "" {
+		return nil, fmt.Errorf("token request rejected with %d: %s %s", resp.StatusCode, token.Error, token.ErrorDescription)
+	}
+	if token.IDToken == "" {
+		return nil, errors.New("token response has no id_token")
+	}
+
+	return p.verifyIDToken(doc, token.IDToken, nonce)
+}
+
+func (p *Provider) verifyIDToken(doc *discoveryDocument, idToken, nonce string) (*domain.OIDCIdentity, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(idToken, claims, p.verificationKey,
+		jwt.WithValidMethods([]string{"RS256", "ES256"}),
+		jwt.WithIssuer(doc.Issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id_token: %w", err)
+	}
+
+	if value, _ := claims["nonce"].(string); value == "" || value != nonce {
+		return nil, errors.New("id_token nonce does not match")
+	}
+	audience, _ := claims.GetAudience()
+	if azp, _ := claims["azp"].(string); len(audience) > 1 && azp != p.cfg.ClientID {
+		return nil, errors.New("id_token was issued to another client")
+	}
+	subject, _ := claims.GetSubject()
+	if subject == "" {
+		return nil, errors.New("id_token has no subject")
+	}
+
+	identity := &domain.OIDCIdentity{
+		Issuer:  doc.Issuer,
+		Subject: subject,
+		Groups:  stringList(claims[p.cfg.GroupsClaim]),
+	}
+	identity.Email, _ = claims["email"].(string)
+	identity.Name, _ = claims["name"].(string)
+	// Some providers send email_verified as a string.
+	switch verified := claims["email_verified"].(type) {
+	case bool:
+		identity.EmailVerified = verified
+	case string:
+		identity.EmailVerified = verified == "true"
+	}
+	return identity, nil
+}
+
+func stringList(value any) []string {
+	switch v := value.(type) {
+	case string:
+		return strings.Fields(strings.ReplaceAll(v, ",", " "))
+	case []any:
+		list := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				list = append(list, s)
+			}
+		}
+		return list
+	}
+	return nil
+}
+
+// verificationKey looks the signing key up by kid, refetching the JWKS when
+// the provider has rotated to a key not seen before.
+func (p *Provider) verificationKey(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key, err := p.lookupKey(kid)
+	if err != nil && time.Since(p.keysFetchedAt) > keyRefreshInterval {
+		if fetchErr := p.fetchKeys(); fetchErr != nil {
+			return nil, fetchErr
+		}
+		key, err = p.lookupKey(kid)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch key.(type) {
+	case *rsa.PublicKey:
+		if token.Method.Alg() != "RS256" {
+			return nil, fmt.Errorf("key %q does not sign with %s", kid, token.Method.Alg())
+		}
+	case *ecdsa.PublicKey:
+		if token.Method.Alg() != "ES256" {
+			return nil, fmt.Errorf("key %q does not sign with %s", kid, token.Method.Alg())
+		}
+	}
+	return key, nil
+}
+
+func (p *Provider) lookupKey(kid string) (crypto.PublicKey, error) {
+	if kid == "" {
+		// Without a kid the provider must have exactly one key.
+		if len(p.keys) == 1 {
+			for _, key := range p.keys {
+				return key, nil
+			}
+		}
+		return nil, errors.New("id_token has no kid")
+	}
+	key, ok := p.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	return key, nil
+}
+
+func (p *Provider) fetchKeys() error {
+	if p.discovery == nil {
+		return errors.New("provider has not been discovered")
+	}
+
+	var jwks auth.JWKS
+	if err := p.getJSON(p.discovery.JWKSURI, &jwks); err != nil {
+		return fmt.Errorf("failed to fetch provider keys: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey)
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.PublicKey()
+		if err != nil {
+			continue
+		}
+		keys[jwk.KeyID] = key
+	}
+	p.keys = keys
+	p.keysFetchedAt = time.Now()
+	return nil
+}
+
+func (p *Provider) discover() (*discoveryDocument, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	var doc discoveryDocument
+	if err := p.getJSON(p.cfg.IssuerURL+"/.well-known/openid-configuration", &doc); err != nil {
+		return nil, fmt.Errorf("failed to discover provider: %w", err)
+	}
+	if strings.TrimSuffix(doc.Issuer, "/") != p.cfg.IssuerURL {
+		return nil, fmt.Errorf("provider issuer %q does not match %q", doc.Issuer, p.cfg.IssuerURL)
+	}
+	if doc.AuthorizationEndpoint == "" || doc.TokenEndpoint == "" || doc.JWKSURI == "" {
+		return nil, errors.New("provider discovery document is incomplete")
+	}
+	p.discovery = &doc
+	return p.discovery, nil
+}
+
+func (p *Provider) getJSON(target string, v any) error {
+	resp, err := p.client.Get(target)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s returned %d", target, resp.StatusCode)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(v)
+}
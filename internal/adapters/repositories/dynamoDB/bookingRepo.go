@@ -2,8 +2,10 @@ package dynamodb
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"sort"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -29,9 +31,214 @@ func NewBookingRepositoryDynamoDB(client *dynamodb.Client, tableName string) por
 	}
 }
 
+// activeBookingFilter leaves out bookings that no longer hold their slot. It
+// expects "#status" to name the Status attribute and the values added by
+// withInactiveStatuses.
+const activeBookingFilter = "NOT (#status IN (:cancelledStatus, :noShowStatus, :rejectedStatus, :expiredStatus))"
+
+func withInactiveStatuses(values map[string]types.AttributeValue) map[string]types.AttributeValue {
+	values[":cancelledStatus"] = &types.AttributeValueMemberS{Value: domain.BookingStatusCancelled}
+	values[":noShowStatus"] = &types.AttributeValueMemberS{Value: domain.BookingStatusNoShow}
+	values[":rejectedStatus"] = &types.AttributeValueMemberS{Value: domain.BookingStatusRejected}
+	values[":expiredStatus"] = &types.AttributeValueMemberS{Value: domain.BookingStatusExpired}
+	return values
+}
+
+// changeableBookingCondition matches bookings that can still be edited,
+// reassigned or cancelled. It expects "#status" to name the Status attribute
+// and the values added by withChangeableStatuses.
+const changeableBookingCondition = "attribute_exists(SK) AND #status IN (:confirmed, :pending)"
+
+func withChangeableStatuses(values map[string]types.AttributeValue) map[string]types.AttributeValue {
+	values[":confirmed"] = &types.AttributeValueMemberS{Value: domain.BookingStatusConfirmed}
+	values[":pending"] = &types.AttributeValueMemberS{Value: domain.BookingStatusPending}
+	return values
+}
+
+func toDomainBooking(item dto.BookingDynamoDBItem) domain.Booking {
+	return domain.Booking{
+		ID:                 item.ID,
+		UserID:             item.UserID,
+		RoomID:             item.RoomID,
+		StartTime:          item.StartTime,
+		EndTime:            item.EndTime,
+		Purpose:            item.Purpose,
+		Status:             item.Status,
+		SeriesID:           item.SeriesID,
+		GroupID:            item.GroupID,
+		CancelledAt:        item.CancelledAt,
+		CancelledBy:        item.CancelledBy,
+		CancellationReason: item.CancellationReason,
+		Sequence:           item.Sequence,
+		ApprovalExpiresAt:  item.ApprovalExpiresAt,
+		ReviewedBy:         item.ReviewedBy,
+		ReviewedAt:         item.ReviewedAt,
+		ReviewNote:         item.ReviewNote,
+		CheckedInAt:        item.CheckedInAt,
+		ReleasedAt:         item.ReleasedAt,
+		CreatedAt:          item.CreatedAt,
+		UpdatedAt:          item.UpdatedAt,
+	}
+}
+
 func (repo *BookingRepositoryDynamoDB) Create(booking *domain.Booking) error {
 	ctx := context.Background()
 
+	write, err := repo.putBooking(booking)
+	if err != nil {
+		return err
+	}
+
+	err = repo.writeWithRoomLocks(ctx, booking, write)
+	if err != nil {
+		log.Printf("Failed to create booking: %v", err)
+		return err
+	}
+
+	log.Printf("Booking created successfully with ID: %s", booking.ID)
+	return nil
+}
+
+func (repo *BookingRepositoryDynamoDB) Update(booking *domain.Booking) error {
+	ctx := context.Background()
+
+	err := repo.writeWithRoomLocks(ctx, booking, repo.updateBooking(booking))
+	if err != nil {
+		log.Printf("Failed to update booking %s: %v", booking.ID, err)
+		return err
+	}
+
+	log.Printf("Booking updated successfully: %s", booking.ID)
+	return nil
+}
+
+// CreateAll puts the bookings together with the room locks of all their
+// rooms. When they need more than one transaction and a later one fails,
+// the bookings already put are deleted again.
+func (repo *BookingRepositoryDynamoDB) CreateAll(bookings []domain.Booking) error {
+	ctx := context.Background()
+
+	if len(bookings) == 0 {
+		return domain.ErrInvalidInput
+	}
+	writes := make([]types.TransactWriteItem, 0, len(bookings))
+	for i := range bookings {
+		write, err := repo.putBooking(&bookings[i])
+		if err != nil {
+			return err
+		}
+		writes = append(writes, write)
+	}
+
+	written, err := repo.writeAllWithRoomLocks(ctx, bookings, writes)
+	if err != nil {
+		log.Printf("Failed to create %d bookings: %v", len(bookings), err)
+		for _, booking := range bookings[:written] {
+			if err := repo.deleteBooking(ctx, booking.ID); err != nil {
+				log.Printf("Failed to remove booking %s of a failed batch: %v", booking.ID, err)
+			}
+		}
+		return err
+	}
+
+	log.Printf("Created %d bookings successfully", len(bookings))
+	return nil
+}
+
+// UpdateAll is CreateAll for updates: when a later transaction fails the
+// bookings already updated are put back the way they were.
+func (repo *BookingRepositoryDynamoDB) UpdateAll(bookings []domain.Booking) error {
+	ctx := context.Background()
+
+	if len(bookings) == 0 {
+		return domain.ErrInvalidInput
+	}
+	originals := make([]domain.Booking, 0, len(bookings))
+	writes := make([]types.TransactWriteItem, 0, len(bookings))
+	for i := range bookings {
+		original, err := repo.GetByID(bookings[i].ID)
+		if err != nil {
+			return err
+		}
+		originals = append(originals, *original)
+		writes = append(writes, repo.updateBooking(&bookings[i]))
+	}
+
+	written, err := repo.writeAllWithRoomLocks(ctx, bookings, writes)
+	if err != nil {
+		log.Printf("Failed to update %d bookings: %v", len(bookings), err)
+		if written > 0 {
+			restores := make([]types.TransactWriteItem, 0, written)
+			for i := range originals[:written] {
+				restores = append(restores, repo.updateBooking(&originals[i]))
+			}
+			if _, err := repo.writeAllWithRoomLocks(ctx, originals[:written], restores); err != nil {
+				log.Printf("Failed to restore %d bookings of a failed batch: %v", written, err)
+			}
+		}
+		return err
+	}
+
+	log.Printf("Updated %d bookings successfully", len(bookings))
+	return nil
+}
+
+// CancelAll cancels the bookings in one transaction. Cancelling frees
+// slots, so unlike the other batch writes it needs no room locks.
+func (repo *BookingRepositoryDynamoDB) CancelAll(ids []string, cancelledBy, reason string, cancelledAt int64) error {
+	ctx := context.Background()
+
+	if len(ids) == 0 || len(ids) > maxTransactItems {
+		return domain.ErrInvalidInput
+	}
+	writes := make([]types.TransactWriteItem, 0, len(ids))
+	for _, id := range ids {
+		writes = append(writes, types.TransactWriteItem{Update: repo.cancelBooking(id, cancelledBy, reason, cancelledAt)})
+	}
+
+	_, err := repo.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: writes})
+	if err != nil {
+		for i := range writes {
+			if cancellationCode(err, i) == "ConditionalCheckFailed" {
+				return domain.ErrBookingNotActive
+			}
+		}
+		log.Printf("Failed to cancel %d bookings: %v", len(ids), err)
+		return fmt.Errorf("failed to cancel bookings: %w", err)
+	}
+
+	log.Printf("Cancelled %d bookings successfully", len(ids))
+	return nil
+}
+
+// ReassignAll hands the bookings over to userID in one transaction.
+func (repo *BookingRepositoryDynamoDB) ReassignAll(ids []string, userID string, updatedAt int64) error {
+	ctx := context.Background()
+
+	if len(ids) == 0 || len(ids) > maxTransactItems {
+		return domain.ErrInvalidInput
+	}
+	writes := make([]types.TransactWriteItem, 0, len(ids))
+	for _, id := range ids {
+		writes = append(writes, types.TransactWriteItem{Update: repo.reassignBooking(id, userID, updatedAt)})
+	}
+
+	_, err := repo.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: writes})
+	if err != nil {
+		for i := range writes {
+			if cancellationCode(err, i) == "ConditionalCheckFailed" {
+				return domain.ErrBookingNotActive
+			}
+		}
+		log.Printf("Failed to reassign %d bookings: %v", len(ids), err)
+		return fmt.Errorf("failed to reassign bookings: %w", err)
+	}
+
+	log.Printf("Reassigned %d bookings to user %s", len(ids), userID)
+	return nil
+}
+
+func (repo *BookingRepositoryDynamoDB) putBooking(booking *domain.Booking) (types.TransactWriteItem, error) {
 	if booking.ID == "" {
 		booking.ID = uuid.New().String()
 	}
@@ -41,7 +248,7 @@ func (repo *BookingRepositoryDynamoDB) Create(booking *domain.Booking) error {
 	booking.UpdatedAt = now
 
 	if booking.Status == "" {
-		booking.Status = "confirmed"
+		booking.Status = domain.BookingStatusConfirmed
 	}
 
 	startOfDay := (booking.StartTime / 86400) * 86400
@@ -59,27 +266,58 @@ func (repo *BookingRepositoryDynamoDB) Create(booking *domain.Booking) error {
 		Status:    booking.Status,
 		CreatedAt: booking.CreatedAt,
 		UpdatedAt: booking.UpdatedAt,
+		SeriesID:  booking.SeriesID,
+		GroupID:   booking.GroupID,
+
+		ApprovalExpiresAt: booking.ApprovalExpiresAt,
 	}
 
 	av, err := attributevalue.MarshalMap(item)
 	if err != nil {
 		log.Printf("Failed to marshal booking: %v", err)
-		return fmt.Errorf("failed to marshal booking: %w", err)
+		return types.TransactWriteItem{}, fmt.Errorf("failed to marshal booking: %w", err)
 	}
 
-	input := &dynamodb.PutItemInput{
-		TableName: aws.String(repo.table),
-		Item:      av,
-	}
+	return types.TransactWriteItem{
+		Put: &types.Put{
+			TableName:           aws.String(repo.table),
+			Item:                av,
+			ConditionExpression: aws.String("attribute_not_exists(SK)"),
+		},
+	}, nil
+}
 
-	_, err = repo.client.PutItem(ctx, input)
-	if err != nil {
-		log.Printf("Failed to create booking: %v", err)
-		return fmt.Errorf("failed to create booking: %w", err)
-	}
+func (repo *BookingRepositoryDynamoDB) updateBooking(booking *domain.Booking) types.TransactWriteItem {
+	startOfDay := (booking.StartTime / 86400) * 86400
 
-	log.Printf("Booking created successfully with ID: %s", booking.ID)
-	return nil
+	return types.TransactWriteItem{
+		Update: &types.Update{
+			TableName: aws.String(repo.table),
+			Key: map[string]types.AttributeValue{
+				"PK": &types.AttributeValueMemberS{Value: "BOOKING"},
+				"SK": &types.AttributeValueMemberS{Value: fmt.Sprintf("BOOKING#%s", booking.ID)},
+			},
+			UpdateExpression:    aws.String("SET RoomID = :roomId, SeriesID = :seriesId, StartTime = :start, EndTime = :end, #date = :date, Purpose = :purpose, #status = :status, ApprovalExpiresAt = :approvalExpiresAt, UpdatedAt = :updatedAt ADD #sequence :one"),
+			ConditionExpression: aws.String(changeableBookingCondition),
+			ExpressionAttributeNames: map[string]string{
+				"#date":     "Date",
+				"#status":   "Status",
+				"#sequence": "Sequence",
+			},
+			ExpressionAttributeValues: withChangeableStatuses(map[string]types.AttributeValue{
+				":roomId":            &types.AttributeValueMemberS{Value: booking.RoomID},
+				":seriesId":          &types.AttributeValueMemberS{Value: booking.SeriesID},
+				":start":             &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", booking.StartTime)},
+				":end":               &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", booking.EndTime)},
+				":date":              &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", startOfDay)},
+				":purpose":           &types.AttributeValueMemberS{Value: booking.Purpose},
+				":status":            &types.AttributeValueMemberS{Value: booking.Status},
+				":approvalExpiresAt": &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", booking.ApprovalExpiresAt)},
+				":updatedAt":         &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", booking.UpdatedAt)},
+				":one":               &types.AttributeValueMemberN{Value: "1"},
+			}),
+		},
+	}
 }
 
 func (repo *BookingRepositoryDynamoDB) GetByID(id string) (*domain.Booking, error) {
@@ -110,19 +348,9 @@ func (repo *BookingRepositoryDynamoDB) GetByID(id string) (*domain.Booking, erro
 		return nil, fmt.Errorf("failed to unmarshal booking: %w", err)
 	}
 
-	booking := &domain.Booking{
-		ID:        item.ID,
-		UserID:    item.UserID,
-		RoomID:    item.RoomID,
-		StartTime: item.StartTime,
-		EndTime:   item.EndTime,
-		Purpose:   item.Purpose,
-		Status:    item.Status,
-		CreatedAt: item.CreatedAt,
-		UpdatedAt: item.UpdatedAt,
-	}
+	booking := toDomainBooking(item)
 
-	return booking, nil
+	return &booking, nil
 }
 
 func (repo *BookingRepositoryDynamoDB) GetAll() ([]domain.Booking, error) {
@@ -155,17 +383,7 @@ func (repo *BookingRepositoryDynamoDB) GetAll() ([]domain.Booking, error) {
 
 	bookings := make([]domain.Booking, len(items))
 	for i, item := range items {
-		bookings[i] = domain.Booking{
-			ID:        item.ID,
-			UserID:    item.UserID,
-			RoomID:    item.RoomID,
-			StartTime: item.StartTime,
-			EndTime:   item.EndTime,
-			Purpose:   item.Purpose,
-			Status:    item.Status,
-			CreatedAt: item.CreatedAt,
-			UpdatedAt: item.UpdatedAt,
-		}
+		bookings[i] = toDomainBooking(item)
 	}
 
 	return bookings, nil
@@ -178,13 +396,16 @@ func (repo *BookingRepositoryDynamoDB) GetByRoomAndTime(roomID string, start, en
 		TableName:              aws.String(repo.table),
 		IndexName:              aws.String("LSI-5"),
 		KeyConditionExpression: aws.String("PK = :pk AND RoomID = :roomId"),
-		FilterExpression:       aws.String("EndTime > :start AND StartTime < :end"),
-		ExpressionAttributeValues: map[string]types.AttributeValue{
+		FilterExpression:       aws.String("EndTime > :start AND StartTime < :end AND " + activeBookingFilter),
+		ExpressionAttributeNames: map[string]string{
+			"#status": "Status",
+		},
+		ExpressionAttributeValues: withInactiveStatuses(map[string]types.AttributeValue{
 			":pk":     &types.AttributeValueMemberS{Value: "BOOKING"},
 			":roomId": &types.AttributeValueMemberS{Value: roomID},
 			":start":  &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", start)},
 			":end":    &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", end)},
-		},
+		}),
 	}
 
 	result, err := repo.client.Query(ctx, input)
@@ -206,17 +427,7 @@ func (repo *BookingRepositoryDynamoDB) GetByRoomAndTime(roomID string, start, en
 
 	bookings := make([]domain.Booking, len(items))
 	for i, item := range items {
-		bookings[i] = domain.Booking{
-			ID:        item.ID,
-			UserID:    item.UserID,
-			RoomID:    item.RoomID,
-			StartTime: item.StartTime,
-			EndTime:   item.EndTime,
-			Purpose:   item.Purpose,
-			Status:    item.Status,
-			CreatedAt: item.CreatedAt,
-			UpdatedAt: item.UpdatedAt,
-		}
+		bookings[i] = toDomainBooking(item)
 	}
 
 	return bookings, nil
@@ -254,17 +465,7 @@ func (repo *BookingRepositoryDynamoDB) GetByRoomID(roomID string) ([]domain.Book
 
 	bookings := make([]domain.Booking, len(items))
 	for i, item := range items {
-		bookings[i] = domain.Booking{
-			ID:        item.ID,
-			UserID:    item.UserID,
-			RoomID:    item.RoomID,
-			StartTime: item.StartTime,
-			EndTime:   item.EndTime,
-			Purpose:   item.Purpose,
-			Status:    item.Status,
-			CreatedAt: item.CreatedAt,
-			UpdatedAt: item.UpdatedAt,
-		}
+		bookings[i] = toDomainBooking(item)
 	}
 
 	return bookings, nil
@@ -302,44 +503,374 @@ func (repo *BookingRepositoryDynamoDB) GetByUserID(userID string) ([]domain.Book
 
 	bookings := make([]domain.Booking, len(items))
 	for i, item := range items {
-		bookings[i] = domain.Booking{
-			ID:        item.ID,
-			UserID:    item.UserID,
-			RoomID:    item.RoomID,
-			StartTime: item.StartTime,
-			EndTime:   item.EndTime,
-			Purpose:   item.Purpose,
-			Status:    item.Status,
-			CreatedAt: item.CreatedAt,
-			UpdatedAt: item.UpdatedAt,
-		}
+		bookings[i] = toDomainBooking(item)
 	}
 
 	return bookings, nil
 }
 
-func (repo *BookingRepositoryDynamoDB) Cancel(id string) error {
+func (repo *BookingRepositoryDynamoDB) Cancel(id, cancelledBy, reason string, cancelledAt int64) error {
+	ctx := context.Background()
+
+	update := repo.cancelBooking(id, cancelledBy, reason, cancelledAt)
+	input := &dynamodb.UpdateItemInput{
+		TableName:                 update.TableName,
+		Key:                       update.Key,
+		UpdateExpression:          update.UpdateExpression,
+		ConditionExpression:       update.ConditionExpression,
+		ExpressionAttributeNames:  update.ExpressionAttributeNames,
+		ExpressionAttributeValues: update.ExpressionAttributeValues,
+	}
+
+	_, err := repo.client.UpdateItem(ctx, input)
+	if err != nil {
+		var conditionFailed *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionFailed) {
+			if _, getErr := repo.GetByID(id); getErr != nil {
+				return getErr
+			}
+			return domain.ErrBookingNotActive
+		}
+		log.Printf("Failed to cancel booking: %v", err)
+		return fmt.Errorf("failed to cancel booking: %w", err)
+	}
+
+	log.Printf("Booking cancelled successfully: %s", id)
+	return nil
+}
+
+func (repo *BookingRepositoryDynamoDB) cancelBooking(id, cancelledBy, reason string, cancelledAt int64) *types.Update {
+	return &types.Update{
+		TableName: aws.String(repo.table),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: "BOOKING"},
+			"SK": &types.AttributeValueMemberS{Value: fmt.Sprintf("BOOKING#%s", id)},
+		},
+		UpdateExpression:    aws.String("SET #status = :cancelled, CancelledAt = :cancelledAt, CancelledBy = :cancelledBy, CancellationReason = :reason, UpdatedAt = :cancelledAt ADD #sequence :one"),
+		ConditionExpression: aws.String(changeableBookingCondition),
+		ExpressionAttributeNames: map[string]string{
+			"#status":   "Status",
+			"#sequence": "Sequence",
+		},
+		ExpressionAttributeValues: withChangeableStatuses(map[string]types.AttributeValue{
+			":cancelled":   &types.AttributeValueMemberS{Value: domain.BookingStatusCancelled},
+			":cancelledAt": &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", cancelledAt)},
+			":cancelledBy": &types.AttributeValueMemberS{Value: cancelledBy},
+			":reason":      &types.AttributeValueMemberS{Value: reason},
+			":one":         &types.AttributeValueMemberN{Value: "1"},
+		}),
+	}
+}
+
+func (repo *BookingRepositoryDynamoDB) deleteBooking(ctx context.Context, id string) error {
+	_, err := repo.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(repo.table),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: "BOOKING"},
+			"SK": &types.AttributeValueMemberS{Value: fmt.Sprintf("BOOKING#%s", id)},
+		},
+	})
+	return err
+}
+
+func (repo *BookingRepositoryDynamoDB) reassignBooking(id, userID string, updatedAt int64) *types.Update {
+	return &types.Update{
+		TableName: aws.String(repo.table),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: "BOOKING"},
+			"SK": &types.AttributeValueMemberS{Value: fmt.Sprintf("BOOKING#%s", id)},
+		},
+		UpdateExpression:    aws.String("SET UserID = :userID, UpdatedAt = :updatedAt ADD #sequence :one"),
+		ConditionExpression: aws.String(changeableBookingCondition),
+		ExpressionAttributeNames: map[string]string{
+			"#status":   "Status",
+			"#sequence": "Sequence",
+		},
+		ExpressionAttributeValues: withChangeableStatuses(map[string]types.AttributeValue{
+			":userID":    &types.AttributeValueMemberS{Value: userID},
+			":updatedAt": &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", updatedAt)},
+			":one":       &types.AttributeValueMemberN{Value: "1"},
+		}),
+	}
+}
+
+func (repo *BookingRepositoryDynamoDB) Review(id, status, reviewedBy, note string, reviewedAt int64) error {
 	ctx := context.Background()
 
-	input := &dynamodb.DeleteItemInput{
+	input := &dynamodb.UpdateItemInput{
 		TableName: aws.String(repo.table),
 		Key: map[string]types.AttributeValue{
 			"PK": &types.AttributeValueMemberS{Value: "BOOKING"},
 			"SK": &types.AttributeValueMemberS{Value: fmt.Sprintf("BOOKING#%s", id)},
 		},
-		ConditionExpression: aws.String("attribute_exists(PK) AND attribute_exists(SK)"),
+		UpdateExpression:    aws.String("SET #status = :status, ReviewedBy = :reviewedBy, ReviewedAt = :reviewedAt, ReviewNote = :note, UpdatedAt = :reviewedAt ADD #sequence :one"),
+		ConditionExpression: aws.String("attribute_exists(SK) AND #status = :pending"),
+		ExpressionAttributeNames: map[string]string{
+			"#status":   "Status",
+			"#sequence": "Sequence",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":status":     &types.AttributeValueMemberS{Value: status},
+			":pending":    &types.AttributeValueMemberS{Value: domain.BookingStatusPending},
+			":reviewedBy": &types.AttributeValueMemberS{Value: reviewedBy},
+			":reviewedAt": &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", reviewedAt)},
+			":note":       &types.AttributeValueMemberS{Value: note},
+			":one":        &types.AttributeValueMemberN{Value: "1"},
+		},
 	}
 
-	_, err := repo.client.DeleteItem(ctx, input)
+	_, err := repo.client.UpdateItem(ctx, input)
 	if err != nil {
-		log.Printf("Failed to delete booking: %v", err)
-		return fmt.Errorf("failed to delete booking: %w", err)
+		var conditionFailed *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionFailed) {
+			if _, getErr := repo.GetByID(id); getErr != nil {
+				return getErr
+			}
+			return domain.ErrBookingNotPending
+		}
+		log.Printf("Failed to review booking: %v", err)
+		return fmt.Errorf("failed to review booking: %w", err)
 	}
 
-	log.Printf("Booking deleted successfully: %s", id)
+	log.Printf("Booking %s marked %s", id, status)
+	return nil
+}
+
+func (repo *BookingRepositoryDynamoDB) CheckIn(id string, checkedInAt int64) error {
+	return repo.settleCheckIn(id, "SET CheckedInAt = :at, UpdatedAt = :at ADD #sequence :one", checkedInAt, nil)
+}
+
+func (repo *BookingRepositoryDynamoDB) MarkNoShow(id string, releasedAt int64) error {
+	return repo.settleCheckIn(id, "SET #status = :noShow, ReleasedAt = :at, UpdatedAt = :at ADD #sequence :one", releasedAt, map[string]types.AttributeValue{
+		":noShow": &types.AttributeValueMemberS{Value: domain.BookingStatusNoShow},
+	})
+}
+
+// settleCheckIn applies update to a confirmed booking nobody has checked in
+// to yet. update uses ":at" for the time and may add values of its own.
+func (repo *BookingRepositoryDynamoDB) settleCheckIn(id, update string, at int64, values map[string]types.AttributeValue) error {
+	ctx := context.Background()
+
+	if values == nil {
+		values = map[string]types.AttributeValue{}
+	}
+	values[":confirmed"] = &types.AttributeValueMemberS{Value: domain.BookingStatusConfirmed}
+	values[":at"] = &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", at)}
+	values[":one"] = &types.AttributeValueMemberN{Value: "1"}
+
+	input := &dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.table),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: "BOOKING"},
+			"SK": &types.AttributeValueMemberS{Value: fmt.Sprintf("BOOKING#%s", id)},
+		},
+		UpdateExpression:    aws.String(update),
+		ConditionExpression: aws.String("attribute_exists(SK) AND #status = :confirmed AND attribute_not_exists(CheckedInAt)"),
+		ExpressionAttributeNames: map[string]string{
+			"#status":   "Status",
+			"#sequence": "Sequence",
+		},
+		ExpressionAttributeValues: values,
+	}
+
+	_, err := repo.client.UpdateItem(ctx, input)
+	if err != nil {
+		var conditionFailed *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionFailed) {
+			booking, getErr := repo.GetByID(id)
+			if getErr != nil {
+				return getErr
+			}
+			if booking.Status == domain.BookingStatusConfirmed {
+				return domain.ErrAlreadyCheckedIn
+			}
+			return domain.ErrBookingNotActive
+		}
+		log.Printf("Failed to settle check-in of booking %s: %v", id, err)
+		return fmt.Errorf("failed to settle check-in of booking: %w", err)
+	}
 	return nil
 }
 
+func (repo *BookingRepositoryDynamoDB) GetAwaitingCheckIn(startedBefore, endsAfter int64) ([]domain.Booking, error) {
+	ctx := context.Background()
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(repo.table),
+		KeyConditionExpression: aws.String("PK = :pk"),
+		FilterExpression:       aws.String("#status = :confirmed AND attribute_not_exists(CheckedInAt) AND StartTime <= :startedBefore AND EndTime > :endsAfter"),
+		ExpressionAttributeNames: map[string]string{
+			"#status": "Status",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":            &types.AttributeValueMemberS{Value: "BOOKING"},
+			":confirmed":     &types.AttributeValueMemberS{Value: domain.BookingStatusConfirmed},
+			":startedBefore": &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", startedBefore)},
+			":endsAfter":     &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", endsAfter)},
+		},
+	}
+
+	return repo.queryBookings(ctx, input, "awaiting check-in")
+}
+
+func (repo *BookingRepositoryDynamoDB) CompleteEnded(endedBefore, unclaimedEndedBefore, completedAt int64) (int, error) {
+	ctx := context.Background()
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(repo.table),
+		KeyConditionExpression: aws.String("PK = :pk"),
+		FilterExpression:       aws.String("#status = :confirmed AND EndTime <= :endedBefore AND (attribute_exists(CheckedInAt) OR EndTime <= :unclaimedEndedBefore)"),
+		ExpressionAttributeNames: map[string]string{
+			"#status": "Status",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":                   &types.AttributeValueMemberS{Value: "BOOKING"},
+			":confirmed":            &types.AttributeValueMemberS{Value: domain.BookingStatusConfirmed},
+			":endedBefore":          &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", endedBefore)},
+			":unclaimedEndedBefore": &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", unclaimedEndedBefore)},
+		},
+	}
+	bookings, err := repo.queryBookings(ctx, input, "ended")
+	if err != nil && err != domain.ErrNotFound {
+		return 0, err
+	}
+
+	completed := 0
+	for _, booking := range bookings {
+		_, err := repo.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+			TableName: aws.String(repo.table),
+			Key: map[string]types.AttributeValue{
+				"PK": &types.AttributeValueMemberS{Value: "BOOKING"},
+				"SK": &types.AttributeValueMemberS{Value: fmt.Sprintf("BOOKING#%s", booking.ID)},
+			},
+			UpdateExpression:    aws.String("SET #status = :completed, UpdatedAt = :at"),
+			ConditionExpression: aws.String("#status = :confirmed"),
+			ExpressionAttributeNames: map[string]string{
+				"#status": "Status",
+			},
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":completed": &types.AttributeValueMemberS{Value: domain.BookingStatusCompleted},
+				":confirmed": &types.AttributeValueMemberS{Value: domain.BookingStatusConfirmed},
+				":at":        &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", completedAt)},
+			},
+		})
+		if err != nil {
+			var conditionFailed *types.ConditionalCheckFailedException
+			if errors.As(err, &conditionFailed) {
+				continue
+			}
+			log.Printf("Failed to complete booking %s: %v", booking.ID, err)
+			return completed, fmt.Errorf("failed to complete booking: %w", err)
+		}
+		completed++
+	}
+	return completed, nil
+}
+
+func (repo *BookingRepositoryDynamoDB) GetByStatus(status string) ([]domain.Booking, error) {
+	ctx := context.Background()
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(repo.table),
+		KeyConditionExpression: aws.String("PK = :pk"),
+		FilterExpression:       aws.String("#status = :status"),
+		ExpressionAttributeNames: map[string]string{
+			"#status": "Status",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":     &types.AttributeValueMemberS{Value: "BOOKING"},
+			":status": &types.AttributeValueMemberS{Value: status},
+		},
+	}
+
+	return repo.queryBookings(ctx, input, "by status")
+}
+
+func (repo *BookingRepositoryDynamoDB) GetByUserIDAndStatus(userID, status string) ([]domain.Booking, error) {
+	ctx := context.Background()
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(repo.table),
+		IndexName:              aws.String("LSI-3"),
+		KeyConditionExpression: aws.String("PK = :pk AND UserID = :userId"),
+		FilterExpression:       aws.String("#status = :status"),
+		ExpressionAttributeNames: map[string]string{
+			"#status": "Status",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":     &types.AttributeValueMemberS{Value: "BOOKING"},
+			":userId": &types.AttributeValueMemberS{Value: userID},
+			":status": &types.AttributeValueMemberS{Value: status},
+		},
+	}
+
+	return repo.queryBookings(ctx, input, "by user and status")
+}
+
+func (repo *BookingRepositoryDynamoDB) GetBySeriesID(seriesID string) ([]domain.Booking, error) {
+	ctx := context.Background()
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(repo.table),
+		KeyConditionExpression: aws.String("PK = :pk"),
+		FilterExpression:       aws.String("SeriesID = :seriesId"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":       &types.AttributeValueMemberS{Value: "BOOKING"},
+			":seriesId": &types.AttributeValueMemberS{Value: seriesID},
+		},
+	}
+
+	bookings, err := repo.queryBookings(ctx, input, "by series")
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(bookings, func(i, j int) bool { return bookings[i].StartTime < bookings[j].StartTime })
+	return bookings, nil
+}
+
+func (repo *BookingRepositoryDynamoDB) GetByGroupID(groupID string) ([]domain.Booking, error) {
+	ctx := context.Background()
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(repo.table),
+		KeyConditionExpression: aws.String("PK = :pk"),
+		FilterExpression:       aws.String("GroupID = :groupId"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":      &types.AttributeValueMemberS{Value: "BOOKING"},
+			":groupId": &types.AttributeValueMemberS{Value: groupID},
+		},
+	}
+
+	bookings, err := repo.queryBookings(ctx, input, "by group")
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(bookings, func(i, j int) bool { return bookings[i].RoomID < bookings[j].RoomID })
+	return bookings, nil
+}
+
+func (repo *BookingRepositoryDynamoDB) queryBookings(ctx context.Context, input *dynamodb.QueryInput, description string) ([]domain.Booking, error) {
+	bookings := []domain.Booking{}
+	paginator := dynamodb.NewQueryPaginator(repo.client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			log.Printf("Failed to get bookings %s: %v", description, err)
+			return nil, fmt.Errorf("failed to get bookings %s: %w", description, err)
+		}
+
+		var items []dto.BookingDynamoDBItem
+		if err := attributevalue.UnmarshalListOfMaps(page.Items, &items); err != nil {
+			log.Printf("Failed to unmarshal bookings: %v", err)
+			return nil, fmt.Errorf("failed to unmarshal bookings: %w", err)
+		}
+		for _, item := range items {
+			bookings = append(bookings, toDomainBooking(item))
+		}
+	}
+	return bookings, nil
+}
+
 func (repo *BookingRepositoryDynamoDB) GetByDateRange(startDate, endDate int64) ([]domain.Booking, error) {
 	ctx := context.Background()
 
@@ -347,14 +878,16 @@ func (repo *BookingRepositoryDynamoDB) GetByDateRange(startDate, endDate int64)
 		TableName:              aws.String(repo.table),
 		IndexName:              aws.String("LSI-4"),
 		KeyConditionExpression: aws.String("PK = :pk AND #date BETWEEN :startDate AND :endDate"),
+		FilterExpression:       aws.String(activeBookingFilter),
 		ExpressionAttributeNames: map[string]string{
-			"#date": "Date",
+			"#date":   "Date",
+			"#status": "Status",
 		},
-		ExpressionAttributeValues: map[string]types.AttributeValue{
+		ExpressionAttributeValues: withInactiveStatuses(map[string]types.AttributeValue{
 			":pk":        &types.AttributeValueMemberS{Value: "BOOKING"},
 			":startDate": &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", startDate)},
 			":endDate":   &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", endDate)},
-		},
+		}),
 	}
 
 	result, err := repo.client.Query(ctx, input)
@@ -376,17 +909,7 @@ func (repo *BookingRepositoryDynamoDB) GetByDateRange(startDate, endDate int64)
 
 	bookings := make([]domain.Booking, len(items))
 	for i, item := range items {
-		bookings[i] = domain.Booking{
-			ID:        item.ID,
-			UserID:    item.UserID,
-			RoomID:    item.RoomID,
-			StartTime: item.StartTime,
-			EndTime:   item.EndTime,
-			Purpose:   item.Purpose,
-			Status:    item.Status,
-			CreatedAt: item.CreatedAt,
-			UpdatedAt: item.UpdatedAt,
-		}
+		bookings[i] = toDomainBooking(item)
 	}
 
 	return bookings, nil
@@ -401,15 +924,16 @@ func (repo *BookingRepositoryDynamoDB) GetByRoomIDAndDate(roomID string, date in
 		TableName:              aws.String(repo.table),
 		IndexName:              aws.String("LSI-5"),
 		KeyConditionExpression: aws.String("PK = :pk AND RoomID = :roomId"),
-		FilterExpression:       aws.String("#date = :date"),
+		FilterExpression:       aws.String("#date = :date AND " + activeBookingFilter),
 		ExpressionAttributeNames: map[string]string{
-			"#date": "Date",
+			"#date":   "Date",
+			"#status": "Status",
 		},
-		ExpressionAttributeValues: map[string]types.AttributeValue{
+		ExpressionAttributeValues: withInactiveStatuses(map[string]types.AttributeValue{
 			":pk":     &types.AttributeValueMemberS{Value: "BOOKING"},
 			":roomId": &types.AttributeValueMemberS{Value: roomID},
 			":date":   &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", startOfDay)},
-		},
+		}),
 	}
 
 	result, err := repo.client.Query(ctx, input)
@@ -431,17 +955,7 @@ func (repo *BookingRepositoryDynamoDB) GetByRoomIDAndDate(roomID string, date in
 
 	bookings := make([]domain.Booking, len(items))
 	for i, item := range items {
-		bookings[i] = domain.Booking{
-			ID:        item.ID,
-			UserID:    item.UserID,
-			RoomID:    item.RoomID,
-			StartTime: item.StartTime,
-			EndTime:   item.EndTime,
-			Purpose:   item.Purpose,
-			Status:    item.Status,
-			CreatedAt: item.CreatedAt,
-			UpdatedAt: item.UpdatedAt,
-		}
+		bookings[i] = toDomainBooking(item)
 	}
 
 	return bookings, nil
@@ -0,0 +1,156 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/ports"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+)
+
+// Calendar tokens are keyed by the hash of their secret so feed requests
+// resolve with a single GetItem.
+const calendarTokenPK = "CALTOKEN"
+
+type CalendarTokenRepositoryDynamoDB struct {
+	client *dynamodb.Client
+	table  string
+}
+
+func NewCalendarTokenRepositoryDynamoDB(client *dynamodb.Client, tableName string) ports.CalendarTokenRepository {
+	return &CalendarTokenRepositoryDynamoDB{
+		client: client,
+		table:  tableName,
+	}
+}
+
+func calendarTokenSK(tokenHash string) string {
+	return fmt.Sprintf("CALTOKEN#%s", tokenHash)
+}
+
+func toDomainCalendarToken(item dto.CalendarTokenDynamoDBItem) domain.CalendarToken {
+	return domain.CalendarToken{
+		ID:        item.ID,
+		UserID:    item.UserID,
+		TokenHash: item.TokenHash,
+		CreatedAt: item.CreatedAt,
+	}
+}
+
+func (repo *CalendarTokenRepositoryDynamoDB) Create(token *domain.CalendarToken) error {
+	if token == nil {
+		return domain.ErrInvalidInput
+	}
+
+	av, err := attributevalue.MarshalMap(dto.CalendarTokenDynamoDBItem{
+		PK:        calendarTokenPK,
+		SK:        calendarTokenSK(token.TokenHash),
+		ID:        token.ID,
+		UserID:    token.UserID,
+		TokenHash: token.TokenHash,
+		CreatedAt: token.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal calendar token: %w", err)
+	}
+
+	_, err = repo.client.PutItem(context.Background(), &dynamodb.PutItemInput{
+		TableName:           aws.String(repo.table),
+		Item:                av,
+		ConditionExpression: aws.String("attribute_not_exists(SK)"),
+	})
+	if err != nil {
+		var conditionFailed *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionFailed) {
+			return domain.ErrConflict
+		}
+		log.Printf("Failed to create calendar token: %v", err)
+		return fmt.Errorf("failed to create calendar token: %w", err)
+	}
+	return nil
+}
+
+func (repo *CalendarTokenRepositoryDynamoDB) GetByHash(tokenHash string) (*domain.CalendarToken, error) {
+	result, err := repo.client.GetItem(context.Background(), &dynamodb.GetItemInput{
+		TableName: aws.String(repo.table),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: calendarTokenPK},
+			"SK": &types.AttributeValueMemberS{Value: calendarTokenSK(tokenHash)},
+		},
+	})
+	if err != nil {
+		log.Printf("Failed to get calendar token: %v", err)
+		return nil, fmt.Errorf("failed to get calendar token: %w", err)
+	}
+	if result.Item == nil {
+		return nil, domain.ErrNotFound
+	}
+
+	var item dto.CalendarTokenDynamoDBItem
+	if err := attributevalue.UnmarshalMap(result.Item, &item); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal calendar token: %w", err)
+	}
+	token := toDomainCalendarToken(item)
+	return &token, nil
+}
+
+func (repo *CalendarTokenRepositoryDynamoDB) GetByUserID(userID string) ([]domain.CalendarToken, error) {
+	result, err := repo.client.Query(context.Background(), &dynamodb.QueryInput{
+		TableName:              aws.String(repo.table),
+		IndexName:              aws.String("LSI-3"),
+		KeyConditionExpression: aws.String("PK = :pk AND UserID = :userId"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":     &types.AttributeValueMemberS{Value: calendarTokenPK},
+			":userId": &types.AttributeValueMemberS{Value: userID},
+		},
+	})
+	if err != nil {
+		log.Printf("Failed to get calendar tokens: %v", err)
+		return nil, fmt.Errorf("failed to get calendar tokens: %w", err)
+	}
+
+	var items []dto.CalendarTokenDynamoDBItem
+	if err := attributevalue.UnmarshalListOfMaps(result.Items, &items); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal calendar tokens: %w", err)
+	}
+
+	tokens := make([]domain.CalendarToken, 0, len(items))
+	for _, item := range items {
+		tokens = append(tokens, toDomainCalendarToken(item))
+	}
+	return tokens, nil
+}
+
+func (repo *CalendarTokenRepositoryDynamoDB) Delete(id, userID string) error {
+	tokens, err := repo.GetByUserID(userID)
+	if err != nil {
+		return err
+	}
+
+	for _, token := range tokens {
+		if token.ID != id {
+			continue
+		}
+		_, err := repo.client.DeleteItem(context.Background(), &dynamodb.DeleteItemInput{
+			TableName: aws.String(repo.table),
+			Key: map[string]types.AttributeValue{
+				"PK": &types.AttributeValueMemberS{Value: calendarTokenPK},
+				"SK": &types.AttributeValueMemberS{Value: calendarTokenSK(token.TokenHash)},
+			},
+		})
+		if err != nil {
+			log.Printf("Failed to delete calendar token: %v", err)
+			return fmt.Errorf("failed to delete calendar token: %w", err)
+		}
+		return nil
+	}
+	return domain.ErrNotFound
+}
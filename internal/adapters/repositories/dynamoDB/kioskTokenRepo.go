@@ -0,0 +1,160 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/ports"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+)
+
+// Kiosk tokens are keyed by the hash of their secret so check-ins resolve
+// with a single GetItem.
+const kioskTokenPK = "KIOSKTOKEN"
+
+type KioskTokenRepositoryDynamoDB struct {
+	client *dynamodb.Client
+	table  string
+}
+
+func NewKioskTokenRepositoryDynamoDB(client *dynamodb.Client, tableName string) ports.KioskTokenRepository {
+	return &KioskTokenRepositoryDynamoDB{
+		client: client,
+		table:  tableName,
+	}
+}
+
+func kioskTokenSK(tokenHash string) string {
+	return fmt.Sprintf("KIOSKTOKEN#%s", tokenHash)
+}
+
+func toDomainKioskToken(item dto.KioskTokenDynamoDBItem) domain.KioskToken {
+	return domain.KioskToken{
+		ID:        item.ID,
+		RoomID:    item.RoomID,
+		Name:      item.Name,
+		TokenHash: item.TokenHash,
+		CreatedBy: item.CreatedBy,
+		CreatedAt: item.CreatedAt,
+	}
+}
+
+func (repo *KioskTokenRepositoryDynamoDB) Create(token *domain.KioskToken) error {
+	if token == nil {
+		return domain.ErrInvalidInput
+	}
+
+	av, err := attributevalue.MarshalMap(dto.KioskTokenDynamoDBItem{
+		PK:        kioskTokenPK,
+		SK:        kioskTokenSK(token.TokenHash),
+		ID:        token.ID,
+		RoomID:    token.RoomID,
+		Name:      token.Name,
+		TokenHash: token.TokenHash,
+		CreatedBy: token.CreatedBy,
+		CreatedAt: token.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal kiosk token: %w", err)
+	}
+
+	_, err = repo.client.PutItem(context.Background(), &dynamodb.PutItemInput{
+		TableName:           aws.String(repo.table),
+		Item:                av,
+		ConditionExpression: aws.String("attribute_not_exists(SK)"),
+	})
+	if err != nil {
+		var conditionFailed *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionFailed) {
+			return domain.ErrConflict
+		}
+		log.Printf("Failed to create kiosk token: %v", err)
+		return fmt.Errorf("failed to create kiosk token: %w", err)
+	}
+	return nil
+}
+
+func (repo *KioskTokenRepositoryDynamoDB) GetByHash(tokenHash string) (*domain.KioskToken, error) {
+	result, err := repo.client.GetItem(context.Background(), &dynamodb.GetItemInput{
+		TableName: aws.String(repo.table),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: kioskTokenPK},
+			"SK": &types.AttributeValueMemberS{Value: kioskTokenSK(tokenHash)},
+		},
+	})
+	if err != nil {
+		log.Printf("Failed to get kiosk token: %v", err)
+		return nil, fmt.Errorf("failed to get kiosk token: %w", err)
+	}
+	if result.Item == nil {
+		return nil, domain.ErrNotFound
+	}
+
+	var item dto.KioskTokenDynamoDBItem
+	if err := attributevalue.UnmarshalMap(result.Item, &item); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal kiosk token: %w", err)
+	}
+	token := toDomainKioskToken(item)
+	return &token, nil
+}
+
+func (repo *KioskTokenRepositoryDynamoDB) GetByRoomID(roomID string) ([]domain.KioskToken, error) {
+	result, err := repo.client.Query(context.Background(), &dynamodb.QueryInput{
+		TableName:              aws.String(repo.table),
+		IndexName:              aws.String("LSI-5"),
+		KeyConditionExpression: aws.String("PK = :pk AND RoomID = :roomId"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":     &types.AttributeValueMemberS{Value: kioskTokenPK},
+			":roomId": &types.AttributeValueMemberS{Value: roomID},
+		},
+	})
+	if err != nil {
+		log.Printf("Failed to get kiosk tokens: %v", err)
+		return nil, fmt.Errorf("failed to get kiosk tokens: %w", err)
+	}
+
+	var items []dto.KioskTokenDynamoDBItem
+	if err := attributevalue.UnmarshalListOfMaps(result.Items, &items); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal kiosk tokens: %w", err)
+	}
+
+	tokens := make([]domain.KioskToken, 0, len(items))
+	for _, item := range items {
+		tokens = append(tokens, toDomainKioskToken(item))
+	}
+	return tokens, nil
+}
+
+func (repo *KioskTokenRepositoryDynamoDB) Delete(id, roomID string) error {
+	tokens, err := repo.GetByRoomID(roomID)
+	if err != nil {
+		return err
+	}
+
+	for _, token := range tokens {
+		if token.ID != id {
+			continue
+		}
+		_, err := repo.client.DeleteItem(context.Background(), &dynamodb.DeleteItemInput{
+			TableName: aws.String(repo.table),
+			Key: map[string]types.AttributeValue{
+				"PK": &types.AttributeValueMemberS{Value: kioskTokenPK},
+				"SK": &types.AttributeValueMemberS{Value: kioskTokenSK(token.TokenHash)},
+			},
+		})
+		if err != nil {
+			log.Printf("Failed to delete kiosk token: %v", err)
+			return fmt.Errorf("failed to delete kiosk token: %w", err)
+		}
+		return nil
+	}
+	return domain.ErrNotFound
+}
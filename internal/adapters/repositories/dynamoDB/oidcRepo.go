@@ -0,0 +1,183 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/ports"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+)
+
+// Login states expire through the table TTL when a login is abandoned.
+// Identity links are keyed by issuer and subject, so a single sign-on
+// resolves its user with one GetItem.
+const (
+	oidcStatePK    = "OIDCSTATE"
+	userIdentityPK = "IDENTITY"
+)
+
+type OIDCLoginStateRepositoryDynamoDB struct {
+	client *dynamodb.Client
+	table  string
+}
+
+func NewOIDCLoginStateRepositoryDynamoDB(client *dynamodb.Client, tableName string) ports.OIDCLoginStateRepository {
+	return &OIDCLoginStateRepositoryDynamoDB{
+		client: client,
+		table:  tableName,
+	}
+}
+
+func oidcStateSK(state string) string {
+	return fmt.Sprintf("OIDCSTATE#%s", state)
+}
+
+func (repo *OIDCLoginStateRepositoryDynamoDB) Create(state *domain.OIDCLoginState) error {
+	if state == nil {
+		return domain.ErrInvalidInput
+	}
+
+	av, err := attributevalue.MarshalMap(dto.OIDCLoginStateDynamoDBItem{
+		PK:           oidcStatePK,
+		SK:           oidcStateSK(state.State),
+		State:        state.State,
+		Nonce:        state.Nonce,
+		CodeVerifier: state.CodeVerifier,
+		ExpiresAt:    state.ExpiresAt,
+		CreatedAt:    state.CreatedAt,
+		ExpireAfter:  state.ExpiresAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal login state: %w", err)
+	}
+
+	_, err = repo.client.PutItem(context.Background(), &dynamodb.PutItemInput{
+		TableName:           aws.String(repo.table),
+		Item:                av,
+		ConditionExpression: aws.String("attribute_not_exists(SK)"),
+	})
+	if err != nil {
+		var conditionFailed *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionFailed) {
+			return domain.ErrConflict
+		}
+		log.Printf("Failed to create login state: %v", err)
+		return fmt.Errorf("failed to create login state: %w", err)
+	}
+	return nil
+}
+
+func (repo *OIDCLoginStateRepositoryDynamoDB) Consume(state string) (*domain.OIDCLoginState, error) {
+	result, err := repo.client.DeleteItem(context.Background(), &dynamodb.DeleteItemInput{
+		TableName: aws.String(repo.table),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: oidcStatePK},
+			"SK": &types.AttributeValueMemberS{Value: oidcStateSK(state)},
+		},
+		ReturnValues: types.ReturnValueAllOld,
+	})
+	if err != nil {
+		log.Printf("Failed to consume login state: %v", err)
+		return nil, fmt.Errorf("failed to consume login state: %w", err)
+	}
+	if len(result.Attributes) == 0 {
+		return nil, domain.ErrNotFound
+	}
+
+	var item dto.OIDCLoginStateDynamoDBItem
+	if err := attributevalue.UnmarshalMap(result.Attributes, &item); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal login state: %w", err)
+	}
+	return &domain.OIDCLoginState{
+		State:        item.State,
+		Nonce:        item.Nonce,
+		CodeVerifier: item.CodeVerifier,
+		ExpiresAt:    item.ExpiresAt,
+		CreatedAt:    item.CreatedAt,
+	}, nil
+}
+
+type UserIdentityRepositoryDynamoDB struct {
+	client *dynamodb.Client
+	table  string
+}
+
+func NewUserIdentityRepositoryDynamoDB(client *dynamodb.Client, tableName string) ports.UserIdentityRepository {
+	return &UserIdentityRepositoryDynamoDB{
+		client: client,
+		table:  tableName,
+	}
+}
+
+func userIdentitySK(issuer, subject string) string {
+	return fmt.Sprintf("IDENTITY#%s#%s", issuer, subject)
+}
+
+func (repo *UserIdentityRepositoryDynamoDB) Create(identity *domain.UserIdentity) error {
+	if identity == nil {
+		return domain.ErrInvalidInput
+	}
+
+	av, err := attributevalue.MarshalMap(dto.UserIdentityDynamoDBItem{
+		PK:        userIdentityPK,
+		SK:        userIdentitySK(identity.Issuer, identity.Subject),
+		Issuer:    identity.Issuer,
+		Subject:   identity.Subject,
+		UserID:    identity.UserID,
+		CreatedAt: identity.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal user identity: %w", err)
+	}
+
+	_, err = repo.client.PutItem(context.Background(), &dynamodb.PutItemInput{
+		TableName:           aws.String(repo.table),
+		Item:                av,
+		ConditionExpression: aws.String("attribute_not_exists(SK)"),
+	})
+	if err != nil {
+		var conditionFailed *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionFailed) {
+			return domain.ErrConflict
+		}
+		log.Printf("Failed to create user identity: %v", err)
+		return fmt.Errorf("failed to create user identity: %w", err)
+	}
+	return nil
+}
+
+func (repo *UserIdentityRepositoryDynamoDB) GetBySubject(issuer, subject string) (*domain.UserIdentity, error) {
+	result, err := repo.client.GetItem(context.Background(), &dynamodb.GetItemInput{
+		TableName: aws.String(repo.table),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: userIdentityPK},
+			"SK": &types.AttributeValueMemberS{Value: userIdentitySK(issuer, subject)},
+		},
+	})
+	if err != nil {
+		log.Printf("Failed to get user identity: %v", err)
+		return nil, fmt.Errorf("failed to get user identity: %w", err)
+	}
+	if result.Item == nil {
+		return nil, domain.ErrNotFound
+	}
+
+	var item dto.UserIdentityDynamoDBItem
+	if err := attributevalue.UnmarshalMap(result.Item, &item); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal user identity: %w", err)
+	}
+	return &domain.UserIdentity{
+		Issuer:    item.Issuer,
+		Subject:   item.Subject,
+		UserID:    item.UserID,
+		CreatedAt: item.CreatedAt,
+	}, nil
+}
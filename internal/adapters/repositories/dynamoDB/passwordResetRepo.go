@@ -0,0 +1,161 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/ports"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+)
+
+// Reset tokens are keyed by the hash of their secret, like calendar tokens,
+// so a reset request resolves with a single GetItem.
+const passwordResetPK = "PWRESET"
+
+type PasswordResetTokenRepositoryDynamoDB struct {
+	client *dynamodb.Client
+	table  string
+}
+
+func NewPasswordResetTokenRepositoryDynamoDB(client *dynamodb.Client, tableName string) ports.PasswordResetTokenRepository {
+	return &PasswordResetTokenRepositoryDynamoDB{
+		client: client,
+		table:  tableName,
+	}
+}
+
+func passwordResetSK(tokenHash string) string {
+	return fmt.Sprintf("PWRESET#%s", tokenHash)
+}
+
+func (repo *PasswordResetTokenRepositoryDynamoDB) Create(token *domain.PasswordResetToken) error {
+	if token == nil {
+		return domain.ErrInvalidInput
+	}
+
+	av, err := attributevalue.MarshalMap(dto.PasswordResetTokenDynamoDBItem{
+		PK:        passwordResetPK,
+		SK:        passwordResetSK(token.TokenHash),
+		ID:        token.ID,
+		UserID:    token.UserID,
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt,
+		UsedAt:    token.UsedAt,
+		CreatedAt: token.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal password reset token: %w", err)
+	}
+
+	_, err = repo.client.PutItem(context.Background(), &dynamodb.PutItemInput{
+		TableName:           aws.String(repo.table),
+		Item:                av,
+		ConditionExpression: aws.String("attribute_not_exists(SK)"),
+	})
+	if err != nil {
+		var conditionFailed *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionFailed) {
+			return domain.ErrConflict
+		}
+		log.Printf("Failed to create password reset token: %v", err)
+		return fmt.Errorf("failed to create password reset token: %w", err)
+	}
+	return nil
+}
+
+func (repo *PasswordResetTokenRepositoryDynamoDB) GetByHash(tokenHash string) (*domain.PasswordResetToken, error) {
+	result, err := repo.client.GetItem(context.Background(), &dynamodb.GetItemInput{
+		TableName: aws.String(repo.table),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: passwordResetPK},
+			"SK": &types.AttributeValueMemberS{Value: passwordResetSK(tokenHash)},
+		},
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		log.Printf("Failed to get password reset token: %v", err)
+		return nil, fmt.Errorf("failed to get password reset token: %w", err)
+	}
+	if result.Item == nil {
+		return nil, domain.ErrNotFound
+	}
+
+	var item dto.PasswordResetTokenDynamoDBItem
+	if err := attributevalue.UnmarshalMap(result.Item, &item); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal password reset token: %w", err)
+	}
+	return &domain.PasswordResetToken{
+		ID:        item.ID,
+		UserID:    item.UserID,
+		TokenHash: item.TokenHash,
+		ExpiresAt: item.ExpiresAt,
+		UsedAt:    item.UsedAt,
+		CreatedAt: item.CreatedAt,
+	}, nil
+}
+
+// MarkUsed consumes the token. It returns ErrNotFound when the token was
+// already used, so only one of two concurrent resets can succeed.
+func (repo *PasswordResetTokenRepositoryDynamoDB) MarkUsed(tokenHash string, usedAt int64) error {
+	_, err := repo.client.UpdateItem(context.Background(), &dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.table),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: passwordResetPK},
+			"SK": &types.AttributeValueMemberS{Value: passwordResetSK(tokenHash)},
+		},
+		UpdateExpression:    aws.String("SET UsedAt = :usedAt"),
+		ConditionExpression: aws.String("attribute_exists(SK) AND UsedAt = :unused"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":usedAt": &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", usedAt)},
+			":unused": &types.AttributeValueMemberN{Value: "0"},
+		},
+	})
+	if err != nil {
+		var conditionFailed *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionFailed) {
+			return domain.ErrNotFound
+		}
+		log.Printf("Failed to mark password reset token used: %v", err)
+		return fmt.Errorf("failed to mark password reset token used: %w", err)
+	}
+	return nil
+}
+
+func (repo *PasswordResetTokenRepositoryDynamoDB) DeleteByUserID(userID string) error {
+	result, err := repo.client.Query(context.Background(), &dynamodb.QueryInput{
+		TableName:              aws.String(repo.table),
+		IndexName:              aws.String("LSI-3"),
+		KeyConditionExpression: aws.String("PK = :pk AND UserID = :userId"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":     &types.AttributeValueMemberS{Value: passwordResetPK},
+			":userId": &types.AttributeValueMemberS{Value: userID},
+		},
+	})
+	if err != nil {
+		log.Printf("Failed to get password reset tokens: %v", err)
+		return fmt.Errorf("failed to get password reset tokens: %w", err)
+	}
+
+	for _, item := range result.Items {
+		_, err := repo.client.DeleteItem(context.Background(), &dynamodb.DeleteItemInput{
+			TableName: aws.String(repo.table),
+			Key: map[string]types.AttributeValue{
+				"PK": item["PK"],
+				"SK": item["SK"],
+			},
+		})
+		if err != nil {
+			log.Printf("Failed to delete password reset token: %v", err)
+			return fmt.Errorf("failed to delete password reset token: %w", err)
+		}
+	}
+	return nil
+}
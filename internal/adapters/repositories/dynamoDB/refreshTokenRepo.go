@@ -0,0 +1,304 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/ports"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+)
+
+// Refresh tokens are keyed by the hash of their secret so a refresh resolves
+// with a single GetItem; a user's sessions are found through LSI-3. Items
+// carry ExpireAfter so the table's TTL removes them once they expire.
+const (
+	refreshTokenPK = "REFRESH"
+	revokedTokenPK = "REVOKED"
+)
+
+type RefreshTokenRepositoryDynamoDB struct {
+	client *dynamodb.Client
+	table  string
+}
+
+func NewRefreshTokenRepositoryDynamoDB(client *dynamodb.Client, tableName string) ports.RefreshTokenRepository {
+	return &RefreshTokenRepositoryDynamoDB{
+		client: client,
+		table:  tableName,
+	}
+}
+
+func refreshTokenSK(tokenHash string) string {
+	return fmt.Sprintf("REFRESH#%s", tokenHash)
+}
+
+func (repo *RefreshTokenRepositoryDynamoDB) Create(token *domain.RefreshToken) error {
+	if token == nil {
+		return domain.ErrInvalidInput
+	}
+
+	av, err := attributevalue.MarshalMap(dto.RefreshTokenDynamoDBItem{
+		PK:          refreshTokenPK,
+		SK:          refreshTokenSK(token.TokenHash),
+		ID:          token.ID,
+		UserID:      token.UserID,
+		SessionID:   token.SessionID,
+		TokenHash:   token.TokenHash,
+		ExpiresAt:   token.ExpiresAt,
+		UsedAt:      token.UsedAt,
+		CreatedAt:   token.CreatedAt,
+		ExpireAfter: token.ExpiresAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal refresh token: %w", err)
+	}
+
+	_, err = repo.client.PutItem(context.Background(), &dynamodb.PutItemInput{
+		TableName:           aws.String(repo.table),
+		Item:                av,
+		ConditionExpression: aws.String("attribute_not_exists(SK)"),
+	})
+	if err != nil {
+		var conditionFailed *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionFailed) {
+			return domain.ErrConflict
+		}
+		log.Printf("Failed to create refresh token: %v", err)
+		return fmt.Errorf("failed to create refresh token: %w", err)
+	}
+	return nil
+}
+
+func (repo *RefreshTokenRepositoryDynamoDB) GetByHash(tokenHash string) (*domain.RefreshToken, error) {
+	result, err := repo.client.GetItem(context.Background(), &dynamodb.GetItemInput{
+		TableName: aws.String(repo.table),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: refreshTokenPK},
+			"SK": &types.AttributeValueMemberS{Value: refreshTokenSK(tokenHash)},
+		},
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		log.Printf("Failed to get refresh token: %v", err)
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+	if result.Item == nil {
+		return nil, domain.ErrNotFound
+	}
+
+	var item dto.RefreshTokenDynamoDBItem
+	if err := attributevalue.UnmarshalMap(result.Item, &item); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal refresh token: %w", err)
+	}
+	token := toDomainRefreshToken(item)
+	return &token, nil
+}
+
+// MarkUsed consumes the token. It returns ErrNotFound when the token was
+// already used, so only one of two concurrent refreshes can succeed.
+func (repo *RefreshTokenRepositoryDynamoDB) MarkUsed(tokenHash string, usedAt int64) error {
+	_, err := repo.client.UpdateItem(context.Background(), &dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.table),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: refreshTokenPK},
+			"SK": &types.AttributeValueMemberS{Value: refreshTokenSK(tokenHash)},
+		},
+		UpdateExpression:    aws.String("SET UsedAt = :usedAt"),
+		ConditionExpression: aws.String("attribute_exists(SK) AND UsedAt = :unused"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":usedAt": &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", usedAt)},
+			":unused": &types.AttributeValueMemberN{Value: "0"},
+		},
+	})
+	if err != nil {
+		var conditionFailed *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionFailed) {
+			return domain.ErrNotFound
+		}
+		log.Printf("Failed to mark refresh token used: %v", err)
+		return fmt.Errorf("failed to mark refresh token used: %w", err)
+	}
+	return nil
+}
+
+func (repo *RefreshTokenRepositoryDynamoDB) GetByUserID(userID string) ([]domain.RefreshToken, error) {
+	return repo.query(userID, "")
+}
+
+func (repo *RefreshTokenRepositoryDynamoDB) DeleteSession(userID, sessionID string) error {
+	tokens, err := repo.query(userID, sessionID)
+	if err != nil {
+		return err
+	}
+	return repo.delete(tokens)
+}
+
+func (repo *RefreshTokenRepositoryDynamoDB) DeleteByUserID(userID string) error {
+	tokens, err := repo.query(userID, "")
+	if err != nil {
+		return err
+	}
+	return repo.delete(tokens)
+}
+
+func (repo *RefreshTokenRepositoryDynamoDB) query(userID, sessionID string) ([]domain.RefreshToken, error) {
+	ctx := context.Background()
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(repo.table),
+		IndexName:              aws.String("LSI-3"),
+		KeyConditionExpression: aws.String("PK = :pk AND UserID = :userId"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":     &types.AttributeValueMemberS{Value: refreshTokenPK},
+			":userId": &types.AttributeValueMemberS{Value: userID},
+		},
+	}
+	if sessionID != "" {
+		input.FilterExpression = aws.String("SessionID = :sessionId")
+		input.ExpressionAttributeValues[":sessionId"] = &types.AttributeValueMemberS{Value: sessionID}
+	}
+
+	tokens := []domain.RefreshToken{}
+	paginator := dynamodb.NewQueryPaginator(repo.client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			log.Printf("Failed to get refresh tokens: %v", err)
+			return nil, fmt.Errorf("failed to get refresh tokens: %w", err)
+		}
+
+		var items []dto.RefreshTokenDynamoDBItem
+		if err := attributevalue.UnmarshalListOfMaps(page.Items, &items); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal refresh tokens: %w", err)
+		}
+		for _, item := range items {
+			tokens = append(tokens, toDomainRefreshToken(item))
+		}
+	}
+	return tokens, nil
+}
+
+func (repo *RefreshTokenRepositoryDynamoDB) delete(tokens []domain.RefreshToken) error {
+	for _, token := range tokens {
+		_, err := repo.client.DeleteItem(context.Background(), &dynamodb.DeleteItemInput{
+			TableName: aws.String(repo.table),
+			Key: map[string]types.AttributeValue{
+				"PK": &types.AttributeValueMemberS{Value: refreshTokenPK},
+				"SK": &types.AttributeValueMemberS{Value: refreshTokenSK(token.TokenHash)},
+			},
+		})
+		if err != nil {
+			log.Printf("Failed to delete refresh token: %v", err)
+			return fmt.Errorf("failed to delete refresh token: %w", err)
+		}
+	}
+	return nil
+}
+
+func toDomainRefreshToken(item dto.RefreshTokenDynamoDBItem) domain.RefreshToken {
+	return domain.RefreshToken{
+		ID:        item.ID,
+		UserID:    item.UserID,
+		SessionID: item.SessionID,
+		TokenHash: item.TokenHash,
+		ExpiresAt: item.ExpiresAt,
+		UsedAt:    item.UsedAt,
+		CreatedAt: item.CreatedAt,
+	}
+}
+
+type TokenRevocationStoreDynamoDB struct {
+	client *dynamodb.Client
+	table  string
+}
+
+func NewTokenRevocationStoreDynamoDB(client *dynamodb.Client, tableName string) ports.TokenRevocationStore {
+	return &TokenRevocationStoreDynamoDB{
+		client: client,
+		table:  tableName,
+	}
+}
+
+func revokedTokenSK(id string) string {
+	return fmt.Sprintf("REVOKED#%s", id)
+}
+
+func (repo *TokenRevocationStoreDynamoDB) Revoke(id string, expiresAt int64) error {
+	if id == "" {
+		return domain.ErrInvalidInput
+	}
+
+	expires := &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", expiresAt)}
+	_, err := repo.client.UpdateItem(context.Background(), &dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.table),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: revokedTokenPK},
+			"SK": &types.AttributeValueMemberS{Value: revokedTokenSK(id)},
+		},
+		UpdateExpression: aws.String("SET ID = :id, ExpiresAt = :expiresAt, ExpireAfter = :expiresAt"),
+		// Revoking twice keeps the later expiry.
+		ConditionExpression: aws.String("attribute_not_exists(SK) OR ExpiresAt < :expiresAt"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":id":        &types.AttributeValueMemberS{Value: id},
+			":expiresAt": expires,
+		},
+	})
+	if err != nil {
+		var conditionFailed *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionFailed) {
+			return nil
+		}
+		log.Printf("Failed to revoke token: %v", err)
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+	return nil
+}
+
+func (repo *TokenRevocationStoreDynamoDB) IsRevoked(ids ...string) (bool, error) {
+	if len(ids) == 0 {
+		return false, nil
+	}
+
+	keys := make([]map[string]types.AttributeValue, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: revokedTokenPK},
+			"SK": &types.AttributeValueMemberS{Value: revokedTokenSK(id)},
+		})
+	}
+
+	result, err := repo.client.BatchGetItem(context.Background(), &dynamodb.BatchGetItemInput{
+		RequestItems: map[string]types.KeysAndAttributes{
+			repo.table: {Keys: keys, ConsistentRead: aws.Bool(true)},
+		},
+	})
+	if err != nil {
+		log.Printf("Failed to check token revocation: %v", err)
+		return false, fmt.Errorf("failed to check token revocation: %w", err)
+	}
+	if len(result.UnprocessedKeys) > 0 {
+		return false, errors.New("failed to check token revocation: request was throttled")
+	}
+
+	var items []dto.RevokedTokenDynamoDBItem
+	if err := attributevalue.UnmarshalListOfMaps(result.Responses[repo.table], &items); err != nil {
+		return false, fmt.Errorf("failed to unmarshal revoked tokens: %w", err)
+	}
+	// TTL deletes lazily, so expired entries may still be returned.
+	now := time.Now().Unix()
+	for _, item := range items {
+		if item.ExpiresAt > now {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,176 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/ports"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+)
+
+// Room blocks carry RoomID so they can be looked up per room through LSI-5,
+// the same index the bookings use.
+const roomBlockPK = "ROOMBLOCK"
+
+type RoomBlockRepositoryDynamoDB struct {
+	client *dynamodb.Client
+	table  string
+}
+
+func NewRoomBlockRepositoryDynamoDB(client *dynamodb.Client, tableName string) ports.RoomBlockRepository {
+	return &RoomBlockRepositoryDynamoDB{
+		client: client,
+		table:  tableName,
+	}
+}
+
+func roomBlockKey(id string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"PK": &types.AttributeValueMemberS{Value: roomBlockPK},
+		"SK": &types.AttributeValueMemberS{Value: fmt.Sprintf("ROOMBLOCK#%s", id)},
+	}
+}
+
+func toDomainRoomBlock(item dto.RoomBlockDynamoDBItem) domain.RoomBlock {
+	return domain.RoomBlock{
+		ID:        item.ID,
+		RoomID:    item.RoomID,
+		StartTime: item.StartTime,
+		EndTime:   item.EndTime,
+		Reason:    item.Reason,
+		CreatedBy: item.CreatedBy,
+		CreatedAt: item.CreatedAt,
+	}
+}
+
+func (repo *RoomBlockRepositoryDynamoDB) Create(block *domain.RoomBlock) error {
+	if block == nil {
+		return domain.ErrInvalidInput
+	}
+
+	av, err := attributevalue.MarshalMap(dto.RoomBlockDynamoDBItem{
+		PK:        roomBlockPK,
+		SK:        fmt.Sprintf("ROOMBLOCK#%s", block.ID),
+		ID:        block.ID,
+		RoomID:    block.RoomID,
+		StartTime: block.StartTime,
+		EndTime:   block.EndTime,
+		Reason:    block.Reason,
+		CreatedBy: block.CreatedBy,
+		CreatedAt: block.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal room block: %w", err)
+	}
+
+	_, err = repo.client.PutItem(context.Background(), &dynamodb.PutItemInput{
+		TableName:           aws.String(repo.table),
+		Item:                av,
+		ConditionExpression: aws.String("attribute_not_exists(SK)"),
+	})
+	if err != nil {
+		var conditionFailed *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionFailed) {
+			return domain.ErrConflict
+		}
+		log.Printf("Failed to create room block: %v", err)
+		return fmt.Errorf("failed to create room block: %w", err)
+	}
+	return nil
+}
+
+func (repo *RoomBlockRepositoryDynamoDB) GetByID(id string) (*domain.RoomBlock, error) {
+	result, err := repo.client.GetItem(context.Background(), &dynamodb.GetItemInput{
+		TableName: aws.String(repo.table),
+		Key:       roomBlockKey(id),
+	})
+	if err != nil {
+		log.Printf("Failed to get room block: %v", err)
+		return nil, fmt.Errorf("failed to get room block: %w", err)
+	}
+	if result.Item == nil {
+		return nil, domain.ErrNotFound
+	}
+
+	var item dto.RoomBlockDynamoDBItem
+	if err := attributevalue.UnmarshalMap(result.Item, &item); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal room block: %w", err)
+	}
+	block := toDomainRoomBlock(item)
+	return &block, nil
+}
+
+func (repo *RoomBlockRepositoryDynamoDB) GetByRoomID(roomID string) ([]domain.RoomBlock, error) {
+	return repo.query(&dynamodb.QueryInput{
+		TableName:              aws.String(repo.table),
+		IndexName:              aws.String("LSI-5"),
+		KeyConditionExpression: aws.String("PK = :pk AND RoomID = :roomId"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":     &types.AttributeValueMemberS{Value: roomBlockPK},
+			":roomId": &types.AttributeValueMemberS{Value: roomID},
+		},
+	})
+}
+
+func (repo *RoomBlockRepositoryDynamoDB) GetByRoomAndTime(roomID string, start, end int64) ([]domain.RoomBlock, error) {
+	return repo.query(&dynamodb.QueryInput{
+		TableName:              aws.String(repo.table),
+		IndexName:              aws.String("LSI-5"),
+		KeyConditionExpression: aws.String("PK = :pk AND RoomID = :roomId"),
+		FilterExpression:       aws.String("EndTime > :start AND StartTime < :end"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":     &types.AttributeValueMemberS{Value: roomBlockPK},
+			":roomId": &types.AttributeValueMemberS{Value: roomID},
+			":start":  &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", start)},
+			":end":    &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", end)},
+		},
+	})
+}
+
+func (repo *RoomBlockRepositoryDynamoDB) query(input *dynamodb.QueryInput) ([]domain.RoomBlock, error) {
+	result, err := repo.client.Query(context.Background(), input)
+	if err != nil {
+		log.Printf("Failed to query room blocks: %v", err)
+		return nil, fmt.Errorf("failed to query room blocks: %w", err)
+	}
+
+	var items []dto.RoomBlockDynamoDBItem
+	if err := attributevalue.UnmarshalListOfMaps(result.Items, &items); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal room blocks: %w", err)
+	}
+
+	blocks := make([]domain.RoomBlock, 0, len(items))
+	for _, item := range items {
+		blocks = append(blocks, toDomainRoomBlock(item))
+	}
+	sort.Slice(blocks, func(i, j int) bool {
+		return blocks[i].StartTime < blocks[j].StartTime
+	})
+	return blocks, nil
+}
+
+func (repo *RoomBlockRepositoryDynamoDB) Delete(id string) error {
+	_, err := repo.client.DeleteItem(context.Background(), &dynamodb.DeleteItemInput{
+		TableName:           aws.String(repo.table),
+		Key:                 roomBlockKey(id),
+		ConditionExpression: aws.String("attribute_exists(SK)"),
+	})
+	if err != nil {
+		var conditionFailed *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionFailed) {
+			return domain.ErrNotFound
+		}
+		log.Printf("Failed to delete room block: %v", err)
+		return fmt.Errorf("failed to delete room block: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,312 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+)
+
+// Every booking write bumps a version counter on one lock item per room and
+// UTC week it touches. Two overlapping bookings always share at least one
+// week, so when both pass the overlap check against the same versions only
+// one of the two transactions can satisfy the version condition; the other is
+// cancelled, re-reads the locks and sees the winner in its overlap check.
+// Locking weeks rather than days keeps a long booking to a few lock items,
+// so one that lasts months still fits into a single transaction.
+
+const (
+	roomLockPK           = "ROOMLOCK"
+	secondsPerDay        = 86400
+	lockPeriod           = 7 * secondsPerDay
+	maxBookingWriteTries = 5
+	// maxTransactItems is the most items one TransactWriteItems call takes.
+	maxTransactItems = 100
+)
+
+type roomLock struct {
+	sk      string
+	version int64
+	exists  bool
+}
+
+func roomLockSK(roomID string, period int64) string {
+	return fmt.Sprintf("ROOMLOCK#%s#%d", roomID, period)
+}
+
+// lockPeriods lists the start of every lock period [start, end) touches.
+func lockPeriods(start, end int64) []int64 {
+	var periods []int64
+	for period := (start / lockPeriod) * lockPeriod; period < end; period += lockPeriod {
+		periods = append(periods, period)
+	}
+	if len(periods) == 0 {
+		periods = append(periods, (start/lockPeriod)*lockPeriod)
+	}
+	return periods
+}
+
+// roomLockKeys lists the lock items covering bookings, each once.
+func roomLockKeys(bookings []domain.Booking) []string {
+	var sks []string
+	seen := map[string]bool{}
+	for _, booking := range bookings {
+		for _, period := range lockPeriods(booking.StartTime, booking.EndTime) {
+			sk := roomLockSK(booking.RoomID, period)
+			if !seen[sk] {
+				seen[sk] = true
+				sks = append(sks, sk)
+			}
+		}
+	}
+	return sks
+}
+
+func (repo *BookingRepositoryDynamoDB) readRoomLocks(ctx context.Context, sks []string) ([]roomLock, error) {
+	locks := make([]roomLock, 0, len(sks))
+	for _, sk := range sks {
+		lock := roomLock{sk: sk}
+		result, err := repo.client.GetItem(ctx, &dynamodb.GetItemInput{
+			TableName: aws.String(repo.table),
+			Key: map[string]types.AttributeValue{
+				"PK": &types.AttributeValueMemberS{Value: roomLockPK},
+				"SK": &types.AttributeValueMemberS{Value: lock.sk},
+			},
+			ConsistentRead: aws.Bool(true),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read room lock: %w", err)
+		}
+		if version, ok := result.Item["Version"].(*types.AttributeValueMemberN); ok {
+			lock.exists = true
+			lock.version, err = strconv.ParseInt(version.Value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid room lock version: %w", err)
+			}
+		}
+		locks = append(locks, lock)
+	}
+	return locks, nil
+}
+
+func (repo *BookingRepositoryDynamoDB) lockWrites(locks []roomLock) []types.TransactWriteItem {
+	writes := make([]types.TransactWriteItem, 0, len(locks))
+	for _, lock := range locks {
+		update := &types.Update{
+			TableName: aws.String(repo.table),
+			Key: map[string]types.AttributeValue{
+				"PK": &types.AttributeValueMemberS{Value: roomLockPK},
+				"SK": &types.AttributeValueMemberS{Value: lock.sk},
+			},
+			UpdateExpression: aws.String("SET Version = :next"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":next": &types.AttributeValueMemberN{Value: strconv.FormatInt(lock.version+1, 10)},
+			},
+		}
+		if lock.exists {
+			update.ConditionExpression = aws.String("Version = :version")
+			update.ExpressionAttributeValues[":version"] = &types.AttributeValueMemberN{Value: strconv.FormatInt(lock.version, 10)}
+		} else {
+			update.ConditionExpression = aws.String("attribute_not_exists(PK)")
+		}
+		writes = append(writes, types.TransactWriteItem{Update: update})
+	}
+	return writes
+}
+
+// hasOverlap reports whether an active booking other than those in ignore
+// holds part of the room between start and end.
+func (repo *BookingRepositoryDynamoDB) hasOverlap(ctx context.Context, roomID string, start, end int64, ignore map[string]bool) (bool, error) {
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(repo.table),
+		IndexName:              aws.String("LSI-5"),
+		KeyConditionExpression: aws.String("PK = :pk AND RoomID = :roomId"),
+		FilterExpression:       aws.String("EndTime > :start AND StartTime < :end AND " + activeBookingFilter),
+		ExpressionAttributeNames: map[string]string{
+			"#status": "Status",
+		},
+		ExpressionAttributeValues: withInactiveStatuses(map[string]types.AttributeValue{
+			":pk":     &types.AttributeValueMemberS{Value: "BOOKING"},
+			":roomId": &types.AttributeValueMemberS{Value: roomID},
+			":start":  &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", start)},
+			":end":    &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", end)},
+		}),
+		ConsistentRead: aws.Bool(true),
+	}
+
+	paginator := dynamodb.NewQueryPaginator(repo.client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return false, fmt.Errorf("failed to check overlapping bookings: %w", err)
+		}
+		for _, item := range page.Items {
+			id, ok := item["ID"].(*types.AttributeValueMemberS)
+			if !ok || !ignore[id.Value] {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// overlapEachOther reports whether two of bookings want the same room at the
+// same time.
+func overlapEachOther(bookings []domain.Booking) bool {
+	sorted := append([]domain.Booking(nil), bookings...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].RoomID != sorted[j].RoomID {
+			return sorted[i].RoomID < sorted[j].RoomID
+		}
+		return sorted[i].StartTime < sorted[j].StartTime
+	})
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].RoomID == sorted[i-1].RoomID && sorted[i].StartTime < sorted[i-1].EndTime {
+			return true
+		}
+	}
+	return false
+}
+
+// transactionChunks splits bookings into consecutive runs whose room locks
+// and writes fit into one transaction and returns where each run ends. A
+// single booking too long to fit on its own yields ErrBookingTooLong.
+func transactionChunks(bookings []domain.Booking) ([]int, error) {
+	var ends []int
+	seen := map[string]bool{}
+	items := 0
+	for i := range bookings {
+		sks := roomLockKeys(bookings[i : i+1])
+		if len(sks)+1 > maxTransactItems {
+			return nil, domain.ErrBookingTooLong
+		}
+		added := 1
+		for _, sk := range sks {
+			if !seen[sk] {
+				added++
+			}
+		}
+		if items+added > maxTransactItems {
+			ends = append(ends, i)
+			seen = map[string]bool{}
+			items, added = 0, len(sks)+1
+		}
+		for _, sk := range sks {
+			seen[sk] = true
+		}
+		items += added
+	}
+	return append(ends, len(bookings)), nil
+}
+
+// writeWithRoomLocks applies write, which must be conditioned on the booking
+// item itself, together with the room locks covering booking's time range.
+// Lost races are retried; a conflicting booking yields ErrRoomUnavailable and
+// a failed condition on the booking item ErrBookingNotActive.
+func (repo *BookingRepositoryDynamoDB) writeWithRoomLocks(ctx context.Context, booking *domain.Booking, write types.TransactWriteItem) error {
+	_, err := repo.writeAllWithRoomLocks(ctx, []domain.Booking{*booking}, []types.TransactWriteItem{write})
+	return err
+}
+
+// writeAllWithRoomLocks is writeWithRoomLocks for several bookings, where
+// writes[i] writes bookings[i]. As many bookings as fit go into each
+// transaction, so a group is written in one, but a long series may take
+// several. It returns how many bookings were written, which is fewer than
+// all of them only when a later transaction failed and the caller has to
+// undo the earlier ones.
+func (repo *BookingRepositoryDynamoDB) writeAllWithRoomLocks(ctx context.Context, bookings []domain.Booking, writes []types.TransactWriteItem) (int, error) {
+	if overlapEachOther(bookings) {
+		return 0, domain.ErrRoomUnavailable
+	}
+	chunks, err := transactionChunks(bookings)
+	if err != nil {
+		return 0, err
+	}
+
+	// The bookings are checked against each other above, and those being
+	// moved must not be held up by the slots they are leaving.
+	ignore := make(map[string]bool, len(bookings))
+	for _, booking := range bookings {
+		ignore[booking.ID] = true
+	}
+	written := 0
+	for _, end := range chunks {
+		if err := repo.writeChunkWithRoomLocks(ctx, bookings[written:end], writes[written:end], ignore); err != nil {
+			return written, err
+		}
+		written = end
+	}
+	return written, nil
+}
+
+func (repo *BookingRepositoryDynamoDB) writeChunkWithRoomLocks(ctx context.Context, bookings []domain.Booking, writes []types.TransactWriteItem, ignore map[string]bool) error {
+	sks := roomLockKeys(bookings)
+	for attempt := 1; attempt <= maxBookingWriteTries; attempt++ {
+		locks, err := repo.readRoomLocks(ctx, sks)
+		if err != nil {
+			return err
+		}
+		for _, booking := range bookings {
+			overlapping, err := repo.hasOverlap(ctx, booking.RoomID, booking.StartTime, booking.EndTime, ignore)
+			if err != nil {
+				return err
+			}
+			if overlapping {
+				return domain.ErrRoomUnavailable
+			}
+		}
+
+		_, err = repo.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+			TransactItems: append(repo.lockWrites(locks), writes...),
+		})
+		if err == nil {
+			return nil
+		}
+		if !isTransactionConflict(err) {
+			return fmt.Errorf("failed to write booking: %w", err)
+		}
+		for i := range writes {
+			if cancellationCode(err, len(locks)+i) == "ConditionalCheckFailed" {
+				return domain.ErrBookingNotActive
+			}
+		}
+	}
+
+	roomIDs := make([]string, len(bookings))
+	for i, booking := range bookings {
+		roomIDs[i] = booking.RoomID
+	}
+	log.Printf("Gave up writing bookings for rooms %s after %d conflicting attempts", strings.Join(roomIDs, ", "), maxBookingWriteTries)
+	return domain.ErrRoomUnavailable
+}
+
+func cancellationCode(err error, index int) string {
+	var canceled *types.TransactionCanceledException
+	if !errors.As(err, &canceled) || index >= len(canceled.CancellationReasons) {
+		return ""
+	}
+	return aws.ToString(canceled.CancellationReasons[index].Code)
+}
+
+func isTransactionConflict(err error) bool {
+	var canceled *types.TransactionCanceledException
+	if !errors.As(err, &canceled) {
+		return false
+	}
+	for _, reason := range canceled.CancellationReasons {
+		code := aws.ToString(reason.Code)
+		if code == "ConditionalCheckFailed" || code == "TransactionConflict" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,327 @@
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+)
+
+// These tests need DynamoDB Local, e.g.
+//
+//	docker run -p 8000:8000 amazon/dynamodb-local
+//	DYNAMODB_LOCAL_ENDPOINT=http://localhost:8000 go test ./internal/adapters/repositories/dynamoDB/
+const parallelWriters = 50
+
+// newLocalTable creates a table shaped like the one in template.yaml on
+// DynamoDB Local and deletes it when the test ends.
+func newLocalTable(t *testing.T) (*dynamodb.Client, string) {
+	t.Helper()
+	endpoint := os.Getenv("DYNAMODB_LOCAL_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("DYNAMODB_LOCAL_ENDPOINT is not set")
+	}
+
+	client := dynamodb.New(dynamodb.Options{
+		Region:       "us-east-1",
+		BaseEndpoint: aws.String(endpoint),
+		Credentials: aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+			return aws.Credentials{AccessKeyID: "local", SecretAccessKey: "local"}, nil
+		}),
+	})
+
+	ctx := context.Background()
+	table := fmt.Sprintf("MeetingRoomTest%d", time.Now().UnixNano())
+	index := func(name, rangeKey string) types.LocalSecondaryIndex {
+		return types.LocalSecondaryIndex{
+			IndexName: aws.String(name),
+			KeySchema: []types.KeySchemaElement{
+				{AttributeName: aws.String("PK"), KeyType: types.KeyTypeHash},
+				{AttributeName: aws.String(rangeKey), KeyType: types.KeyTypeRange},
+			},
+			Projection: &types.Projection{ProjectionType: types.ProjectionTypeAll},
+		}
+	}
+	_, err := client.CreateTable(ctx, &dynamodb.CreateTableInput{
+		TableName: aws.String(table),
+		AttributeDefinitions: []types.AttributeDefinition{
+			{AttributeName: aws.String("PK"), AttributeType: types.ScalarAttributeTypeS},
+			{AttributeName: aws.String("SK"), AttributeType: types.ScalarAttributeTypeS},
+			{AttributeName: aws.String("LSI1"), AttributeType: types.ScalarAttributeTypeN},
+			{AttributeName: aws.String("LSI2"), AttributeType: types.ScalarAttributeTypeN},
+			{AttributeName: aws.String("UserID"), AttributeType: types.ScalarAttributeTypeS},
+			{AttributeName: aws.String("Date"), AttributeType: types.ScalarAttributeTypeN},
+			{AttributeName: aws.String("RoomID"), AttributeType: types.ScalarAttributeTypeS},
+		},
+		KeySchema: []types.KeySchemaElement{
+			{AttributeName: aws.String("PK"), KeyType: types.KeyTypeHash},
+			{AttributeName: aws.String("SK"), KeyType: types.KeyTypeRange},
+		},
+		LocalSecondaryIndexes: []types.LocalSecondaryIndex{
+			index("LSI-1", "LSI1"),
+			index("LSI-2", "LSI2"),
+			index("LSI-3", "UserID"),
+			index("LSI-4", "Date"),
+			index("LSI-5", "RoomID"),
+		},
+		BillingMode: types.BillingModePayPerRequest,
+	})
+	if err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	t.Cleanup(func() {
+		client.DeleteTable(context.Background(), &dynamodb.DeleteTableInput{TableName: aws.String(table)})
+	})
+	return client, table
+}
+
+// runParallel calls write from n goroutines at once and returns how many
+// succeeded, failing the test on any error other than ErrRoomUnavailable.
+func runParallel(t *testing.T, n int, write func(i int) error) int {
+	t.Helper()
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		succeeded int
+		failures  []error
+	)
+	start := make(chan struct{})
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			err := write(i)
+			mu.Lock()
+			defer mu.Unlock()
+			switch err {
+			case nil:
+				succeeded++
+			case domain.ErrRoomUnavailable:
+			default:
+				failures = append(failures, err)
+			}
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	for _, err := range failures {
+		t.Errorf("unexpected error: %v", err)
+	}
+	return succeeded
+}
+
+func TestBookingRepositoryCreateAllowsOneOfParallelOverlaps(t *testing.T) {
+	client, table := newLocalTable(t)
+	repo := NewBookingRepositoryDynamoDB(client, table)
+
+	// The windows straddle midnight UTC, so each write takes two day locks.
+	base := time.Now().Add(48*time.Hour).Truncate(24*time.Hour).Unix() - 1800
+	succeeded := runParallel(t, parallelWriters, func(i int) error {
+		start := base + int64(i%30)*60
+		return repo.Create(&domain.Booking{
+			ID:        fmt.Sprintf("booking-%d", i),
+			UserID:    "user-1",
+			RoomID:    "room-1",
+			StartTime: start,
+			EndTime:   start + 3600,
+			Status:    domain.BookingStatusConfirmed,
+		})
+	})
+	if succeeded != 1 {
+		t.Fatalf("%d of %d overlapping creates succeeded, want exactly 1", succeeded, parallelWriters)
+	}
+
+	bookings, err := repo.GetByRoomID("room-1")
+	if err != nil {
+		t.Fatalf("list bookings: %v", err)
+	}
+	if len(bookings) != 1 {
+		t.Fatalf("room holds %d bookings, want 1", len(bookings))
+	}
+}
+
+func TestBookingRepositoryUpdateAllowsOneOfParallelOverlaps(t *testing.T) {
+	client, table := newLocalTable(t)
+	repo := NewBookingRepositoryDynamoDB(client, table)
+
+	// Lay the bookings out back to back after the contested window, then move
+	// them all into it at once.
+	base := time.Now().Add(48 * time.Hour).Truncate(time.Hour).Unix()
+	bookings := make([]*domain.Booking, parallelWriters)
+	for i := range bookings {
+		start := base + int64(i+2)*3600
+		bookings[i] = &domain.Booking{
+			ID:        fmt.Sprintf("booking-%d", i),
+			UserID:    "user-1",
+			RoomID:    "room-1",
+			StartTime: start,
+			EndTime:   start + 3600,
+			Status:    domain.BookingStatusConfirmed,
+		}
+		if err := repo.Create(bookings[i]); err != nil {
+			t.Fatalf("create booking %d: %v", i, err)
+		}
+	}
+
+	succeeded := runParallel(t, parallelWriters, func(i int) error {
+		moved := *bookings[i]
+		moved.StartTime = base + int64(i%30)*60
+		moved.EndTime = moved.StartTime + 3600
+		return repo.Update(&moved)
+	})
+	if succeeded != 1 {
+		t.Fatalf("%d of %d overlapping updates succeeded, want exactly 1", succeeded, parallelWriters)
+	}
+
+	overlapping, err := repo.GetByRoomAndTime("room-1", base, base+2*3600)
+	if err != nil {
+		t.Fatalf("list bookings: %v", err)
+	}
+	var inWindow int
+	for _, b := range overlapping {
+		if b.StartTime < base+3600 {
+			inWindow++
+		}
+	}
+	if inWindow != 1 {
+		t.Fatalf("%d bookings were moved into the contested window, want 1", inWindow)
+	}
+}
+
+// dailyBookings lays out n one-hour bookings of room-1 on consecutive days,
+// so a batch of them needs several transactions.
+func dailyBookings(n int) []domain.Booking {
+	start := time.Now().Add(48 * time.Hour).Truncate(24 * time.Hour).Unix()
+	bookings := make([]domain.Booking, n)
+	for i := range bookings {
+		bookings[i] = domain.Booking{
+			ID:        fmt.Sprintf("booking-%d", i),
+			UserID:    "user-1",
+			RoomID:    "room-1",
+			StartTime: start + int64(i)*secondsPerDay,
+			EndTime:   start + int64(i)*secondsPerDay + 3600,
+			SeriesID:  "series-1",
+			Status:    domain.BookingStatusConfirmed,
+		}
+	}
+	return bookings
+}
+
+func TestBookingRepositoryBatchesSpanSeveralTransactions(t *testing.T) {
+	client, table := newLocalTable(t)
+	repo := NewBookingRepositoryDynamoDB(client, table)
+
+	bookings := dailyBookings(120)
+	if err := repo.CreateAll(bookings); err != nil {
+		t.Fatalf("CreateAll() failed: %v", err)
+	}
+	// Moving every booking a day later puts all but the last into a slot
+	// another booking of the batch is leaving.
+	for i := range bookings {
+		bookings[i].StartTime += secondsPerDay
+		bookings[i].EndTime += secondsPerDay
+	}
+	if err := repo.UpdateAll(bookings); err != nil {
+		t.Fatalf("UpdateAll() failed: %v", err)
+	}
+
+	stored, err := repo.GetBySeriesID("series-1")
+	if err != nil {
+		t.Fatalf("list bookings: %v", err)
+	}
+	if len(stored) != len(bookings) {
+		t.Fatalf("series holds %d bookings, want %d", len(stored), len(bookings))
+	}
+	want := make(map[string]int64, len(bookings))
+	for _, b := range bookings {
+		want[b.ID] = b.StartTime
+	}
+	for _, b := range stored {
+		if b.StartTime != want[b.ID] {
+			t.Errorf("booking %s starts at %d, want %d", b.ID, b.StartTime, want[b.ID])
+		}
+	}
+}
+
+func TestBookingRepositoryUndoesEarlierTransactionsOfAFailedBatch(t *testing.T) {
+	client, table := newLocalTable(t)
+	repo := NewBookingRepositoryDynamoDB(client, table)
+
+	bookings := dailyBookings(120)
+	last := bookings[len(bookings)-1]
+	blocker := domain.Booking{ID: "blocker", UserID: "user-2", RoomID: last.RoomID, StartTime: last.StartTime, EndTime: last.EndTime}
+	if err := repo.Create(&blocker); err != nil {
+		t.Fatalf("create blocker: %v", err)
+	}
+
+	if err := repo.CreateAll(bookings); err != domain.ErrRoomUnavailable {
+		t.Fatalf("CreateAll() = %v, want ErrRoomUnavailable", err)
+	}
+	stored, err := repo.GetBySeriesID("series-1")
+	if err != nil {
+		t.Fatalf("list bookings: %v", err)
+	}
+	if len(stored) != 0 {
+		t.Fatalf("%d bookings of the failed batch were left behind", len(stored))
+	}
+}
+
+func TestBookingRepositoryRejectsBookingBeyondOneTransaction(t *testing.T) {
+	client, table := newLocalTable(t)
+	repo := NewBookingRepositoryDynamoDB(client, table)
+
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Hour).Unix()
+	booking := &domain.Booking{
+		ID:        "booking-1",
+		UserID:    "user-1",
+		RoomID:    "room-1",
+		StartTime: start,
+		EndTime:   start + maxTransactItems*lockPeriod,
+		Status:    domain.BookingStatusConfirmed,
+	}
+	if err := repo.Create(booking); err != domain.ErrBookingTooLong {
+		t.Fatalf("Create() = %v, want ErrBookingTooLong", err)
+	}
+}
+
+func TestTransactionChunksFitsBookingsOfSeveralMonths(t *testing.T) {
+	start := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC).Unix()
+	bookings := []domain.Booking{
+		{ID: "booking-1", RoomID: "room-1", StartTime: start, EndTime: start + 180*secondsPerDay},
+		{ID: "booking-2", RoomID: "room-2", StartTime: start, EndTime: start + 180*secondsPerDay},
+	}
+	chunks, err := transactionChunks(bookings)
+	if err != nil {
+		t.Fatalf("transactionChunks() failed: %v", err)
+	}
+	if len(chunks) != 1 || chunks[0] != len(bookings) {
+		t.Fatalf("transactionChunks() = %v, want both bookings in one transaction", chunks)
+	}
+}
+
+func TestRoomLockKeysShareALockBetweenOverlappingBookings(t *testing.T) {
+	start := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+	long := domain.Booking{RoomID: "room-1", StartTime: start, EndTime: start + 60*secondsPerDay}
+	for day := int64(0); day < 60; day++ {
+		short := domain.Booking{RoomID: "room-1", StartTime: start + day*secondsPerDay + 23*3600, EndTime: start + (day+1)*secondsPerDay + 3600}
+		shared := false
+		for _, sk := range roomLockKeys([]domain.Booking{short}) {
+			for _, other := range roomLockKeys([]domain.Booking{long}) {
+				shared = shared || sk == other
+			}
+		}
+		if !shared {
+			t.Errorf("booking on day %d shares no lock with the booking it overlaps", day)
+		}
+	}
+}
@@ -17,6 +17,9 @@ import (
 	"github.com/google/uuid"
 )
 
+// activeRoomFilter hides soft-deleted rooms from listings and searches.
+const activeRoomFilter = "attribute_not_exists(DeletedAt)"
+
 type RoomRepositoryDynamoDB struct {
 	client *dynamodb.Client
 	table  string
@@ -41,7 +44,7 @@ func (repo *RoomRepositoryDynamoDB) Create(room *domain.Room) error {
 	room.UpdatedAt = now
 
 	if room.Status == "" {
-		room.Status = "available"
+		room.Status = domain.RoomStatusAvailable
 	}
 
 	item := dto.RoomDynamoDBItem{
@@ -60,6 +63,10 @@ func (repo *RoomRepositoryDynamoDB) Create(room *domain.Room) error {
 		Description: room.Description,
 		CreatedAt:   room.CreatedAt,
 		UpdatedAt:   room.UpdatedAt,
+
+		RequiresApproval: room.RequiresApproval,
+		Approvers:        room.Approvers,
+		BookingPolicy:    toRoomPolicyItem(room.BookingPolicy),
 	}
 
 	av, err := attributevalue.MarshalMap(item)
@@ -93,6 +100,7 @@ func (repo *RoomRepositoryDynamoDB) GetAll() ([]domain.Room, error) {
 	input := &dynamodb.QueryInput{
 		TableName:              aws.String(repo.table),
 		KeyConditionExpression: aws.String("PK = :pk"),
+		FilterExpression:       aws.String(activeRoomFilter),
 		ExpressionAttributeValues: map[string]types.AttributeValue{
 			":pk": &types.AttributeValueMemberS{Value: "ROOM"},
 		},
@@ -127,8 +135,13 @@ func (repo *RoomRepositoryDynamoDB) GetAll() ([]domain.Room, error) {
 			Status:      roomItem.Status,
 			Location:    roomItem.Location,
 			Description: roomItem.Description,
+			DeletedAt:   roomItem.DeletedAt,
 			CreatedAt:   roomItem.CreatedAt,
 			UpdatedAt:   roomItem.UpdatedAt,
+
+			RequiresApproval: roomItem.RequiresApproval,
+			Approvers:        roomItem.Approvers,
+			BookingPolicy:    fromRoomPolicyItem(roomItem.BookingPolicy),
 		}
 		rooms = append(rooms, room)
 	}
@@ -176,27 +189,38 @@ func (repo *RoomRepositoryDynamoDB) GetByID(id string) (*domain.Room, error) {
 		Status:      roomItem.Status,
 		Location:    roomItem.Location,
 		Description: roomItem.Description,
+		DeletedAt:   roomItem.DeletedAt,
 		CreatedAt:   roomItem.CreatedAt,
 		UpdatedAt:   roomItem.UpdatedAt,
+
+		RequiresApproval: roomItem.RequiresApproval,
+		Approvers:        roomItem.Approvers,
+		BookingPolicy:    fromRoomPolicyItem(roomItem.BookingPolicy),
 	}
 
 	log.Printf("Retrieved room with ID: %s", id)
 	return room, nil
 }
 
-func (repo *RoomRepositoryDynamoDB) DeleteByID(id string) error {
+// SoftDelete stamps DeletedAt on the room item instead of removing it, so
+// bookings that reference the room keep resolving.
+func (repo *RoomRepositoryDynamoDB) SoftDelete(id string, deletedAt int64) error {
 	ctx := context.Background()
 
-	input := &dynamodb.DeleteItemInput{
+	input := &dynamodb.UpdateItemInput{
 		TableName: aws.String(repo.table),
 		Key: map[string]types.AttributeValue{
 			"PK": &types.AttributeValueMemberS{Value: "ROOM"},
 			"SK": &types.AttributeValueMemberS{Value: fmt.Sprintf("ROOM#%s", id)},
 		},
-		ConditionExpression: aws.String("attribute_exists(PK) AND attribute_exists(SK)"),
+		UpdateExpression: aws.String("SET DeletedAt = :deletedAt, UpdatedAt = :deletedAt"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":deletedAt": &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", deletedAt)},
+		},
+		ConditionExpression: aws.String("attribute_exists(PK) AND attribute_exists(SK) AND attribute_not_exists(DeletedAt)"),
 	}
 
-	_, err := repo.client.DeleteItem(ctx, input)
+	_, err := repo.client.UpdateItem(ctx, input)
 	if err != nil {
 		log.Printf("Failed to delete room with ID %s: %v", id, err)
 		if strings.Contains(err.Error(), "ConditionalCheckFailedException") {
@@ -205,7 +229,81 @@ func (repo *RoomRepositoryDynamoDB) DeleteByID(id string) error {
 		return fmt.Errorf("failed to delete room: %w", err)
 	}
 
-	log.Printf("Deleted room with ID: %s", id)
+	log.Printf("Soft-deleted room with ID: %s", id)
+	return nil
+}
+
+func (repo *RoomRepositoryDynamoDB) Update(room *domain.Room) error {
+	ctx := context.Background()
+
+	if room == nil || room.ID == "" {
+		return domain.ErrInvalidInput
+	}
+	room.UpdatedAt = time.Now().Unix()
+
+	amenities, err := attributevalue.Marshal(room.Amenities)
+	if err != nil {
+		return fmt.Errorf("failed to marshal amenities: %w", err)
+	}
+	approvers := room.Approvers
+	if approvers == nil {
+		approvers = []string{}
+	}
+	approverList, err := attributevalue.Marshal(approvers)
+	if err != nil {
+		return fmt.Errorf("failed to marshal approvers: %w", err)
+	}
+	// A room without overrides is stored with an empty map rather than
+	// dropping the attribute, which SET cannot do.
+	policy, err := attributevalue.Marshal(dto.RoomPolicyDynamoDBItem(room.BookingPolicy))
+	if err != nil {
+		return fmt.Errorf("failed to marshal booking policy: %w", err)
+	}
+
+	// LSI1/LSI2 carry floor and capacity for the index queries, so they are
+	// rewritten alongside the plain attributes.
+	input := &dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.table),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: "ROOM"},
+			"SK": &types.AttributeValueMemberS{Value: fmt.Sprintf("ROOM#%s", room.ID)},
+		},
+		UpdateExpression: aws.String("SET #name = :name, RoomNumber = :roomNumber, Capacity = :capacity, Floor = :floor, " +
+			"LSI1 = :floor, LSI2 = :capacity, Amenities = :amenities, #status = :status, #location = :location, " +
+			"Description = :description, RequiresApproval = :requiresApproval, Approvers = :approvers, BookingPolicy = :bookingPolicy, UpdatedAt = :updatedAt"),
+		ExpressionAttributeNames: map[string]string{
+			"#name":     "Name",
+			"#status":   "Status",
+			"#location": "Location",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":name":        &types.AttributeValueMemberS{Value: room.Name},
+			":roomNumber":  &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", room.RoomNumber)},
+			":capacity":    &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", room.Capacity)},
+			":floor":       &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", room.Floor)},
+			":amenities":   amenities,
+			":status":      &types.AttributeValueMemberS{Value: room.Status},
+			":location":    &types.AttributeValueMemberS{Value: room.Location},
+			":description": &types.AttributeValueMemberS{Value: room.Description},
+			":updatedAt":   &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", room.UpdatedAt)},
+
+			":requiresApproval": &types.AttributeValueMemberBOOL{Value: room.RequiresApproval},
+			":approvers":        approverList,
+			":bookingPolicy":    policy,
+		},
+		ConditionExpression: aws.String("attribute_exists(PK) AND attribute_exists(SK)"),
+	}
+
+	_, err = repo.client.UpdateItem(ctx, input)
+	if err != nil {
+		log.Printf("Failed to update room: %v", err)
+		if strings.Contains(err.Error(), "ConditionalCheckFailedException") {
+			return domain.ErrNotFound
+		}
+		return fmt.Errorf("failed to update room: %w", err)
+	}
+
+	log.Printf("Updated room with ID: %s", room.ID)
 	return nil
 }
 
@@ -242,105 +340,127 @@ func (repo *RoomRepositoryDynamoDB) UpdateAvailability(id string, status string)
 	return nil
 }
 
-func (repo *RoomRepositoryDynamoDB) SearchWithFilters(minCapacity, maxCapacity int, floor *int) ([]domain.Room, error) {
+func (repo *RoomRepositoryDynamoDB) SearchWithFilters(filter domain.RoomSearchFilter) ([]domain.Room, error) {
 	ctx := context.Background()
 
-	if floor != nil {
-		input := &dynamodb.QueryInput{
-			TableName:              aws.String(repo.table),
-			IndexName:              aws.String("LSI-1"),
-			KeyConditionExpression: aws.String("PK = :pk AND LSI1 = :floor"),
-			ExpressionAttributeValues: map[string]types.AttributeValue{
-				":pk":    &types.AttributeValueMemberS{Value: "ROOM"},
-				":floor": &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", *floor)},
-			},
-		}
+	exprAttrValues := map[string]types.AttributeValue{
+		":pk": &types.AttributeValueMemberS{Value: "ROOM"},
+	}
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(repo.table),
+		ExpressionAttributeValues: exprAttrValues,
+	}
+	filterExprs := []string{activeRoomFilter}
 
-		if minCapacity > 0 || maxCapacity > 0 {
-			filterExprs := []string{}
-			if minCapacity > 0 {
-				filterExprs = append(filterExprs, "LSI2 >= :minCap")
-				input.ExpressionAttributeValues[":minCap"] = &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", minCapacity)}
-			}
-			if maxCapacity > 0 {
-				filterExprs = append(filterExprs, "LSI2 <= :maxCap")
-				input.ExpressionAttributeValues[":maxCap"] = &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", maxCapacity)}
-			}
-			if len(filterExprs) > 0 {
-				input.FilterExpression = aws.String(strings.Join(filterExprs, " AND "))
-			}
-		}
+	if filter.Floor != nil {
+		input.IndexName = aws.String("LSI-1")
+		input.KeyConditionExpression = aws.String("PK = :pk AND LSI1 = :floor")
+		exprAttrValues[":floor"] = &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", *filter.Floor)}
 
-		result, err := repo.client.Query(ctx, input)
-		if err != nil {
-			log.Printf("Failed to search rooms: %v", err)
-			return nil, fmt.Errorf("failed to search rooms: %w", err)
-		}
-
-		if len(result.Items) == 0 {
-			return []domain.Room{}, nil
+		if filter.MinCapacity > 0 {
+			filterExprs = append(filterExprs, "LSI2 >= :minCap")
+			exprAttrValues[":minCap"] = &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", filter.MinCapacity)}
 		}
-
-		return repo.parseRoomItems(result.Items)
-	}
-
-	if minCapacity > 0 || maxCapacity > 0 {
-		var keyConditionExpr string
-		exprAttrValues := map[string]types.AttributeValue{
-			":pk": &types.AttributeValueMemberS{Value: "ROOM"},
+		if filter.MaxCapacity > 0 {
+			filterExprs = append(filterExprs, "LSI2 <= :maxCap")
+			exprAttrValues[":maxCap"] = &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", filter.MaxCapacity)}
 		}
-
-		if minCapacity > 0 && maxCapacity > 0 {
-			keyConditionExpr = "PK = :pk AND LSI2 BETWEEN :minCap AND :maxCap"
-			exprAttrValues[":minCap"] = &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", minCapacity)}
-			exprAttrValues[":maxCap"] = &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", maxCapacity)}
-		} else if minCapacity > 0 {
-			keyConditionExpr = "PK = :pk AND LSI2 >= :minCap"
-			exprAttrValues[":minCap"] = &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", minCapacity)}
+	} else if filter.MinCapacity > 0 || filter.MaxCapacity > 0 {
+		input.IndexName = aws.String("LSI-2")
+
+		if filter.MinCapacity > 0 && filter.MaxCapacity > 0 {
+			input.KeyConditionExpression = aws.String("PK = :pk AND LSI2 BETWEEN :minCap AND :maxCap")
+			exprAttrValues[":minCap"] = &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", filter.MinCapacity)}
+			exprAttrValues[":maxCap"] = &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", filter.MaxCapacity)}
+		} else if filter.MinCapacity > 0 {
+			input.KeyConditionExpression = aws.String("PK = :pk AND LSI2 >= :minCap")
+			exprAttrValues[":minCap"] = &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", filter.MinCapacity)}
 		} else {
-			keyConditionExpr = "PK = :pk AND LSI2 <= :maxCap"
-			exprAttrValues[":maxCap"] = &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", maxCapacity)}
+			input.KeyConditionExpression = aws.String("PK = :pk AND LSI2 <= :maxCap")
+			exprAttrValues[":maxCap"] = &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", filter.MaxCapacity)}
 		}
+	} else {
+		input.KeyConditionExpression = aws.String("PK = :pk")
+	}
 
-		input := &dynamodb.QueryInput{
-			TableName:                 aws.String(repo.table),
-			IndexName:                 aws.String("LSI-2"),
-			KeyConditionExpression:    aws.String(keyConditionExpr),
-			ExpressionAttributeValues: exprAttrValues,
-		}
+	for i, amenity := range filter.Amenities {
+		placeholder := fmt.Sprintf(":amenity%d", i)
+		filterExprs = append(filterExprs, fmt.Sprintf("contains(Amenities, %s)", placeholder))
+		exprAttrValues[placeholder] = &types.AttributeValueMemberS{Value: amenity}
+	}
+	if filter.Status != "" {
+		filterExprs = append(filterExprs, "#status = :status")
+		input.ExpressionAttributeNames = map[string]string{"#status": "Status"}
+		exprAttrValues[":status"] = &types.AttributeValueMemberS{Value: filter.Status}
+	}
+	input.FilterExpression = aws.String(strings.Join(filterExprs, " AND "))
 
-		result, err := repo.client.Query(ctx, input)
+	// Filters apply per page, so a page can come back empty with more after it.
+	var items []map[string]types.AttributeValue
+	paginator := dynamodb.NewQueryPaginator(repo.client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
 		if err != nil {
 			log.Printf("Failed to search rooms: %v", err)
 			return nil, fmt.Errorf("failed to search rooms: %w", err)
 		}
+		items = append(items, page.Items...)
+	}
 
-		if len(result.Items) == 0 {
-			return []domain.Room{}, nil
-		}
+	if len(items) == 0 {
+		return []domain.Room{}, nil
+	}
 
-		return repo.parseRoomItems(result.Items)
+	rooms, err := repo.parseRoomItems(items)
+	if err != nil {
+		return nil, err
+	}
+	if filter.StartTime == nil || filter.EndTime == nil || filter.Available == nil {
+		return rooms, nil
 	}
 
+	matching := []domain.Room{}
+	for _, room := range rooms {
+		busy, err := repo.hasBookingBetween(ctx, room.ID, *filter.StartTime, *filter.EndTime)
+		if err != nil {
+			return nil, err
+		}
+		if busy != *filter.Available {
+			matching = append(matching, room)
+		}
+	}
+	return matching, nil
+}
+
+func (repo *RoomRepositoryDynamoDB) hasBookingBetween(ctx context.Context, roomID string, start, end int64) (bool, error) {
 	input := &dynamodb.QueryInput{
 		TableName:              aws.String(repo.table),
-		KeyConditionExpression: aws.String("PK = :pk"),
-		ExpressionAttributeValues: map[string]types.AttributeValue{
-			":pk": &types.AttributeValueMemberS{Value: "ROOM"},
+		IndexName:              aws.String("LSI-5"),
+		KeyConditionExpression: aws.String("PK = :pk AND RoomID = :roomId"),
+		FilterExpression:       aws.String("EndTime > :start AND StartTime < :end AND " + activeBookingFilter),
+		ExpressionAttributeNames: map[string]string{
+			"#status": "Status",
 		},
+		ExpressionAttributeValues: withInactiveStatuses(map[string]types.AttributeValue{
+			":pk":     &types.AttributeValueMemberS{Value: "BOOKING"},
+			":roomId": &types.AttributeValueMemberS{Value: roomID},
+			":start":  &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", start)},
+			":end":    &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", end)},
+		}),
 	}
 
-	result, err := repo.client.Query(ctx, input)
-	if err != nil {
-		log.Printf("Failed to search rooms: %v", err)
-		return nil, fmt.Errorf("failed to search rooms: %w", err)
-	}
-
-	if len(result.Items) == 0 {
-		return []domain.Room{}, nil
+	paginator := dynamodb.NewQueryPaginator(repo.client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			log.Printf("Failed to check bookings for room %s: %v", roomID, err)
+			return false, fmt.Errorf("failed to check room bookings: %w", err)
+		}
+		if len(page.Items) > 0 {
+			return true, nil
+		}
 	}
-
-	return repo.parseRoomItems(result.Items)
+	return false, nil
 }
 
 func (repo *RoomRepositoryDynamoDB) parseRoomItems(items []map[string]types.AttributeValue) ([]domain.Room, error) {
@@ -363,8 +483,13 @@ func (repo *RoomRepositoryDynamoDB) parseRoomItems(items []map[string]types.Attr
 			Status:      roomItem.Status,
 			Location:    roomItem.Location,
 			Description: roomItem.Description,
+			DeletedAt:   roomItem.DeletedAt,
 			CreatedAt:   roomItem.CreatedAt,
 			UpdatedAt:   roomItem.UpdatedAt,
+
+			RequiresApproval: roomItem.RequiresApproval,
+			Approvers:        roomItem.Approvers,
+			BookingPolicy:    fromRoomPolicyItem(roomItem.BookingPolicy),
 		}
 		rooms = append(rooms, room)
 	}
@@ -372,3 +497,18 @@ func (repo *RoomRepositoryDynamoDB) parseRoomItems(items []map[string]types.Attr
 	log.Printf("Search returned %d rooms", len(rooms))
 	return rooms, nil
 }
+
+func toRoomPolicyItem(policy domain.RoomPolicy) *dto.RoomPolicyDynamoDBItem {
+	if policy.IsZero() {
+		return nil
+	}
+	item := dto.RoomPolicyDynamoDBItem(policy)
+	return &item
+}
+
+func fromRoomPolicyItem(item *dto.RoomPolicyDynamoDBItem) domain.RoomPolicy {
+	if item == nil {
+		return domain.RoomPolicy{}
+	}
+	return domain.RoomPolicy(*item)
+}
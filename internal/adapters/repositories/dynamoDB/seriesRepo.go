@@ -0,0 +1,139 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/ports"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+)
+
+type BookingSeriesRepositoryDynamoDB struct {
+	client *dynamodb.Client
+	table  string
+}
+
+func NewBookingSeriesRepositoryDynamoDB(client *dynamodb.Client, tableName string) ports.BookingSeriesRepository {
+	return &BookingSeriesRepositoryDynamoDB{
+		client: client,
+		table:  tableName,
+	}
+}
+
+func toSeriesItem(series *domain.BookingSeries) dto.BookingSeriesDynamoDBItem {
+	return dto.BookingSeriesDynamoDBItem{
+		PK:        "SERIES",
+		SK:        fmt.Sprintf("SERIES#%s", series.ID),
+		ID:        series.ID,
+		UserID:    series.UserID,
+		RoomID:    series.RoomID,
+		Purpose:   series.Purpose,
+		RRule:     series.RRule,
+		Timezone:  series.Timezone,
+		StartTime: series.StartTime,
+		EndTime:   series.EndTime,
+		ExDates:   series.ExDates,
+		Status:    series.Status,
+		CreatedAt: series.CreatedAt,
+		UpdatedAt: series.UpdatedAt,
+	}
+}
+
+func (repo *BookingSeriesRepositoryDynamoDB) put(series *domain.BookingSeries, condition string) error {
+	av, err := attributevalue.MarshalMap(toSeriesItem(series))
+	if err != nil {
+		return fmt.Errorf("failed to marshal booking series: %w", err)
+	}
+
+	_, err = repo.client.PutItem(context.Background(), &dynamodb.PutItemInput{
+		TableName:           aws.String(repo.table),
+		Item:                av,
+		ConditionExpression: aws.String(condition),
+	})
+	if err != nil {
+		var conditionFailed *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionFailed) {
+			if condition == "attribute_exists(SK)" {
+				return domain.ErrNotFound
+			}
+			return domain.ErrConflict
+		}
+		log.Printf("Failed to save booking series: %v", err)
+		return fmt.Errorf("failed to save booking series: %w", err)
+	}
+	return nil
+}
+
+func (repo *BookingSeriesRepositoryDynamoDB) Create(series *domain.BookingSeries) error {
+	if series == nil {
+		return domain.ErrInvalidInput
+	}
+	return repo.put(series, "attribute_not_exists(SK)")
+}
+
+func (repo *BookingSeriesRepositoryDynamoDB) Update(series *domain.BookingSeries) error {
+	if series == nil {
+		return domain.ErrInvalidInput
+	}
+	return repo.put(series, "attribute_exists(SK)")
+}
+
+func (repo *BookingSeriesRepositoryDynamoDB) Delete(id string) error {
+	_, err := repo.client.DeleteItem(context.Background(), &dynamodb.DeleteItemInput{
+		TableName: aws.String(repo.table),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: "SERIES"},
+			"SK": &types.AttributeValueMemberS{Value: fmt.Sprintf("SERIES#%s", id)},
+		},
+	})
+	if err != nil {
+		log.Printf("Failed to delete booking series: %v", err)
+		return fmt.Errorf("failed to delete booking series: %w", err)
+	}
+	return nil
+}
+
+func (repo *BookingSeriesRepositoryDynamoDB) GetByID(id string) (*domain.BookingSeries, error) {
+	result, err := repo.client.GetItem(context.Background(), &dynamodb.GetItemInput{
+		TableName: aws.String(repo.table),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: "SERIES"},
+			"SK": &types.AttributeValueMemberS{Value: fmt.Sprintf("SERIES#%s", id)},
+		},
+	})
+	if err != nil {
+		log.Printf("Failed to get booking series: %v", err)
+		return nil, fmt.Errorf("failed to get booking series: %w", err)
+	}
+	if result.Item == nil {
+		return nil, domain.ErrNotFound
+	}
+
+	var item dto.BookingSeriesDynamoDBItem
+	if err := attributevalue.UnmarshalMap(result.Item, &item); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal booking series: %w", err)
+	}
+
+	return &domain.BookingSeries{
+		ID:        item.ID,
+		UserID:    item.UserID,
+		RoomID:    item.RoomID,
+		Purpose:   item.Purpose,
+		RRule:     item.RRule,
+		Timezone:  item.Timezone,
+		StartTime: item.StartTime,
+		EndTime:   item.EndTime,
+		ExDates:   item.ExDates,
+		Status:    item.Status,
+		CreatedAt: item.CreatedAt,
+		UpdatedAt: item.UpdatedAt,
+	}, nil
+}
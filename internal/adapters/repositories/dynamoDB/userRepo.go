@@ -2,6 +2,7 @@ package dynamodb
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
@@ -37,6 +38,14 @@ func toDomainUser(item map[string]types.AttributeValue) (domain.User, error) {
 	if role, ok := item["Role"].(*types.AttributeValueMemberS); ok {
 		user.Role = role.Value
 	}
+	if status, ok := item["Status"].(*types.AttributeValueMemberS); ok {
+		user.Status = status.Value
+	}
+	if disabledAt, ok := item["DisabledAt"].(*types.AttributeValueMemberN); ok {
+		if timestamp, err := strconv.ParseInt(disabledAt.Value, 10, 64); err == nil {
+			user.DisabledAt = timestamp
+		}
+	}
 	if createdAt, ok := item["CreatedAt"].(*types.AttributeValueMemberN); ok {
 		if timestamp, err := strconv.ParseInt(createdAt.Value, 10, 64); err == nil {
 			user.CreatedAt = timestamp
@@ -162,6 +171,7 @@ func (repo *UserRepositoryDynamoDB) Create(user *domain.User) error {
 		"Email":     &types.AttributeValueMemberS{Value: user.Email},
 		"Password":  &types.AttributeValueMemberS{Value: user.Password},
 		"Role":      &types.AttributeValueMemberS{Value: user.Role},
+		"Status":    &types.AttributeValueMemberS{Value: user.Status},
 		"CreatedAt": &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", user.CreatedAt)},
 		"UpdatedAt": &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", user.UpdatedAt)},
 	}
@@ -226,12 +236,15 @@ func (repo *UserRepositoryDynamoDB) GetAll() ([]domain.User, error) {
 	return users, nil
 }
 
-func (repo *UserRepositoryDynamoDB) DeleteByID(userID string) error {
-	if userID == "" {
+// Update writes the user's profile. When the email changes the lookup item
+// keyed by email is moved in the same transaction, failing with ErrConflict
+// if another user already owns the new address.
+func (repo *UserRepositoryDynamoDB) Update(user *domain.User) error {
+	if user == nil || user.ID == "" {
 		return domain.ErrInvalidInput
 	}
 
-	user, err := repo.GetByID(userID)
+	current, err := repo.GetByID(user.ID)
 	if err != nil {
 		return err
 	}
@@ -239,33 +252,139 @@ func (repo *UserRepositoryDynamoDB) DeleteByID(userID string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	transactItems := []types.TransactWriteItem{
-		{
-			Delete: &types.Delete{
-				TableName: aws.String(repo.table),
-				Key: map[string]types.AttributeValue{
-					"PK": &types.AttributeValueMemberS{Value: "USER"},
-					"SK": &types.AttributeValueMemberS{Value: user.Email},
+	userUpdate := &types.Update{
+		TableName: aws.String(repo.table),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: "USER"},
+			"SK": &types.AttributeValueMemberS{Value: "USER#" + user.ID},
+		},
+		UpdateExpression:    aws.String("SET #name = :name, Email = :email, #role = :role, UpdatedAt = :updatedAt"),
+		ConditionExpression: aws.String("attribute_exists(SK) AND Email = :currentEmail"),
+		ExpressionAttributeNames: map[string]string{
+			"#name": "Name",
+			"#role": "Role",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":name":         &types.AttributeValueMemberS{Value: user.Name},
+			":email":        &types.AttributeValueMemberS{Value: user.Email},
+			":role":         &types.AttributeValueMemberS{Value: user.Role},
+			":updatedAt":    &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", user.UpdatedAt)},
+			":currentEmail": &types.AttributeValueMemberS{Value: current.Email},
+		},
+	}
+
+	transactItems := []types.TransactWriteItem{{Update: userUpdate}}
+	if user.Email != current.Email {
+		transactItems = append(transactItems,
+			types.TransactWriteItem{
+				Delete: &types.Delete{
+					TableName: aws.String(repo.table),
+					Key: map[string]types.AttributeValue{
+						"PK": &types.AttributeValueMemberS{Value: "USER"},
+						"SK": &types.AttributeValueMemberS{Value: current.Email},
+					},
 				},
 			},
-		},
-		{
-			Delete: &types.Delete{
-				TableName: aws.String(repo.table),
-				Key: map[string]types.AttributeValue{
-					"PK": &types.AttributeValueMemberS{Value: "USER"},
-					"SK": &types.AttributeValueMemberS{Value: "USER#" + userID},
+			types.TransactWriteItem{
+				Put: &types.Put{
+					TableName: aws.String(repo.table),
+					Item: map[string]types.AttributeValue{
+						"PK": &types.AttributeValueMemberS{Value: "USER"},
+						"SK": &types.AttributeValueMemberS{Value: user.Email},
+						"ID": &types.AttributeValueMemberS{Value: user.ID},
+					},
+					ConditionExpression: aws.String("attribute_not_exists(SK)"),
 				},
 			},
-		},
+		)
 	}
 
 	_, err = repo.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
 		TransactItems: transactItems,
 	})
+	if err != nil {
+		var cancelled *types.TransactionCanceledException
+		if errors.As(err, &cancelled) {
+			// Either the email changed concurrently or the new address
+			// already belongs to someone else.
+			for _, reason := range cancelled.CancellationReasons {
+				if aws.ToString(reason.Code) == "ConditionalCheckFailed" {
+					return domain.ErrConflict
+				}
+			}
+		}
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *UserRepositoryDynamoDB) UpdatePassword(userID, passwordHash string, updatedAt int64) error {
+	if userID == "" {
+		return domain.ErrInvalidInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	input := &dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.table),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: "USER"},
+			"SK": &types.AttributeValueMemberS{Value: "USER#" + userID},
+		},
+		UpdateExpression:    aws.String("SET Password = :password, UpdatedAt = :updatedAt"),
+		ConditionExpression: aws.String("attribute_exists(SK)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":password":  &types.AttributeValueMemberS{Value: passwordHash},
+			":updatedAt": &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", updatedAt)},
+		},
+	}
+
+	_, err := repo.client.UpdateItem(ctx, input)
+	if err != nil {
+		var conditionFailed *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionFailed) {
+			return domain.ErrNotFound
+		}
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	return nil
+}
 
+func (repo *UserRepositoryDynamoDB) Disable(userID string, disabledAt int64) error {
+	if userID == "" {
+		return domain.ErrInvalidInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	input := &dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.table),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: "USER"},
+			"SK": &types.AttributeValueMemberS{Value: "USER#" + userID},
+		},
+		UpdateExpression:    aws.String("SET #status = :disabled, DisabledAt = :disabledAt, UpdatedAt = :disabledAt"),
+		ConditionExpression: aws.String("attribute_exists(SK) AND (attribute_not_exists(#status) OR #status <> :disabled)"),
+		ExpressionAttributeNames: map[string]string{
+			"#status": "Status",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":disabled":   &types.AttributeValueMemberS{Value: domain.UserStatusDisabled},
+			":disabledAt": &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", disabledAt)},
+		},
+	}
+
+	_, err := repo.client.UpdateItem(ctx, input)
 	if err != nil {
-		return fmt.Errorf("failed to delete user: %w", err)
+		var conditionFailed *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionFailed) {
+			return domain.ErrNotFound
+		}
+		return fmt.Errorf("failed to disable user: %w", err)
 	}
 
 	return nil
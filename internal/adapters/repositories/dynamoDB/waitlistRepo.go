@@ -0,0 +1,243 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/ports"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+)
+
+// Waitlist entries carry UserID and RoomID so they can be listed per user
+// through LSI-3 and per room through LSI-5.
+const waitlistPK = "WAITLIST"
+
+type WaitlistRepositoryDynamoDB struct {
+	client *dynamodb.Client
+	table  string
+}
+
+func NewWaitlistRepositoryDynamoDB(client *dynamodb.Client, tableName string) ports.WaitlistRepository {
+	return &WaitlistRepositoryDynamoDB{
+		client: client,
+		table:  tableName,
+	}
+}
+
+func waitlistKey(id string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"PK": &types.AttributeValueMemberS{Value: waitlistPK},
+		"SK": &types.AttributeValueMemberS{Value: fmt.Sprintf("WAITLIST#%s", id)},
+	}
+}
+
+func toDomainWaitlistEntry(item dto.WaitlistEntryDynamoDBItem) domain.WaitlistEntry {
+	return domain.WaitlistEntry{
+		ID:             item.ID,
+		UserID:         item.UserID,
+		RoomID:         item.RoomID,
+		StartTime:      item.StartTime,
+		EndTime:        item.EndTime,
+		Purpose:        item.Purpose,
+		AutoBook:       item.AutoBook,
+		Status:         item.Status,
+		BookingID:      item.BookingID,
+		OfferExpiresAt: item.OfferExpiresAt,
+		CreatedAt:      item.CreatedAt,
+		UpdatedAt:      item.UpdatedAt,
+	}
+}
+
+func (repo *WaitlistRepositoryDynamoDB) Create(entry *domain.WaitlistEntry) error {
+	if entry == nil {
+		return domain.ErrInvalidInput
+	}
+
+	av, err := attributevalue.MarshalMap(dto.WaitlistEntryDynamoDBItem{
+		PK:             waitlistPK,
+		SK:             fmt.Sprintf("WAITLIST#%s", entry.ID),
+		ID:             entry.ID,
+		UserID:         entry.UserID,
+		RoomID:         entry.RoomID,
+		StartTime:      entry.StartTime,
+		EndTime:        entry.EndTime,
+		Purpose:        entry.Purpose,
+		AutoBook:       entry.AutoBook,
+		Status:         entry.Status,
+		BookingID:      entry.BookingID,
+		OfferExpiresAt: entry.OfferExpiresAt,
+		CreatedAt:      entry.CreatedAt,
+		UpdatedAt:      entry.UpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal waitlist entry: %w", err)
+	}
+
+	_, err = repo.client.PutItem(context.Background(), &dynamodb.PutItemInput{
+		TableName:           aws.String(repo.table),
+		Item:                av,
+		ConditionExpression: aws.String("attribute_not_exists(SK)"),
+	})
+	if err != nil {
+		var conditionFailed *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionFailed) {
+			return domain.ErrConflict
+		}
+		log.Printf("Failed to create waitlist entry: %v", err)
+		return fmt.Errorf("failed to create waitlist entry: %w", err)
+	}
+	return nil
+}
+
+func (repo *WaitlistRepositoryDynamoDB) GetByID(id string) (*domain.WaitlistEntry, error) {
+	result, err := repo.client.GetItem(context.Background(), &dynamodb.GetItemInput{
+		TableName: aws.String(repo.table),
+		Key:       waitlistKey(id),
+	})
+	if err != nil {
+		log.Printf("Failed to get waitlist entry: %v", err)
+		return nil, fmt.Errorf("failed to get waitlist entry: %w", err)
+	}
+	if result.Item == nil {
+		return nil, domain.ErrNotFound
+	}
+
+	var item dto.WaitlistEntryDynamoDBItem
+	if err := attributevalue.UnmarshalMap(result.Item, &item); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal waitlist entry: %w", err)
+	}
+	entry := toDomainWaitlistEntry(item)
+	return &entry, nil
+}
+
+func (repo *WaitlistRepositoryDynamoDB) GetByUserID(userID string) ([]domain.WaitlistEntry, error) {
+	entries, err := repo.query(&dynamodb.QueryInput{
+		TableName:              aws.String(repo.table),
+		IndexName:              aws.String("LSI-3"),
+		KeyConditionExpression: aws.String("PK = :pk AND UserID = :userId"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":     &types.AttributeValueMemberS{Value: waitlistPK},
+			":userId": &types.AttributeValueMemberS{Value: userID},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].StartTime < entries[j].StartTime
+	})
+	return entries, nil
+}
+
+func (repo *WaitlistRepositoryDynamoDB) GetPendingByRoomAndTime(roomID string, start, end int64) ([]domain.WaitlistEntry, error) {
+	entries, err := repo.query(&dynamodb.QueryInput{
+		TableName:              aws.String(repo.table),
+		IndexName:              aws.String("LSI-5"),
+		KeyConditionExpression: aws.String("PK = :pk AND RoomID = :roomId"),
+		FilterExpression:       aws.String("EndTime > :start AND StartTime < :end AND #status IN (:waiting, :offered)"),
+		ExpressionAttributeNames: map[string]string{
+			"#status": "Status",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":      &types.AttributeValueMemberS{Value: waitlistPK},
+			":roomId":  &types.AttributeValueMemberS{Value: roomID},
+			":start":   &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", start)},
+			":end":     &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", end)},
+			":waiting": &types.AttributeValueMemberS{Value: domain.WaitlistStatusWaiting},
+			":offered": &types.AttributeValueMemberS{Value: domain.WaitlistStatusOffered},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].CreatedAt < entries[j].CreatedAt
+	})
+	return entries, nil
+}
+
+func (repo *WaitlistRepositoryDynamoDB) GetLapsedOffers(now int64) ([]domain.WaitlistEntry, error) {
+	entries, err := repo.query(&dynamodb.QueryInput{
+		TableName:              aws.String(repo.table),
+		KeyConditionExpression: aws.String("PK = :pk"),
+		FilterExpression:       aws.String("#status = :offered AND OfferExpiresAt <= :now"),
+		ExpressionAttributeNames: map[string]string{
+			"#status": "Status",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":      &types.AttributeValueMemberS{Value: waitlistPK},
+			":offered": &types.AttributeValueMemberS{Value: domain.WaitlistStatusOffered},
+			":now":     &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", now)},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].CreatedAt < entries[j].CreatedAt
+	})
+	return entries, nil
+}
+
+func (repo *WaitlistRepositoryDynamoDB) query(input *dynamodb.QueryInput) ([]domain.WaitlistEntry, error) {
+	ctx := context.Background()
+
+	entries := []domain.WaitlistEntry{}
+	paginator := dynamodb.NewQueryPaginator(repo.client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			log.Printf("Failed to query waitlist entries: %v", err)
+			return nil, fmt.Errorf("failed to query waitlist entries: %w", err)
+		}
+
+		var items []dto.WaitlistEntryDynamoDBItem
+		if err := attributevalue.UnmarshalListOfMaps(page.Items, &items); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal waitlist entries: %w", err)
+		}
+		for _, item := range items {
+			entries = append(entries, toDomainWaitlistEntry(item))
+		}
+	}
+	return entries, nil
+}
+
+func (repo *WaitlistRepositoryDynamoDB) Update(entry *domain.WaitlistEntry) error {
+	if entry == nil {
+		return domain.ErrInvalidInput
+	}
+
+	_, err := repo.client.UpdateItem(context.Background(), &dynamodb.UpdateItemInput{
+		TableName:           aws.String(repo.table),
+		Key:                 waitlistKey(entry.ID),
+		UpdateExpression:    aws.String("SET #status = :status, BookingID = :bookingId, OfferExpiresAt = :offerExpiresAt, UpdatedAt = :updatedAt"),
+		ConditionExpression: aws.String("attribute_exists(SK)"),
+		ExpressionAttributeNames: map[string]string{
+			"#status": "Status",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":status":         &types.AttributeValueMemberS{Value: entry.Status},
+			":bookingId":      &types.AttributeValueMemberS{Value: entry.BookingID},
+			":offerExpiresAt": &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", entry.OfferExpiresAt)},
+			":updatedAt":      &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", entry.UpdatedAt)},
+		},
+	})
+	if err != nil {
+		var conditionFailed *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionFailed) {
+			return domain.ErrNotFound
+		}
+		log.Printf("Failed to update waitlist entry: %v", err)
+		return fmt.Errorf("failed to update waitlist entry: %w", err)
+	}
+	return nil
+}
@@ -16,9 +16,25 @@ func NewBookingRepository(db *sql.DB) *bookingRepository {
 	return &bookingRepository{db: db}
 }
 
-func (r *bookingRepository) scanBooking(rows *sql.Rows) (domain.Booking, error) {
+// activeBookingCondition leaves out bookings that no longer hold their slot.
+const activeBookingCondition = `status NOT IN ('cancelled', 'no_show', 'rejected', 'expired')`
+
+// changeableBookingCondition matches bookings that can still be edited,
+// reassigned or cancelled.
+const changeableBookingCondition = `status IN ('confirmed', 'pending')`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func (r *bookingRepository) scanBooking(row rowScanner) (domain.Booking, error) {
 	var booking domain.Booking
-	err := rows.Scan(&booking.ID, &booking.UserID, &booking.RoomID, &booking.StartTime, &booking.EndTime, &booking.Purpose, &booking.CreatedAt, &booking.UpdatedAt)
+	err := row.Scan(
+		&booking.ID, &booking.UserID, &booking.RoomID, asUnixTime(&booking.StartTime), asUnixTime(&booking.EndTime), &booking.Purpose,
+		&booking.Status, &booking.SeriesID, &booking.GroupID, &booking.CancelledAt, &booking.CancelledBy, &booking.CancellationReason,
+		&booking.Sequence, &booking.ApprovalExpiresAt, &booking.ReviewedBy, &booking.ReviewedAt, &booking.ReviewNote,
+		&booking.CheckedInAt, &booking.ReleasedAt, asUnixTime(&booking.CreatedAt), asUnixTime(&booking.UpdatedAt),
+	)
 	return booking, err
 }
 
@@ -34,18 +50,19 @@ func (r *bookingRepository) scanBookings(rows *sql.Rows) ([]domain.Booking, erro
 	return bookings, nil
 }
 
-func (r *bookingRepository) checkAvailability(roomID string, startTime, endTime int64) (bool, error) {
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+func (r *bookingRepository) checkAvailability(ctx context.Context, q queryRower, roomID string, startTime, endTime int64, excludeID string) (bool, error) {
 	query := `
 		SELECT COUNT(*) 
 		FROM bookings 
-		WHERE room_id = ?
-		AND ((start_time < ? AND end_time > ?) OR (start_time < ? AND end_time > ?))
-	`
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-	defer cancel()
-
+		WHERE room_id = ? AND id <> ?
+		AND start_time < ? AND end_time > ?
+		AND ` + activeBookingCondition
 	var conflictCount int
-	err := r.db.QueryRowContext(ctx, query, roomID, endTime, startTime, endTime, startTime).Scan(&conflictCount)
+	err := q.QueryRowContext(ctx, query, roomID, excludeID, endTime, startTime).Scan(&conflictCount)
 	if err != nil {
 		return false, err
 	}
@@ -56,47 +73,211 @@ func (r *bookingRepository) Create(booking *domain.Booking) error {
 	if booking == nil {
 		return domain.ErrInvalidInput
 	}
+	return r.inTx(func(ctx context.Context, tx *sql.Tx) error {
+		return r.insertBooking(ctx, tx, booking)
+	})
+}
+
+func (r *bookingRepository) Update(booking *domain.Booking) error {
+	if booking == nil {
+		return domain.ErrInvalidInput
+	}
+	return r.inTx(func(ctx context.Context, tx *sql.Tx) error {
+		return r.updateBooking(ctx, tx, booking)
+	})
+}
+
+// CreateAll inserts the bookings in one transaction, so a room that turns
+// out to be taken leaves none of them behind.
+func (r *bookingRepository) CreateAll(bookings []domain.Booking) error {
+	if len(bookings) == 0 {
+		return domain.ErrInvalidInput
+	}
+	return r.inTx(func(ctx context.Context, tx *sql.Tx) error {
+		for i := range bookings {
+			if err := r.insertBooking(ctx, tx, &bookings[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// UpdateAll updates every booking before checking any of them, so bookings
+// that move into slots others of the batch are leaving do not collide.
+func (r *bookingRepository) UpdateAll(bookings []domain.Booking) error {
+	if len(bookings) == 0 {
+		return domain.ErrInvalidInput
+	}
+	return r.inTx(func(ctx context.Context, tx *sql.Tx) error {
+		for i := range bookings {
+			if err := r.writeBookingUpdate(ctx, tx, &bookings[i]); err != nil {
+				return err
+			}
+		}
+		for _, booking := range bookings {
+			if err := r.requireAvailable(ctx, tx, &booking); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *bookingRepository) CancelAll(bookingIDs []string, cancelledBy, reason string, cancelledAt int64) error {
+	if len(bookingIDs) == 0 {
+		return domain.ErrInvalidInput
+	}
+	query := `
+		UPDATE bookings
+		SET status = ?, cancelled_at = ?, cancelled_by = ?, cancellation_reason = ?, sequence = sequence + 1, updated_at = ?
+		WHERE id = ? AND ` + changeableBookingCondition
+	return r.inTx(func(ctx context.Context, tx *sql.Tx) error {
+		for _, bookingID := range bookingIDs {
+			result, err := tx.ExecContext(ctx, query,
+				domain.BookingStatusCancelled, cancelledAt, cancelledBy, reason, cancelledAt,
+				bookingID,
+			)
+			if err != nil {
+				return err
+			}
+			rowsAffected, err := result.RowsAffected()
+			if err != nil {
+				return err
+			}
+			if rowsAffected == 0 {
+				return domain.ErrBookingNotActive
+			}
+		}
+		return nil
+	})
+}
+
+func (r *bookingRepository) ReassignAll(bookingIDs []string, userID string, updatedAt int64) error {
+	if len(bookingIDs) == 0 {
+		return domain.ErrInvalidInput
+	}
+	query := `
+		UPDATE bookings
+		SET user_id = ?, sequence = sequence + 1, updated_at = ?
+		WHERE id = ? AND ` + changeableBookingCondition
+	return r.inTx(func(ctx context.Context, tx *sql.Tx) error {
+		for _, bookingID := range bookingIDs {
+			result, err := tx.ExecContext(ctx, query, userID, updatedAt, bookingID)
+			if err != nil {
+				return err
+			}
+			rowsAffected, err := result.RowsAffected()
+			if err != nil {
+				return err
+			}
+			if rowsAffected == 0 {
+				return domain.ErrBookingNotActive
+			}
+		}
+		return nil
+	})
+}
+
+// inTx runs write in a transaction that is committed only when it succeeds.
+func (r *bookingRepository) inTx(write func(ctx context.Context, tx *sql.Tx) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
 
-	available, err := r.checkAvailability(booking.RoomID, booking.StartTime, booking.EndTime)
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
-	if !available {
-		return domain.ErrConflict
+	defer tx.Rollback()
+
+	if err := write(ctx, tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *bookingRepository) insertBooking(ctx context.Context, tx *sql.Tx, booking *domain.Booking) error {
+	if err := r.requireAvailable(ctx, tx, booking); err != nil {
+		return err
 	}
 
 	query := `
-		INSERT INTO bookings (id, user_id, room_id, start_time, end_time, purpose, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO bookings (id, user_id, room_id, start_time, end_time, purpose, status, series_id, group_id, approval_expires_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-	defer cancel()
-
-	_, err = r.db.ExecContext(ctx, query,
+	_, err := tx.ExecContext(ctx, query,
 		booking.ID,
 		booking.UserID,
 		booking.RoomID,
 		booking.StartTime,
 		booking.EndTime,
 		booking.Purpose,
+		booking.Status,
+		booking.SeriesID,
+		booking.GroupID,
+		booking.ApprovalExpiresAt,
 		booking.CreatedAt,
 		booking.UpdatedAt,
 	)
 	return err
 }
 
+func (r *bookingRepository) updateBooking(ctx context.Context, tx *sql.Tx, booking *domain.Booking) error {
+	if err := r.requireAvailable(ctx, tx, booking); err != nil {
+		return err
+	}
+	return r.writeBookingUpdate(ctx, tx, booking)
+}
+
+func (r *bookingRepository) requireAvailable(ctx context.Context, tx *sql.Tx, booking *domain.Booking) error {
+	available, err := r.checkAvailability(ctx, tx, booking.RoomID, booking.StartTime, booking.EndTime, booking.ID)
+	if err != nil {
+		return err
+	}
+	if !available {
+		return domain.ErrRoomUnavailable
+	}
+	return nil
+}
+
+func (r *bookingRepository) writeBookingUpdate(ctx context.Context, tx *sql.Tx, booking *domain.Booking) error {
+	query := `
+		UPDATE bookings
+		SET room_id = ?, series_id = ?, start_time = ?, end_time = ?, purpose = ?, status = ?, approval_expires_at = ?, sequence = sequence + 1, updated_at = ?
+		WHERE id = ? AND ` + changeableBookingCondition
+	result, err := tx.ExecContext(ctx, query,
+		booking.RoomID,
+		booking.SeriesID,
+		booking.StartTime,
+		booking.EndTime,
+		booking.Purpose,
+		booking.Status,
+		booking.ApprovalExpiresAt,
+		booking.UpdatedAt,
+		booking.ID,
+	)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain.ErrBookingNotActive
+	}
+	return nil
+}
+
 func (r *bookingRepository) GetByID(bookingID string) (*domain.Booking, error) {
 	query := `
-		SELECT id, user_id, room_id, start_time, end_time, purpose, created_at, updated_at
+		SELECT id, user_id, room_id, start_time, end_time, purpose, status, series_id, group_id, cancelled_at, cancelled_by, cancellation_reason, sequence, approval_expires_at, reviewed_by, reviewed_at, review_note, checked_in_at, released_at, created_at, updated_at
 		FROM bookings WHERE id = ?
 	`
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	var booking domain.Booking
-	err := r.db.QueryRowContext(ctx, query, bookingID).Scan(
-		&booking.ID, &booking.UserID, &booking.RoomID, &booking.StartTime, &booking.EndTime, &booking.Purpose, &booking.CreatedAt, &booking.UpdatedAt,
-	)
+	booking, err := r.scanBooking(r.db.QueryRowContext(ctx, query, bookingID))
 	if err == sql.ErrNoRows {
 		return nil, domain.ErrNotFound
 	}
@@ -108,7 +289,7 @@ func (r *bookingRepository) GetByID(bookingID string) (*domain.Booking, error) {
 
 func (r *bookingRepository) GetAll() ([]domain.Booking, error) {
 	query := `
-		SELECT id, user_id, room_id, start_time, end_time, purpose, created_at, updated_at 
+		SELECT id, user_id, room_id, start_time, end_time, purpose, status, series_id, group_id, cancelled_at, cancelled_by, cancellation_reason, sequence, approval_expires_at, reviewed_by, reviewed_at, review_note, checked_in_at, released_at, created_at, updated_at 
 		FROM bookings ORDER BY start_time DESC
 	`
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
@@ -132,13 +313,12 @@ func (r *bookingRepository) GetAll() ([]domain.Booking, error) {
 
 func (r *bookingRepository) GetByRoomAndTime(roomID string, startTime, endTime int64) ([]domain.Booking, error) {
 	query := `
-		SELECT id, user_id, room_id, start_time, end_time, purpose, created_at, updated_at
+		SELECT id, user_id, room_id, start_time, end_time, purpose, status, series_id, group_id, cancelled_at, cancelled_by, cancellation_reason, sequence, approval_expires_at, reviewed_by, reviewed_at, review_note, checked_in_at, released_at, created_at, updated_at
 		FROM bookings
 		WHERE room_id = ? AND (
 			(start_time < ? AND end_time > ?) OR
 			(start_time >= ? AND start_time < ?)
-		)
-	`
+		) AND ` + activeBookingCondition
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
@@ -157,7 +337,7 @@ func (r *bookingRepository) GetByRoomAndTime(roomID string, startTime, endTime i
 
 func (r *bookingRepository) GetByRoomID(roomID string) ([]domain.Booking, error) {
 	query := `
-		SELECT id, user_id, room_id, start_time, end_time, purpose, created_at, updated_at
+		SELECT id, user_id, room_id, start_time, end_time, purpose, status, series_id, group_id, cancelled_at, cancelled_by, cancellation_reason, sequence, approval_expires_at, reviewed_by, reviewed_at, review_note, checked_in_at, released_at, created_at, updated_at
 		FROM bookings
 		WHERE room_id = ?
 		ORDER BY start_time ASC
@@ -180,7 +360,7 @@ func (r *bookingRepository) GetByRoomID(roomID string) ([]domain.Booking, error)
 
 func (r *bookingRepository) GetByUserID(userID string) ([]domain.Booking, error) {
 	query := `
-		SELECT id, user_id, room_id, start_time, end_time, purpose, created_at, updated_at
+		SELECT id, user_id, room_id, start_time, end_time, purpose, status, series_id, group_id, cancelled_at, cancelled_by, cancellation_reason, sequence, approval_expires_at, reviewed_by, reviewed_at, review_note, checked_in_at, released_at, created_at, updated_at
 		FROM bookings
 		WHERE user_id = ?
 		ORDER BY start_time DESC
@@ -201,12 +381,122 @@ func (r *bookingRepository) GetByUserID(userID string) ([]domain.Booking, error)
 	return bookings, nil
 }
 
-func (r *bookingRepository) Cancel(bookingID string) error {
-	query := `DELETE FROM bookings WHERE id = ?`
+func (r *bookingRepository) GetBySeriesID(seriesID string) ([]domain.Booking, error) {
+	query := `
+		SELECT id, user_id, room_id, start_time, end_time, purpose, status, series_id, group_id, cancelled_at, cancelled_by, cancellation_reason, sequence, approval_expires_at, reviewed_by, reviewed_at, review_note, checked_in_at, released_at, created_at, updated_at
+		FROM bookings
+		WHERE series_id = ?
+		ORDER BY start_time ASC
+	`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanBookings(rows)
+}
+
+func (r *bookingRepository) GetByGroupID(groupID string) ([]domain.Booking, error) {
+	query := `
+		SELECT id, user_id, room_id, start_time, end_time, purpose, status, series_id, group_id, cancelled_at, cancelled_by, cancellation_reason, sequence, approval_expires_at, reviewed_by, reviewed_at, review_note, checked_in_at, released_at, created_at, updated_at
+		FROM bookings
+		WHERE group_id = ?
+		ORDER BY room_id ASC
+	`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, groupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanBookings(rows)
+}
+
+func (r *bookingRepository) Cancel(bookingID, cancelledBy, reason string, cancelledAt int64) error {
+	query := `
+		UPDATE bookings
+		SET status = ?, cancelled_at = ?, cancelled_by = ?, cancellation_reason = ?, sequence = sequence + 1, updated_at = ?
+		WHERE id = ? AND ` + changeableBookingCondition
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, query,
+		domain.BookingStatusCancelled, cancelledAt, cancelledBy, reason, cancelledAt,
+		bookingID,
+	)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		if _, err := r.GetByID(bookingID); err != nil {
+			return err
+		}
+		return domain.ErrBookingNotActive
+	}
+	return nil
+}
+
+func (r *bookingRepository) Review(bookingID, status, reviewedBy, note string, reviewedAt int64) error {
+	query := `
+		UPDATE bookings
+		SET status = ?, reviewed_by = ?, reviewed_at = ?, review_note = ?, sequence = sequence + 1, updated_at = ?
+		WHERE id = ? AND status = ?
+	`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, query,
+		status, reviewedBy, reviewedAt, note, reviewedAt,
+		bookingID, domain.BookingStatusPending,
+	)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		if _, err := r.GetByID(bookingID); err != nil {
+			return err
+		}
+		return domain.ErrBookingNotPending
+	}
+	return nil
+}
+
+func (r *bookingRepository) CheckIn(bookingID string, checkedInAt int64) error {
+	return r.settleCheckIn(bookingID, `checked_in_at = ?`, checkedInAt, checkedInAt)
+}
+
+func (r *bookingRepository) MarkNoShow(bookingID string, releasedAt int64) error {
+	return r.settleCheckIn(bookingID, `status = ?, released_at = ?`, releasedAt, domain.BookingStatusNoShow, releasedAt)
+}
+
+// settleCheckIn applies assignment with its values to a confirmed booking
+// nobody has checked in to yet.
+func (r *bookingRepository) settleCheckIn(bookingID, assignment string, updatedAt int64, values ...any) error {
+	query := `
+		UPDATE bookings
+		SET ` + assignment + `, sequence = sequence + 1, updated_at = ?
+		WHERE id = ? AND status = ? AND checked_in_at = 0
+	`
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	result, err := r.db.ExecContext(ctx, query, bookingID)
+	args := append(values, updatedAt, bookingID, domain.BookingStatusConfirmed)
+	result, err := r.db.ExecContext(ctx, query, args...)
 	if err != nil {
 		return err
 	}
@@ -215,16 +505,100 @@ func (r *bookingRepository) Cancel(bookingID string) error {
 		return err
 	}
 	if rowsAffected == 0 {
-		return domain.ErrNotFound
+		booking, err := r.GetByID(bookingID)
+		if err != nil {
+			return err
+		}
+		if booking.Status == domain.BookingStatusConfirmed {
+			return domain.ErrAlreadyCheckedIn
+		}
+		return domain.ErrBookingNotActive
 	}
 	return nil
 }
 
+func (r *bookingRepository) GetAwaitingCheckIn(startedBefore, endsAfter int64) ([]domain.Booking, error) {
+	query := `
+		SELECT id, user_id, room_id, start_time, end_time, purpose, status, series_id, group_id, cancelled_at, cancelled_by, cancellation_reason, sequence, approval_expires_at, reviewed_by, reviewed_at, review_note, checked_in_at, released_at, created_at, updated_at
+		FROM bookings
+		WHERE status = ? AND checked_in_at = 0 AND start_time <= ? AND end_time > ?
+		ORDER BY start_time ASC
+	`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, domain.BookingStatusConfirmed, startedBefore, endsAfter)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanBookings(rows)
+}
+
+func (r *bookingRepository) CompleteEnded(endedBefore, unclaimedEndedBefore, completedAt int64) (int, error) {
+	query := `
+		UPDATE bookings
+		SET status = ?, updated_at = ?
+		WHERE status = ? AND end_time <= ? AND (checked_in_at > 0 OR end_time <= ?)
+	`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, query, domain.BookingStatusCompleted, completedAt, domain.BookingStatusConfirmed, endedBefore, unclaimedEndedBefore)
+	if err != nil {
+		return 0, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(rowsAffected), nil
+}
+
+func (r *bookingRepository) GetByStatus(status string) ([]domain.Booking, error) {
+	query := `
+		SELECT id, user_id, room_id, start_time, end_time, purpose, status, series_id, group_id, cancelled_at, cancelled_by, cancellation_reason, sequence, approval_expires_at, reviewed_by, reviewed_at, review_note, checked_in_at, released_at, created_at, updated_at
+		FROM bookings
+		WHERE status = ?
+		ORDER BY start_time DESC
+	`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanBookings(rows)
+}
+
+func (r *bookingRepository) GetByUserIDAndStatus(userID, status string) ([]domain.Booking, error) {
+	query := `
+		SELECT id, user_id, room_id, start_time, end_time, purpose, status, series_id, group_id, cancelled_at, cancelled_by, cancellation_reason, sequence, approval_expires_at, reviewed_by, reviewed_at, review_note, checked_in_at, released_at, created_at, updated_at
+		FROM bookings
+		WHERE user_id = ? AND status = ?
+		ORDER BY start_time DESC
+	`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, userID, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanBookings(rows)
+}
+
 func (r *bookingRepository) GetByDateRange(startDate, endDate int64) ([]domain.Booking, error) {
 	query := `
-		SELECT id, user_id, room_id, start_time, end_time, purpose, created_at, updated_at
+		SELECT id, user_id, room_id, start_time, end_time, purpose, status, series_id, group_id, cancelled_at, cancelled_by, cancellation_reason, sequence, approval_expires_at, reviewed_by, reviewed_at, review_note, checked_in_at, released_at, created_at, updated_at
 		FROM bookings
-		WHERE start_time >= ? AND end_time <= ?
+		WHERE start_time >= ? AND end_time <= ? AND ` + activeBookingCondition + `
 		ORDER BY start_time ASC
 	`
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
@@ -245,9 +619,9 @@ func (r *bookingRepository) GetByRoomIDAndDate(roomID string, targetDate int64)
 	endOfDay := time.Date(targetTime.Year(), targetTime.Month(), targetTime.Day(), 23, 59, 59, 0, targetTime.Location()).Unix()
 
 	query := `
-		SELECT id, user_id, room_id, start_time, end_time, purpose, created_at, updated_at
+		SELECT id, user_id, room_id, start_time, end_time, purpose, status, series_id, group_id, cancelled_at, cancelled_by, cancellation_reason, sequence, approval_expires_at, reviewed_by, reviewed_at, review_note, checked_in_at, released_at, created_at, updated_at
 		FROM bookings
-		WHERE room_id = ? AND start_time >= ? AND start_time <= ?
+		WHERE room_id = ? AND start_time >= ? AND start_time <= ? AND ` + activeBookingCondition + `
 		ORDER BY start_time ASC
 	`
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
@@ -0,0 +1,332 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+)
+
+const parallelWriters = 200
+
+func newTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := NewSQLiteConnection(DBConfig{Path: filepath.Join(t.TempDir(), "bookings.db")})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := InitSQLite(db); err != nil {
+		t.Fatalf("init db: %v", err)
+	}
+	return db
+}
+
+func seedUserAndRoom(t *testing.T, db *sql.DB) (userID, roomID string) {
+	t.Helper()
+	user := &domain.User{ID: "user-1", Name: "Test", Email: "test@example.com", Password: "x", Role: domain.UserRoleUser, Status: domain.UserStatusActive}
+	if err := NewUserRepository(db).Create(user); err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	room := &domain.Room{ID: "room-1", Name: "Room", RoomNumber: 1, Capacity: 4, Status: domain.RoomStatusAvailable}
+	if err := NewRoomRepository(db).Create(room); err != nil {
+		t.Fatalf("seed room: %v", err)
+	}
+	return user.ID, room.ID
+}
+
+// runParallel calls write from n goroutines at once and returns how many
+// succeeded, failing the test on any error other than ErrRoomUnavailable.
+func runParallel(t *testing.T, n int, write func(i int) error) int {
+	t.Helper()
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		succeeded int
+		failures  []error
+	)
+	start := make(chan struct{})
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			err := write(i)
+			mu.Lock()
+			defer mu.Unlock()
+			switch err {
+			case nil:
+				succeeded++
+			case domain.ErrRoomUnavailable:
+			default:
+				failures = append(failures, err)
+			}
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	for _, err := range failures {
+		t.Errorf("unexpected error: %v", err)
+	}
+	return succeeded
+}
+
+func TestBookingRepositoryCreateAllowsOneOfParallelOverlaps(t *testing.T) {
+	db := newTestDB(t)
+	userID, roomID := seedUserAndRoom(t, db)
+	repo := NewBookingRepository(db)
+
+	base := time.Now().Add(24 * time.Hour).Truncate(time.Hour).Unix()
+	succeeded := runParallel(t, parallelWriters, func(i int) error {
+		// Every window overlaps every other one by at least half an hour.
+		start := base + int64(i%30)*60
+		return repo.Create(&domain.Booking{
+			ID:        fmt.Sprintf("booking-%d", i),
+			UserID:    userID,
+			RoomID:    roomID,
+			StartTime: start,
+			EndTime:   start + 3600,
+			Status:    domain.BookingStatusConfirmed,
+		})
+	})
+	if succeeded != 1 {
+		t.Fatalf("%d of %d overlapping creates succeeded, want exactly 1", succeeded, parallelWriters)
+	}
+
+	bookings, err := repo.GetByRoomID(roomID)
+	if err != nil {
+		t.Fatalf("list bookings: %v", err)
+	}
+	if len(bookings) != 1 {
+		t.Fatalf("room holds %d bookings, want 1", len(bookings))
+	}
+}
+
+func TestBookingRepositoryUpdateAllowsOneOfParallelOverlaps(t *testing.T) {
+	db := newTestDB(t)
+	userID, roomID := seedUserAndRoom(t, db)
+	repo := NewBookingRepository(db)
+
+	// Lay the bookings out back to back after the contested window, then move
+	// them all into it at once.
+	base := time.Now().Add(24 * time.Hour).Truncate(time.Hour).Unix()
+	bookings := make([]*domain.Booking, parallelWriters)
+	for i := range bookings {
+		start := base + int64(i+2)*3600
+		bookings[i] = &domain.Booking{
+			ID:        fmt.Sprintf("booking-%d", i),
+			UserID:    userID,
+			RoomID:    roomID,
+			StartTime: start,
+			EndTime:   start + 3600,
+			Status:    domain.BookingStatusConfirmed,
+		}
+		if err := repo.Create(bookings[i]); err != nil {
+			t.Fatalf("create booking %d: %v", i, err)
+		}
+	}
+
+	succeeded := runParallel(t, parallelWriters, func(i int) error {
+		moved := *bookings[i]
+		moved.StartTime = base + int64(i%30)*60
+		moved.EndTime = moved.StartTime + 3600
+		return repo.Update(&moved)
+	})
+	if succeeded != 1 {
+		t.Fatalf("%d of %d overlapping updates succeeded, want exactly 1", succeeded, parallelWriters)
+	}
+
+	overlapping, err := repo.GetByRoomAndTime(roomID, base, base+2*3600)
+	if err != nil {
+		t.Fatalf("list bookings: %v", err)
+	}
+	var inWindow int
+	for _, b := range overlapping {
+		if b.StartTime < base+3600 {
+			inWindow++
+		}
+	}
+	if inWindow != 1 {
+		t.Fatalf("%d bookings were moved into the contested window, want 1", inWindow)
+	}
+}
+
+func TestBookingRepositoryCreateAllWritesNoneOnConflict(t *testing.T) {
+	db := newTestDB(t)
+	userID, roomID := seedUserAndRoom(t, db)
+	repo := NewBookingRepository(db)
+
+	base := time.Now().Add(24 * time.Hour).Truncate(time.Hour).Unix()
+	blocker := &domain.Booking{ID: "blocker", UserID: userID, RoomID: roomID, StartTime: base + 2*3600, EndTime: base + 3*3600, Status: domain.BookingStatusConfirmed}
+	if err := repo.Create(blocker); err != nil {
+		t.Fatalf("create blocker: %v", err)
+	}
+
+	bookings := make([]domain.Booking, 3)
+	for i := range bookings {
+		start := base + int64(i)*3600
+		bookings[i] = domain.Booking{ID: fmt.Sprintf("booking-%d", i), UserID: userID, RoomID: roomID, StartTime: start, EndTime: start + 3600, Status: domain.BookingStatusConfirmed}
+	}
+	if err := repo.CreateAll(bookings); err != domain.ErrRoomUnavailable {
+		t.Fatalf("CreateAll() = %v, want ErrRoomUnavailable", err)
+	}
+
+	stored, err := repo.GetByRoomID(roomID)
+	if err != nil {
+		t.Fatalf("list bookings: %v", err)
+	}
+	if len(stored) != 1 {
+		t.Fatalf("room holds %d bookings, want only the blocker", len(stored))
+	}
+}
+
+func TestBookingRepositoryUpdateAllMovesIntoSlotsTheBatchLeaves(t *testing.T) {
+	db := newTestDB(t)
+	userID, roomID := seedUserAndRoom(t, db)
+	repo := NewBookingRepository(db)
+
+	base := time.Now().Add(24 * time.Hour).Truncate(time.Hour).Unix()
+	bookings := make([]domain.Booking, 3)
+	for i := range bookings {
+		start := base + int64(i)*3600
+		bookings[i] = domain.Booking{ID: fmt.Sprintf("booking-%d", i), UserID: userID, RoomID: roomID, StartTime: start, EndTime: start + 3600, Status: domain.BookingStatusConfirmed}
+	}
+	if err := repo.CreateAll(bookings); err != nil {
+		t.Fatalf("CreateAll() failed: %v", err)
+	}
+
+	// Each booking but the last moves into the slot of the next one.
+	for i := range bookings {
+		bookings[i].StartTime += 3600
+		bookings[i].EndTime += 3600
+	}
+	if err := repo.UpdateAll(bookings); err != nil {
+		t.Fatalf("UpdateAll() failed: %v", err)
+	}
+
+	// Moving them onto each other must still fail.
+	bookings[0].StartTime, bookings[0].EndTime = bookings[1].StartTime, bookings[1].EndTime
+	if err := repo.UpdateAll(bookings); err != domain.ErrRoomUnavailable {
+		t.Fatalf("UpdateAll() of overlapping bookings = %v, want ErrRoomUnavailable", err)
+	}
+}
+
+func TestBookingRepositoryReassignAllChangesNoneWhenOneIsCancelled(t *testing.T) {
+	db := newTestDB(t)
+	userID, roomID := seedUserAndRoom(t, db)
+	delegate := &domain.User{ID: "user-2", Name: "Delegate", Email: "delegate@example.com", Password: "x", Role: domain.UserRoleUser, Status: domain.UserStatusActive}
+	if err := NewUserRepository(db).Create(delegate); err != nil {
+		t.Fatalf("seed delegate: %v", err)
+	}
+	repo := NewBookingRepository(db)
+
+	base := time.Now().Add(24 * time.Hour).Truncate(time.Hour).Unix()
+	bookings := make([]domain.Booking, 3)
+	ids := make([]string, len(bookings))
+	for i := range bookings {
+		start := base + int64(i)*3600
+		bookings[i] = domain.Booking{ID: fmt.Sprintf("booking-%d", i), UserID: userID, RoomID: roomID, StartTime: start, EndTime: start + 3600, Status: domain.BookingStatusConfirmed}
+		ids[i] = bookings[i].ID
+	}
+	if err := repo.CreateAll(bookings); err != nil {
+		t.Fatalf("CreateAll() failed: %v", err)
+	}
+	if err := repo.Cancel(ids[2], userID, "", base); err != nil {
+		t.Fatalf("cancel booking: %v", err)
+	}
+
+	if err := repo.ReassignAll(ids, delegate.ID, base); err != domain.ErrBookingNotActive {
+		t.Fatalf("ReassignAll() = %v, want ErrBookingNotActive", err)
+	}
+	stored, err := repo.GetByUserID(delegate.ID)
+	if err != nil && err != domain.ErrNotFound {
+		t.Fatalf("list bookings: %v", err)
+	}
+	if len(stored) != 0 {
+		t.Fatalf("%d bookings were reassigned, want none", len(stored))
+	}
+
+	if err := repo.ReassignAll(ids[:2], delegate.ID, base); err != nil {
+		t.Fatalf("ReassignAll() failed: %v", err)
+	}
+	stored, err = repo.GetByUserID(delegate.ID)
+	if err != nil {
+		t.Fatalf("list bookings: %v", err)
+	}
+	if len(stored) != 2 {
+		t.Fatalf("delegate holds %d bookings, want 2", len(stored))
+	}
+}
+
+func TestBookingRepositoryCompleteEndedFeedsTheCompletedFilter(t *testing.T) {
+	db := newTestDB(t)
+	userID, roomID := seedUserAndRoom(t, db)
+	repo := NewBookingRepository(db)
+
+	now := time.Now().Unix()
+	grace := int64(3600)
+	bookings := []struct {
+		id           string
+		start, end   int64
+		checkedIn    bool
+		cancelled    bool
+		wantComplete bool
+	}{
+		{id: "ended-checked-in", start: now - 2400, end: now - 1800, checkedIn: true, wantComplete: true},
+		{id: "ended-unclaimed-within-grace", start: now - 3000, end: now - 2400},
+		{id: "ended-unclaimed-long-ago", start: now - 3*3600, end: now - 2*3600, wantComplete: true},
+		{id: "running-checked-in", start: now - 1800, end: now + 1800, checkedIn: true},
+		{id: "ended-cancelled", start: now - 5*3600, end: now - 4*3600, cancelled: true},
+	}
+	for _, b := range bookings {
+		booking := &domain.Booking{ID: b.id, UserID: userID, RoomID: roomID, StartTime: b.start, EndTime: b.end, Status: domain.BookingStatusConfirmed}
+		if err := repo.Create(booking); err != nil {
+			t.Fatalf("create %s: %v", b.id, err)
+		}
+		if b.checkedIn {
+			if err := repo.CheckIn(b.id, b.start); err != nil {
+				t.Fatalf("check in %s: %v", b.id, err)
+			}
+		}
+		if b.cancelled {
+			if err := repo.Cancel(b.id, userID, "", b.start); err != nil {
+				t.Fatalf("cancel %s: %v", b.id, err)
+			}
+		}
+	}
+
+	completed, err := repo.CompleteEnded(now, now-grace, now)
+	if err != nil {
+		t.Fatalf("CompleteEnded() failed: %v", err)
+	}
+	if completed != 2 {
+		t.Fatalf("CompleteEnded() = %d, want 2", completed)
+	}
+
+	stored, err := repo.GetByStatus(domain.BookingStatusCompleted)
+	if err != nil {
+		t.Fatalf("list completed bookings: %v", err)
+	}
+	got := map[string]bool{}
+	for _, booking := range stored {
+		got[booking.ID] = true
+	}
+	for _, b := range bookings {
+		if got[b.id] != b.wantComplete {
+			t.Errorf("%s completed = %v, want %v", b.id, got[b.id], b.wantComplete)
+		}
+	}
+
+	stored, err = repo.GetByUserIDAndStatus(userID, domain.BookingStatusCompleted)
+	if err != nil {
+		t.Fatalf("list the user's completed bookings: %v", err)
+	}
+	if len(stored) != 2 {
+		t.Errorf("user has %d completed bookings, want 2", len(stored))
+	}
+}
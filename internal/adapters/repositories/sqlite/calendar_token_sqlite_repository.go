@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+)
+
+type calendarTokenRepository struct {
+	db *sql.DB
+}
+
+func NewCalendarTokenRepository(db *sql.DB) *calendarTokenRepository {
+	return &calendarTokenRepository{db: db}
+}
+
+func (r *calendarTokenRepository) Create(token *domain.CalendarToken) error {
+	if token == nil {
+		return domain.ErrInvalidInput
+	}
+
+	query := `INSERT INTO calendar_tokens (id, user_id, token_hash, created_at) VALUES (?, ?, ?, ?)`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	_, err := r.db.ExecContext(ctx, query, token.ID, token.UserID, token.TokenHash, token.CreatedAt)
+	return err
+}
+
+func (r *calendarTokenRepository) GetByHash(tokenHash string) (*domain.CalendarToken, error) {
+	query := `SELECT id, user_id, token_hash, created_at FROM calendar_tokens WHERE token_hash = ?`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var token domain.CalendarToken
+	err := r.db.QueryRowContext(ctx, query, tokenHash).Scan(&token.ID, &token.UserID, &token.TokenHash, &token.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *calendarTokenRepository) GetByUserID(userID string) ([]domain.CalendarToken, error) {
+	query := `SELECT id, user_id, token_hash, created_at FROM calendar_tokens WHERE user_id = ? ORDER BY created_at DESC`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tokens []domain.CalendarToken
+	for rows.Next() {
+		var token domain.CalendarToken
+		if err := rows.Scan(&token.ID, &token.UserID, &token.TokenHash, &token.CreatedAt); err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, rows.Err()
+}
+
+func (r *calendarTokenRepository) Delete(id, userID string) error {
+	query := `DELETE FROM calendar_tokens WHERE id = ? AND user_id = ?`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
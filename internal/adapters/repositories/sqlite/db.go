@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	_ "github.com/mattn/go-sqlite3"
 )
@@ -20,7 +22,7 @@ func NewSQLiteConnection(cfg DBConfig) (*sql.DB, error) {
 			return nil, fmt.Errorf("failed to create db directory: %w", err)
 		}
 	}
-	db, err := sql.Open("sqlite3", cfg.Path)
+	db, err := sql.Open("sqlite3", dataSourceName(cfg.Path))
 	if err != nil {
 		return nil, fmt.Errorf("failed to open SQLite connection: %w", err)
 	}
@@ -30,9 +32,57 @@ func NewSQLiteConnection(cfg DBConfig) (*sql.DB, error) {
 	return db, nil
 }
 
+// dataSourceName makes every transaction take the write lock up front and
+// wait for it instead of failing with SQLITE_BUSY, so a read-then-write
+// transaction cannot interleave with another writer. Foreign keys are a
+// per-connection setting, so they are enabled here for the whole pool.
+func dataSourceName(path string) string {
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	return path + separator + "_txlock=immediate&_busy_timeout=5000&_foreign_keys=on"
+}
+
 func CloseDB(db *sql.DB) error {
 	if db == nil {
 		return nil
 	}
 	return db.Close()
 }
+
+// unixTime scans columns declared as TIMESTAMP or DATETIME into Unix seconds.
+// The sqlite3 driver converts such columns to time.Time even when they hold
+// integers, which database/sql cannot assign to an int64 field directly.
+type unixTime int64
+
+func asUnixTime(v *int64) *unixTime {
+	return (*unixTime)(v)
+}
+
+func (t *unixTime) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*t = 0
+	case int64:
+		*t = unixTime(v)
+	case time.Time:
+		*t = unixTime(v.Unix())
+	case string:
+		return t.parse(v)
+	case []byte:
+		return t.parse(string(v))
+	default:
+		return fmt.Errorf("unsupported timestamp type %T", value)
+	}
+	return nil
+}
+
+func (t *unixTime) parse(value string) error {
+	parsed, err := time.Parse("2006-01-02 15:04:05", value)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp %q: %w", value, err)
+	}
+	*t = unixTime(parsed.Unix())
+	return nil
+}
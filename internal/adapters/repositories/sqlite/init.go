@@ -2,6 +2,7 @@ package repository
 
 import (
 	"database/sql"
+	"fmt"
 	"log"
 
 	"github.com/google/uuid"
@@ -17,6 +18,8 @@ CREATE TABLE IF NOT EXISTS users (
   email TEXT UNIQUE NOT NULL,
   password TEXT NOT NULL,
   role TEXT DEFAULT 'user',
+  status TEXT NOT NULL DEFAULT 'active',
+  disabled_at INTEGER NOT NULL DEFAULT 0,
   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
   updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
@@ -31,6 +34,10 @@ CREATE TABLE IF NOT EXISTS rooms (
   status TEXT NOT NULL DEFAULT 'Available',
   location TEXT,
   description TEXT,
+  requires_approval INTEGER NOT NULL DEFAULT 0,
+  approvers TEXT NOT NULL DEFAULT '[]',
+  booking_policy TEXT NOT NULL DEFAULT '{}',
+  deleted_at INTEGER NOT NULL DEFAULT 0,
   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
   updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
@@ -42,19 +49,183 @@ CREATE TABLE IF NOT EXISTS bookings (
   start_time DATETIME NOT NULL,
   end_time DATETIME NOT NULL,
   purpose TEXT,
+  status TEXT NOT NULL DEFAULT 'confirmed',
+  cancelled_at INTEGER NOT NULL DEFAULT 0,
+  cancelled_by TEXT NOT NULL DEFAULT '',
+  cancellation_reason TEXT NOT NULL DEFAULT '',
+  series_id TEXT NOT NULL DEFAULT '',
+  group_id TEXT NOT NULL DEFAULT '',
+  sequence INTEGER NOT NULL DEFAULT 0,
+  approval_expires_at INTEGER NOT NULL DEFAULT 0,
+  reviewed_by TEXT NOT NULL DEFAULT '',
+  reviewed_at INTEGER NOT NULL DEFAULT 0,
+  review_note TEXT NOT NULL DEFAULT '',
+  checked_in_at INTEGER NOT NULL DEFAULT 0,
+  released_at INTEGER NOT NULL DEFAULT 0,
   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
   updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
   FOREIGN KEY (user_id) REFERENCES users(id),
   FOREIGN KEY (room_id) REFERENCES rooms(id)
 );
+
+CREATE INDEX IF NOT EXISTS idx_bookings_room_time ON bookings (room_id, start_time);
+
+CREATE TABLE IF NOT EXISTS booking_series (
+  id TEXT PRIMARY KEY,
+  user_id TEXT NOT NULL,
+  room_id TEXT NOT NULL,
+  purpose TEXT NOT NULL DEFAULT '',
+  rrule TEXT NOT NULL,
+  timezone TEXT NOT NULL DEFAULT 'UTC',
+  start_time INTEGER NOT NULL,
+  end_time INTEGER NOT NULL,
+  exdates TEXT NOT NULL DEFAULT '[]',
+  status TEXT NOT NULL DEFAULT 'confirmed',
+  created_at INTEGER NOT NULL,
+  updated_at INTEGER NOT NULL,
+  FOREIGN KEY (user_id) REFERENCES users(id),
+  FOREIGN KEY (room_id) REFERENCES rooms(id)
+);
+
+CREATE TABLE IF NOT EXISTS calendar_tokens (
+  id TEXT PRIMARY KEY,
+  user_id TEXT NOT NULL,
+  token_hash TEXT UNIQUE NOT NULL,
+  created_at INTEGER NOT NULL,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS kiosk_tokens (
+  id TEXT PRIMARY KEY,
+  room_id TEXT NOT NULL,
+  name TEXT NOT NULL DEFAULT '',
+  token_hash TEXT UNIQUE NOT NULL,
+  created_by TEXT NOT NULL,
+  created_at INTEGER NOT NULL,
+  FOREIGN KEY (room_id) REFERENCES rooms(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS room_blocks (
+  id TEXT PRIMARY KEY,
+  room_id TEXT NOT NULL,
+  start_time INTEGER NOT NULL,
+  end_time INTEGER NOT NULL,
+  reason TEXT NOT NULL DEFAULT '',
+  created_by TEXT NOT NULL,
+  created_at INTEGER NOT NULL,
+  FOREIGN KEY (room_id) REFERENCES rooms(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_room_blocks_room_time ON room_blocks (room_id, start_time);
+
+CREATE TABLE IF NOT EXISTS password_reset_tokens (
+  id TEXT PRIMARY KEY,
+  user_id TEXT NOT NULL,
+  token_hash TEXT UNIQUE NOT NULL,
+  expires_at INTEGER NOT NULL,
+  used_at INTEGER NOT NULL DEFAULT 0,
+  created_at INTEGER NOT NULL,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+  id TEXT PRIMARY KEY,
+  user_id TEXT NOT NULL,
+  session_id TEXT NOT NULL,
+  token_hash TEXT UNIQUE NOT NULL,
+  expires_at INTEGER NOT NULL,
+  used_at INTEGER NOT NULL DEFAULT 0,
+  created_at INTEGER NOT NULL,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user ON refresh_tokens (user_id);
+
+CREATE TABLE IF NOT EXISTS revoked_tokens (
+  id TEXT PRIMARY KEY,
+  expires_at INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS oidc_login_states (
+  state TEXT PRIMARY KEY,
+  nonce TEXT NOT NULL,
+  code_verifier TEXT NOT NULL,
+  expires_at INTEGER NOT NULL,
+  created_at INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS user_identities (
+  issuer TEXT NOT NULL,
+  subject TEXT NOT NULL,
+  user_id TEXT NOT NULL,
+  created_at INTEGER NOT NULL,
+  PRIMARY KEY (issuer, subject),
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS waitlist_entries (
+  id TEXT PRIMARY KEY,
+  user_id TEXT NOT NULL,
+  room_id TEXT NOT NULL,
+  start_time INTEGER NOT NULL,
+  end_time INTEGER NOT NULL,
+  purpose TEXT NOT NULL DEFAULT '',
+  auto_book INTEGER NOT NULL DEFAULT 0,
+  status TEXT NOT NULL,
+  booking_id TEXT NOT NULL DEFAULT '',
+  offer_expires_at INTEGER NOT NULL DEFAULT 0,
+  created_at INTEGER NOT NULL,
+  updated_at INTEGER NOT NULL,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+  FOREIGN KEY (room_id) REFERENCES rooms(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_waitlist_room_time ON waitlist_entries (room_id, start_time);
+CREATE INDEX IF NOT EXISTS idx_waitlist_user ON waitlist_entries (user_id);
 `
 
+type columnMigration struct {
+	table      string
+	column     string
+	definition string
+}
+
+// migrations adds columns introduced after a table was first created, since
+// CREATE TABLE IF NOT EXISTS leaves existing databases untouched.
+var migrations = []columnMigration{
+	{"bookings", "status", "TEXT NOT NULL DEFAULT 'confirmed'"},
+	{"bookings", "cancelled_at", "INTEGER NOT NULL DEFAULT 0"},
+	{"bookings", "cancelled_by", "TEXT NOT NULL DEFAULT ''"},
+	{"bookings", "cancellation_reason", "TEXT NOT NULL DEFAULT ''"},
+	{"bookings", "series_id", "TEXT NOT NULL DEFAULT ''"},
+	{"bookings", "sequence", "INTEGER NOT NULL DEFAULT 0"},
+	{"bookings", "approval_expires_at", "INTEGER NOT NULL DEFAULT 0"},
+	{"bookings", "reviewed_by", "TEXT NOT NULL DEFAULT ''"},
+	{"bookings", "reviewed_at", "INTEGER NOT NULL DEFAULT 0"},
+	{"bookings", "review_note", "TEXT NOT NULL DEFAULT ''"},
+	{"bookings", "checked_in_at", "INTEGER NOT NULL DEFAULT 0"},
+	{"bookings", "released_at", "INTEGER NOT NULL DEFAULT 0"},
+	{"bookings", "group_id", "TEXT NOT NULL DEFAULT ''"},
+	{"rooms", "deleted_at", "INTEGER NOT NULL DEFAULT 0"},
+	{"rooms", "requires_approval", "INTEGER NOT NULL DEFAULT 0"},
+	{"rooms", "approvers", "TEXT NOT NULL DEFAULT '[]'"},
+	{"rooms", "booking_policy", "TEXT NOT NULL DEFAULT '{}'"},
+	{"users", "status", "TEXT NOT NULL DEFAULT 'active'"},
+	{"users", "disabled_at", "INTEGER NOT NULL DEFAULT 0"},
+}
+
 func InitSQLite(db *sql.DB) error {
 	_, err := db.Exec(schema)
 	if err != nil {
 		return err
 	}
 
+	for _, m := range migrations {
+		if err := ensureColumn(db, m); err != nil {
+			return err
+		}
+	}
+
 	var count int
 	row := db.QueryRow(`SELECT COUNT(*) FROM users WHERE email = ?`, "admin@example.com")
 	if err := row.Scan(&count); err != nil {
@@ -80,3 +251,37 @@ func InitSQLite(db *sql.DB) error {
 	}
 	return nil
 }
+
+func ensureColumn(db *sql.DB, m columnMigration) error {
+	rows, err := db.Query(fmt.Sprintf("PRAGMA table_info(%s)", m.table))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cid          int
+			name, ctype  string
+			notNull, pk  int
+			defaultValue sql.NullString
+		)
+		if err := rows.Scan(&cid, &name, &ctype, &notNull, &defaultValue, &pk); err != nil {
+			return err
+		}
+		if name == m.column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+
+	_, err = db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", m.table, m.column, m.definition))
+	if err != nil {
+		return fmt.Errorf("failed to add column %s.%s: %w", m.table, m.column, err)
+	}
+	log.Printf("Added column %s.%s", m.table, m.column)
+	return nil
+}
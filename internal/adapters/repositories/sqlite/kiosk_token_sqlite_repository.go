@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+)
+
+type kioskTokenRepository struct {
+	db *sql.DB
+}
+
+func NewKioskTokenRepository(db *sql.DB) *kioskTokenRepository {
+	return &kioskTokenRepository{db: db}
+}
+
+func (r *kioskTokenRepository) Create(token *domain.KioskToken) error {
+	if token == nil {
+		return domain.ErrInvalidInput
+	}
+
+	query := `INSERT INTO kiosk_tokens (id, room_id, name, token_hash, created_by, created_at) VALUES (?, ?, ?, ?, ?, ?)`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	_, err := r.db.ExecContext(ctx, query, token.ID, token.RoomID, token.Name, token.TokenHash, token.CreatedBy, token.CreatedAt)
+	return err
+}
+
+func (r *kioskTokenRepository) GetByHash(tokenHash string) (*domain.KioskToken, error) {
+	query := `SELECT id, room_id, name, token_hash, created_by, created_at FROM kiosk_tokens WHERE token_hash = ?`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var token domain.KioskToken
+	err := r.db.QueryRowContext(ctx, query, tokenHash).Scan(&token.ID, &token.RoomID, &token.Name, &token.TokenHash, &token.CreatedBy, &token.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *kioskTokenRepository) GetByRoomID(roomID string) ([]domain.KioskToken, error) {
+	query := `SELECT id, room_id, name, token_hash, created_by, created_at FROM kiosk_tokens WHERE room_id = ? ORDER BY created_at DESC`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, roomID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tokens []domain.KioskToken
+	for rows.Next() {
+		var token domain.KioskToken
+		if err := rows.Scan(&token.ID, &token.RoomID, &token.Name, &token.TokenHash, &token.CreatedBy, &token.CreatedAt); err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, rows.Err()
+}
+
+func (r *kioskTokenRepository) Delete(id, roomID string) error {
+	query := `DELETE FROM kiosk_tokens WHERE id = ? AND room_id = ?`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, query, id, roomID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
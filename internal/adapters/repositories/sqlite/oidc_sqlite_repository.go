@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+)
+
+type oidcLoginStateRepository struct {
+	db *sql.DB
+}
+
+func NewOIDCLoginStateRepository(db *sql.DB) *oidcLoginStateRepository {
+	return &oidcLoginStateRepository{db: db}
+}
+
+func (r *oidcLoginStateRepository) Create(state *domain.OIDCLoginState) error {
+	if state == nil {
+		return domain.ErrInvalidInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	// Abandoned logins are never consumed; drop them here.
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM oidc_login_states WHERE expires_at <= ?`, state.CreatedAt); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO oidc_login_states (state, nonce, code_verifier, expires_at, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		state.State, state.Nonce, state.CodeVerifier, state.ExpiresAt, state.CreatedAt,
+	)
+	return err
+}
+
+func (r *oidcLoginStateRepository) Consume(state string) (*domain.OIDCLoginState, error) {
+	query := `
+		DELETE FROM oidc_login_states WHERE state = ?
+		RETURNING state, nonce, code_verifier, expires_at, created_at
+	`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var loginState domain.OIDCLoginState
+	err := r.db.QueryRowContext(ctx, query, state).Scan(
+		&loginState.State, &loginState.Nonce, &loginState.CodeVerifier, &loginState.ExpiresAt, &loginState.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &loginState, nil
+}
+
+type userIdentityRepository struct {
+	db *sql.DB
+}
+
+func NewUserIdentityRepository(db *sql.DB) *userIdentityRepository {
+	return &userIdentityRepository{db: db}
+}
+
+func (r *userIdentityRepository) Create(identity *domain.UserIdentity) error {
+	if identity == nil {
+		return domain.ErrInvalidInput
+	}
+
+	query := `
+		INSERT INTO user_identities (issuer, subject, user_id, created_at)
+		VALUES (?, ?, ?, ?)
+	`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	_, err := r.db.ExecContext(ctx, query, identity.Issuer, identity.Subject, identity.UserID, identity.CreatedAt)
+	if isUniqueViolation(err) {
+		return domain.ErrConflict
+	}
+	return err
+}
+
+func (r *userIdentityRepository) GetBySubject(issuer, subject string) (*domain.UserIdentity, error) {
+	query := `
+		SELECT issuer, subject, user_id, created_at
+		FROM user_identities WHERE issuer = ? AND subject = ?
+	`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var identity domain.UserIdentity
+	err := r.db.QueryRowContext(ctx, query, issuer, subject).Scan(
+		&identity.Issuer, &identity.Subject, &identity.UserID, &identity.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
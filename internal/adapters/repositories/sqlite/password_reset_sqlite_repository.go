@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+)
+
+type passwordResetTokenRepository struct {
+	db *sql.DB
+}
+
+func NewPasswordResetTokenRepository(db *sql.DB) *passwordResetTokenRepository {
+	return &passwordResetTokenRepository{db: db}
+}
+
+func (r *passwordResetTokenRepository) Create(token *domain.PasswordResetToken) error {
+	if token == nil {
+		return domain.ErrInvalidInput
+	}
+
+	query := `
+		INSERT INTO password_reset_tokens (id, user_id, token_hash, expires_at, used_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	_, err := r.db.ExecContext(ctx, query,
+		token.ID, token.UserID, token.TokenHash, token.ExpiresAt, token.UsedAt, token.CreatedAt,
+	)
+	return err
+}
+
+func (r *passwordResetTokenRepository) GetByHash(tokenHash string) (*domain.PasswordResetToken, error) {
+	query := `
+		SELECT id, user_id, token_hash, expires_at, used_at, created_at
+		FROM password_reset_tokens WHERE token_hash = ?
+	`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var token domain.PasswordResetToken
+	err := r.db.QueryRowContext(ctx, query, tokenHash).Scan(
+		&token.ID, &token.UserID, &token.TokenHash, &token.ExpiresAt, &token.UsedAt, &token.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// MarkUsed consumes the token. It returns ErrNotFound when the token was
+// already used, so only one of two concurrent resets can succeed.
+func (r *passwordResetTokenRepository) MarkUsed(tokenHash string, usedAt int64) error {
+	query := `UPDATE password_reset_tokens SET used_at = ? WHERE token_hash = ? AND used_at = 0`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, query, usedAt, tokenHash)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *passwordResetTokenRepository) DeleteByUserID(userID string) error {
+	query := `DELETE FROM password_reset_tokens WHERE user_id = ?`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	_, err := r.db.ExecContext(ctx, query, userID)
+	return err
+}
@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+)
+
+type refreshTokenRepository struct {
+	db *sql.DB
+}
+
+func NewRefreshTokenRepository(db *sql.DB) *refreshTokenRepository {
+	return &refreshTokenRepository{db: db}
+}
+
+func (r *refreshTokenRepository) Create(token *domain.RefreshToken) error {
+	if token == nil {
+		return domain.ErrInvalidInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	// Used tokens are kept to detect replays until they expire; this is a
+	// convenient place to drop the expired ones.
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM refresh_tokens WHERE user_id = ? AND expires_at <= ?`,
+		token.UserID, token.CreatedAt); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO refresh_tokens (id, user_id, session_id, token_hash, expires_at, used_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		token.ID, token.UserID, token.SessionID, token.TokenHash, token.ExpiresAt, token.UsedAt, token.CreatedAt,
+	)
+	return err
+}
+
+func (r *refreshTokenRepository) GetByHash(tokenHash string) (*domain.RefreshToken, error) {
+	query := `
+		SELECT id, user_id, session_id, token_hash, expires_at, used_at, created_at
+		FROM refresh_tokens WHERE token_hash = ?
+	`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	token, err := scanRefreshToken(r.db.QueryRowContext(ctx, query, tokenHash))
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// MarkUsed consumes the token. It returns ErrNotFound when the token was
+// already used, so only one of two concurrent refreshes can succeed.
+func (r *refreshTokenRepository) MarkUsed(tokenHash string, usedAt int64) error {
+	query := `UPDATE refresh_tokens SET used_at = ? WHERE token_hash = ? AND used_at = 0`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, query, usedAt, tokenHash)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *refreshTokenRepository) GetByUserID(userID string) ([]domain.RefreshToken, error) {
+	query := `
+		SELECT id, user_id, session_id, token_hash, expires_at, used_at, created_at
+		FROM refresh_tokens WHERE user_id = ?
+	`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tokens []domain.RefreshToken
+	for rows.Next() {
+		token, err := scanRefreshToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, *token)
+	}
+	return tokens, rows.Err()
+}
+
+func (r *refreshTokenRepository) DeleteSession(userID, sessionID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	_, err := r.db.ExecContext(ctx, `DELETE FROM refresh_tokens WHERE user_id = ? AND session_id = ?`, userID, sessionID)
+	return err
+}
+
+func (r *refreshTokenRepository) DeleteByUserID(userID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	_, err := r.db.ExecContext(ctx, `DELETE FROM refresh_tokens WHERE user_id = ?`, userID)
+	return err
+}
+
+func scanRefreshToken(row rowScanner) (*domain.RefreshToken, error) {
+	var token domain.RefreshToken
+	err := row.Scan(
+		&token.ID, &token.UserID, &token.SessionID, &token.TokenHash, &token.ExpiresAt, &token.UsedAt, &token.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+type tokenRevocationStore struct {
+	db *sql.DB
+}
+
+func NewTokenRevocationStore(db *sql.DB) *tokenRevocationStore {
+	return &tokenRevocationStore{db: db}
+}
+
+func (r *tokenRevocationStore) Revoke(id string, expiresAt int64) error {
+	if id == "" {
+		return domain.ErrInvalidInput
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM revoked_tokens WHERE expires_at <= ?`, time.Now().Unix()); err != nil {
+		return err
+	}
+
+	// Revoking twice keeps the later expiry.
+	query := `
+		INSERT INTO revoked_tokens (id, expires_at) VALUES (?, ?)
+		ON CONFLICT(id) DO UPDATE SET expires_at = MAX(expires_at, excluded.expires_at)
+	`
+	_, err := r.db.ExecContext(ctx, query, id, expiresAt)
+	return err
+}
+
+func (r *tokenRevocationStore) IsRevoked(ids ...string) (bool, error) {
+	if len(ids) == 0 {
+		return false, nil
+	}
+
+	placeholders := make([]string, len(ids))
+	args := make([]any, 0, len(ids)+1)
+	for i, id := range ids {
+		placeholders[i] = "?"
+		args = append(args, id)
+	}
+	args = append(args, time.Now().Unix())
+
+	query := `SELECT COUNT(*) FROM revoked_tokens WHERE id IN (` + strings.Join(placeholders, ", ") + `) AND expires_at > ?`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+)
+
+type roomBlockRepository struct {
+	db *sql.DB
+}
+
+func NewRoomBlockRepository(db *sql.DB) *roomBlockRepository {
+	return &roomBlockRepository{db: db}
+}
+
+const roomBlockColumns = `id, room_id, start_time, end_time, reason, created_by, created_at`
+
+func scanRoomBlock(row rowScanner) (domain.RoomBlock, error) {
+	var block domain.RoomBlock
+	err := row.Scan(&block.ID, &block.RoomID, &block.StartTime, &block.EndTime, &block.Reason, &block.CreatedBy, &block.CreatedAt)
+	return block, err
+}
+
+func (r *roomBlockRepository) queryBlocks(query string, args ...any) ([]domain.RoomBlock, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var blocks []domain.RoomBlock
+	for rows.Next() {
+		block, err := scanRoomBlock(rows)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks, rows.Err()
+}
+
+func (r *roomBlockRepository) Create(block *domain.RoomBlock) error {
+	if block == nil {
+		return domain.ErrInvalidInput
+	}
+
+	query := `INSERT INTO room_blocks (` + roomBlockColumns + `) VALUES (?, ?, ?, ?, ?, ?, ?)`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	_, err := r.db.ExecContext(ctx, query, block.ID, block.RoomID, block.StartTime, block.EndTime, block.Reason, block.CreatedBy, block.CreatedAt)
+	return err
+}
+
+func (r *roomBlockRepository) GetByID(id string) (*domain.RoomBlock, error) {
+	query := `SELECT ` + roomBlockColumns + ` FROM room_blocks WHERE id = ?`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	block, err := scanRoomBlock(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &block, nil
+}
+
+func (r *roomBlockRepository) GetByRoomID(roomID string) ([]domain.RoomBlock, error) {
+	return r.queryBlocks(`SELECT `+roomBlockColumns+` FROM room_blocks WHERE room_id = ? ORDER BY start_time ASC`, roomID)
+}
+
+func (r *roomBlockRepository) GetByRoomAndTime(roomID string, start, end int64) ([]domain.RoomBlock, error) {
+	return r.queryBlocks(`SELECT `+roomBlockColumns+` FROM room_blocks WHERE room_id = ? AND end_time > ? AND start_time < ? ORDER BY start_time ASC`, roomID, start, end)
+}
+
+func (r *roomBlockRepository) Delete(id string) error {
+	query := `DELETE FROM room_blocks WHERE id = ?`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
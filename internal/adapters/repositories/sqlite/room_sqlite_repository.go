@@ -20,15 +20,25 @@ func NewRoomRepository(db *sql.DB) *roomRepository {
 
 func (r *roomRepository) scanRoom(rows *sql.Rows) (domain.Room, error) {
 	var room domain.Room
-	var amenitiesJSON string
-	err := rows.Scan(&room.ID, &room.Name, &room.RoomNumber, &room.Capacity, &room.Floor, &amenitiesJSON, &room.Status, &room.Location, &room.Description, &room.CreatedAt, &room.UpdatedAt)
+	var amenitiesJSON, approversJSON, policyJSON string
+	err := rows.Scan(&room.ID, &room.Name, &room.RoomNumber, &room.Capacity, &room.Floor, &amenitiesJSON, &room.Status, &room.Location, &room.Description, &room.RequiresApproval, &approversJSON, &policyJSON, &room.DeletedAt, asUnixTime(&room.CreatedAt), asUnixTime(&room.UpdatedAt))
 	if err != nil {
 		return room, err
 	}
+	decodeRoomLists(&room, amenitiesJSON, approversJSON, policyJSON)
+	return room, nil
+}
+
+func decodeRoomLists(room *domain.Room, amenitiesJSON, approversJSON, policyJSON string) {
 	if err := json.Unmarshal([]byte(amenitiesJSON), &room.Amenities); err != nil {
 		room.Amenities = []string{}
 	}
-	return room, nil
+	if err := json.Unmarshal([]byte(approversJSON), &room.Approvers); err != nil {
+		room.Approvers = []string{}
+	}
+	if err := json.Unmarshal([]byte(policyJSON), &room.BookingPolicy); err != nil {
+		room.BookingPolicy = domain.RoomPolicy{}
+	}
 }
 
 func (r *roomRepository) scanRooms(rows *sql.Rows) ([]domain.Room, error) {
@@ -58,10 +68,18 @@ func (r *roomRepository) Create(room *domain.Room) error {
 	if err != nil {
 		return err
 	}
+	approversJson, err := json.Marshal(approverList(room.Approvers))
+	if err != nil {
+		return err
+	}
+	policyJson, err := json.Marshal(room.BookingPolicy)
+	if err != nil {
+		return err
+	}
 
 	query := `
-		INSERT INTO rooms (id, name, room_number, capacity, floor, amenities, status, location, description, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO rooms (id, name, room_number, capacity, floor, amenities, status, location, description, requires_approval, approvers, booking_policy, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
@@ -76,14 +94,26 @@ func (r *roomRepository) Create(room *domain.Room) error {
 		room.Status,
 		room.Location,
 		room.Description,
+		room.RequiresApproval,
+		string(approversJson),
+		string(policyJson),
 		room.CreatedAt,
 		room.UpdatedAt,
 	)
 	return execErr
 }
 
+// approverList keeps a room without approvers stored as an empty JSON array
+// rather than null.
+func approverList(approvers []string) []string {
+	if approvers == nil {
+		return []string{}
+	}
+	return approvers
+}
+
 func (r *roomRepository) GetAll() ([]domain.Room, error) {
-	query := `SELECT id, name, room_number, capacity, floor, amenities, status, location, description, created_at, updated_at FROM rooms`
+	query := `SELECT id, name, room_number, capacity, floor, amenities, status, location, description, requires_approval, approvers, booking_policy, deleted_at, created_at, updated_at FROM rooms WHERE deleted_at = 0`
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
@@ -104,14 +134,14 @@ func (r *roomRepository) GetAll() ([]domain.Room, error) {
 }
 
 func (r *roomRepository) GetByID(roomID string) (*domain.Room, error) {
-	query := `SELECT id, name, room_number, capacity, floor, amenities, status, location, description, created_at, updated_at FROM rooms WHERE id = ?`
+	query := `SELECT id, name, room_number, capacity, floor, amenities, status, location, description, requires_approval, approvers, booking_policy, deleted_at, created_at, updated_at FROM rooms WHERE id = ?`
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
 	var room domain.Room
-	var amenitiesJSON string
+	var amenitiesJSON, approversJSON, policyJSON string
 	err := r.db.QueryRowContext(ctx, query, roomID).Scan(
-		&room.ID, &room.Name, &room.RoomNumber, &room.Capacity, &room.Floor, &amenitiesJSON, &room.Status, &room.Location, &room.Description, &room.CreatedAt, &room.UpdatedAt,
+		&room.ID, &room.Name, &room.RoomNumber, &room.Capacity, &room.Floor, &amenitiesJSON, &room.Status, &room.Location, &room.Description, &room.RequiresApproval, &approversJSON, &policyJSON, &room.DeletedAt, asUnixTime(&room.CreatedAt), asUnixTime(&room.UpdatedAt),
 	)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, domain.ErrNotFound
@@ -119,12 +149,65 @@ func (r *roomRepository) GetByID(roomID string) (*domain.Room, error) {
 	if err != nil {
 		return nil, err
 	}
-	if err := json.Unmarshal([]byte(amenitiesJSON), &room.Amenities); err != nil {
-		room.Amenities = []string{}
-	}
+	decodeRoomLists(&room, amenitiesJSON, approversJSON, policyJSON)
 	return &room, nil
 }
 
+func (r *roomRepository) Update(room *domain.Room) error {
+	if room == nil || room.ID == "" {
+		return domain.ErrInvalidInput
+	}
+
+	amenitiesJson, err := json.Marshal(room.Amenities)
+	if err != nil {
+		return err
+	}
+	approversJson, err := json.Marshal(approverList(room.Approvers))
+	if err != nil {
+		return err
+	}
+	policyJson, err := json.Marshal(room.BookingPolicy)
+	if err != nil {
+		return err
+	}
+	room.UpdatedAt = time.Now().Unix()
+
+	query := `
+		UPDATE rooms
+		SET name = ?, room_number = ?, capacity = ?, floor = ?, amenities = ?, status = ?, location = ?, description = ?, requires_approval = ?, approvers = ?, booking_policy = ?, updated_at = ?
+		WHERE id = ?
+	`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, query,
+		room.Name,
+		room.RoomNumber,
+		room.Capacity,
+		room.Floor,
+		string(amenitiesJson),
+		room.Status,
+		room.Location,
+		room.Description,
+		room.RequiresApproval,
+		string(approversJson),
+		string(policyJson),
+		room.UpdatedAt,
+		room.ID,
+	)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
 func (r *roomRepository) UpdateAvailability(roomID string, roomStatus string) error {
 	query := `UPDATE rooms SET status = ?, updated_at = ? WHERE id = ?`
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
@@ -144,16 +227,18 @@ func (r *roomRepository) UpdateAvailability(roomID string, roomStatus string) er
 	return nil
 }
 
-func (r *roomRepository) DeleteByID(roomID string) error {
+// SoftDelete marks the room as deleted; the row stays so past bookings keep
+// resolving to it.
+func (r *roomRepository) SoftDelete(roomID string, deletedAt int64) error {
 	if roomID == "" {
 		return domain.ErrInvalidInput
 	}
 
-	query := `DELETE FROM rooms WHERE id = ?`
+	query := `UPDATE rooms SET deleted_at = ?, updated_at = ? WHERE id = ? AND deleted_at = 0`
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	result, err := r.db.ExecContext(ctx, query, roomID)
+	result, err := r.db.ExecContext(ctx, query, deletedAt, deletedAt, roomID)
 	if err != nil {
 		return err
 	}
@@ -167,22 +252,47 @@ func (r *roomRepository) DeleteByID(roomID string) error {
 	return nil
 }
 
-func (r *roomRepository) SearchWithFilters(minCapacity, maxCapacity int, floorNumber *int) ([]domain.Room, error) {
-	query := `SELECT id, name, room_number, capacity, floor, amenities, status, location, description, created_at, updated_at FROM rooms WHERE 1=1`
+func (r *roomRepository) SearchWithFilters(filter domain.RoomSearchFilter) ([]domain.Room, error) {
+	query := `SELECT id, name, room_number, capacity, floor, amenities, status, location, description, requires_approval, approvers, booking_policy, deleted_at, created_at, updated_at FROM rooms WHERE deleted_at = 0`
 	queryArgs := []any{}
 
-	if minCapacity > 0 {
+	if filter.MinCapacity > 0 {
 		query += ` AND capacity >= ?`
-		queryArgs = append(queryArgs, minCapacity)
+		queryArgs = append(queryArgs, filter.MinCapacity)
 	}
-	if maxCapacity > 0 {
+	if filter.MaxCapacity > 0 {
 		query += ` AND capacity <= ?`
-		queryArgs = append(queryArgs, maxCapacity)
+		queryArgs = append(queryArgs, filter.MaxCapacity)
 	}
-	if floorNumber != nil {
+	if filter.Floor != nil {
 		query += ` AND floor = ?`
-		queryArgs = append(queryArgs, *floorNumber)
+		queryArgs = append(queryArgs, *filter.Floor)
+	}
+	for _, amenity := range filter.Amenities {
+		query += ` AND EXISTS (
+			SELECT 1 FROM json_each(CASE WHEN json_valid(rooms.amenities) THEN rooms.amenities ELSE '[]' END) AS amenity
+			WHERE LOWER(amenity.value) = LOWER(?)
+		)`
+		queryArgs = append(queryArgs, amenity)
+	}
+	if filter.Status != "" {
+		query += ` AND status = ? COLLATE NOCASE`
+		queryArgs = append(queryArgs, filter.Status)
+	}
+	if filter.StartTime != nil && filter.EndTime != nil && filter.Available != nil {
+		busyClause := `EXISTS (
+			SELECT 1 FROM bookings
+			WHERE bookings.room_id = rooms.id AND bookings.start_time < ? AND bookings.end_time > ?
+			AND bookings.` + activeBookingCondition + `
+		)`
+		if *filter.Available {
+			query += ` AND NOT ` + busyClause
+		} else {
+			query += ` AND ` + busyClause
+		}
+		queryArgs = append(queryArgs, *filter.EndTime, *filter.StartTime)
 	}
+	query += ` ORDER BY floor, room_number`
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+)
+
+type bookingSeriesRepository struct {
+	db *sql.DB
+}
+
+func NewBookingSeriesRepository(db *sql.DB) *bookingSeriesRepository {
+	return &bookingSeriesRepository{db: db}
+}
+
+func (r *bookingSeriesRepository) Create(series *domain.BookingSeries) error {
+	if series == nil {
+		return domain.ErrInvalidInput
+	}
+
+	exdatesJSON, err := json.Marshal(series.ExDates)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO booking_series (id, user_id, room_id, purpose, rrule, timezone, start_time, end_time, exdates, status, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	_, err = r.db.ExecContext(ctx, query,
+		series.ID,
+		series.UserID,
+		series.RoomID,
+		series.Purpose,
+		series.RRule,
+		series.Timezone,
+		series.StartTime,
+		series.EndTime,
+		string(exdatesJSON),
+		series.Status,
+		series.CreatedAt,
+		series.UpdatedAt,
+	)
+	return err
+}
+
+func (r *bookingSeriesRepository) GetByID(seriesID string) (*domain.BookingSeries, error) {
+	query := `
+		SELECT id, user_id, room_id, purpose, rrule, timezone, start_time, end_time, exdates, status, created_at, updated_at
+		FROM booking_series WHERE id = ?
+	`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var series domain.BookingSeries
+	var exdatesJSON string
+	err := r.db.QueryRowContext(ctx, query, seriesID).Scan(
+		&series.ID, &series.UserID, &series.RoomID, &series.Purpose, &series.RRule, &series.Timezone,
+		&series.StartTime, &series.EndTime, &exdatesJSON, &series.Status, &series.CreatedAt, &series.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(exdatesJSON), &series.ExDates); err != nil {
+		series.ExDates = nil
+	}
+	return &series, nil
+}
+
+func (r *bookingSeriesRepository) Update(series *domain.BookingSeries) error {
+	if series == nil {
+		return domain.ErrInvalidInput
+	}
+
+	exdatesJSON, err := json.Marshal(series.ExDates)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE booking_series
+		SET user_id = ?, room_id = ?, purpose = ?, rrule = ?, start_time = ?, end_time = ?, exdates = ?, status = ?, updated_at = ?
+		WHERE id = ?
+	`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, query,
+		series.UserID,
+		series.RoomID,
+		series.Purpose,
+		series.RRule,
+		series.StartTime,
+		series.EndTime,
+		string(exdatesJSON),
+		series.Status,
+		series.UpdatedAt,
+		series.ID,
+	)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *bookingSeriesRepository) Delete(seriesID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	_, err := r.db.ExecContext(ctx, `DELETE FROM booking_series WHERE id = ?`, seriesID)
+	return err
+}
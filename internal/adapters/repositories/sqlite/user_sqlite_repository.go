@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/mattn/go-sqlite3"
 )
 
 type userRepository struct {
@@ -23,26 +24,29 @@ func (r *userRepository) Create(user *domain.User) error {
 	}
 
 	query := `
-		INSERT INTO users (id, name, email, password, role, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO users (id, name, email, password, role, status, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 	`
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
 	_, err := r.db.ExecContext(ctx, query,
-		user.ID, user.Name, user.Email, user.Password, user.Role, user.CreatedAt, user.UpdatedAt,
+		user.ID, user.Name, user.Email, user.Password, user.Role, user.Status, user.CreatedAt, user.UpdatedAt,
 	)
+	if isUniqueViolation(err) {
+		return domain.ErrConflict
+	}
 	return err
 }
 
 func (r *userRepository) FindByEmail(userEmail string) (*domain.User, error) {
-	query := `SELECT id, name, email, password, role, created_at, updated_at FROM users WHERE email = ? LIMIT 1`
+	query := `SELECT id, name, email, password, role, status, disabled_at, created_at, updated_at FROM users WHERE email = ? LIMIT 1`
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
 	var user domain.User
 	err := r.db.QueryRowContext(ctx, query, userEmail).Scan(
-		&user.ID, &user.Name, &user.Email, &user.Password, &user.Role, &user.CreatedAt, &user.UpdatedAt,
+		&user.ID, &user.Name, &user.Email, &user.Password, &user.Role, &user.Status, &user.DisabledAt, asUnixTime(&user.CreatedAt), asUnixTime(&user.UpdatedAt),
 	)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, domain.ErrNotFound
@@ -54,13 +58,13 @@ func (r *userRepository) FindByEmail(userEmail string) (*domain.User, error) {
 }
 
 func (r *userRepository) GetByID(userID string) (*domain.User, error) {
-	query := `SELECT id, name, email, password, role, created_at, updated_at FROM users WHERE id = ?`
+	query := `SELECT id, name, email, password, role, status, disabled_at, created_at, updated_at FROM users WHERE id = ?`
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
 	var user domain.User
 	err := r.db.QueryRowContext(ctx, query, userID).Scan(
-		&user.ID, &user.Name, &user.Email, &user.Password, &user.Role, &user.CreatedAt, &user.UpdatedAt,
+		&user.ID, &user.Name, &user.Email, &user.Password, &user.Role, &user.Status, &user.DisabledAt, asUnixTime(&user.CreatedAt), asUnixTime(&user.UpdatedAt),
 	)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, domain.ErrNotFound
@@ -72,7 +76,7 @@ func (r *userRepository) GetByID(userID string) (*domain.User, error) {
 }
 
 func (r *userRepository) GetAll() ([]domain.User, error) {
-	query := `SELECT id, name, email, role, created_at, updated_at FROM users`
+	query := `SELECT id, name, email, role, status, disabled_at, created_at, updated_at FROM users`
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
@@ -85,7 +89,7 @@ func (r *userRepository) GetAll() ([]domain.User, error) {
 	var users []domain.User
 	for rows.Next() {
 		var user domain.User
-		err := rows.Scan(&user.ID, &user.Name, &user.Email, &user.Role, &user.CreatedAt, &user.UpdatedAt)
+		err := rows.Scan(&user.ID, &user.Name, &user.Email, &user.Role, &user.Status, &user.DisabledAt, asUnixTime(&user.CreatedAt), asUnixTime(&user.UpdatedAt))
 		if err != nil {
 			return nil, err
 		}
@@ -97,12 +101,19 @@ func (r *userRepository) GetAll() ([]domain.User, error) {
 	return users, nil
 }
 
-func (r *userRepository) DeleteByID(userID string) error {
-	query := `DELETE FROM users WHERE id = ?`
+func (r *userRepository) Update(user *domain.User) error {
+	if user == nil {
+		return domain.ErrInvalidInput
+	}
+
+	query := `UPDATE users SET name = ?, email = ?, role = ?, updated_at = ? WHERE id = ?`
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	result, err := r.db.ExecContext(ctx, query, userID)
+	result, err := r.db.ExecContext(ctx, query, user.Name, user.Email, user.Role, user.UpdatedAt, user.ID)
+	if isUniqueViolation(err) {
+		return domain.ErrConflict
+	}
 	if err != nil {
 		return err
 	}
@@ -115,3 +126,49 @@ func (r *userRepository) DeleteByID(userID string) error {
 	}
 	return nil
 }
+
+func (r *userRepository) UpdatePassword(userID, passwordHash string, updatedAt int64) error {
+	query := `UPDATE users SET password = ?, updated_at = ? WHERE id = ?`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, query, passwordHash, updatedAt, userID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *userRepository) Disable(userID string, disabledAt int64) error {
+	query := `UPDATE users SET status = ?, disabled_at = ?, updated_at = ? WHERE id = ? AND status != ?`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, query,
+		domain.UserStatusDisabled, disabledAt, disabledAt, userID, domain.UserStatusDisabled,
+	)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func isUniqueViolation(err error) bool {
+	var sqliteErr sqlite3.Error
+	return errors.As(err, &sqliteErr) &&
+		(sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique || sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey)
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+)
+
+type waitlistRepository struct {
+	db *sql.DB
+}
+
+func NewWaitlistRepository(db *sql.DB) *waitlistRepository {
+	return &waitlistRepository{db: db}
+}
+
+const waitlistColumns = `id, user_id, room_id, start_time, end_time, purpose, auto_book, status, booking_id, offer_expires_at, created_at, updated_at`
+
+func scanWaitlistEntry(row rowScanner) (domain.WaitlistEntry, error) {
+	var entry domain.WaitlistEntry
+	err := row.Scan(&entry.ID, &entry.UserID, &entry.RoomID, &entry.StartTime, &entry.EndTime, &entry.Purpose,
+		&entry.AutoBook, &entry.Status, &entry.BookingID, &entry.OfferExpiresAt, &entry.CreatedAt, &entry.UpdatedAt)
+	return entry, err
+}
+
+func (r *waitlistRepository) queryEntries(query string, args ...any) ([]domain.WaitlistEntry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []domain.WaitlistEntry
+	for rows.Next() {
+		entry, err := scanWaitlistEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+func (r *waitlistRepository) Create(entry *domain.WaitlistEntry) error {
+	if entry == nil {
+		return domain.ErrInvalidInput
+	}
+
+	query := `INSERT INTO waitlist_entries (` + waitlistColumns + `) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	_, err := r.db.ExecContext(ctx, query, entry.ID, entry.UserID, entry.RoomID, entry.StartTime, entry.EndTime, entry.Purpose,
+		entry.AutoBook, entry.Status, entry.BookingID, entry.OfferExpiresAt, entry.CreatedAt, entry.UpdatedAt)
+	return err
+}
+
+func (r *waitlistRepository) GetByID(id string) (*domain.WaitlistEntry, error) {
+	query := `SELECT ` + waitlistColumns + ` FROM waitlist_entries WHERE id = ?`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	entry, err := scanWaitlistEntry(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *waitlistRepository) GetByUserID(userID string) ([]domain.WaitlistEntry, error) {
+	return r.queryEntries(`SELECT `+waitlistColumns+` FROM waitlist_entries WHERE user_id = ? ORDER BY start_time ASC`, userID)
+}
+
+func (r *waitlistRepository) GetPendingByRoomAndTime(roomID string, start, end int64) ([]domain.WaitlistEntry, error) {
+	return r.queryEntries(`SELECT `+waitlistColumns+` FROM waitlist_entries
+		WHERE room_id = ? AND end_time > ? AND start_time < ? AND status IN (?, ?)
+		ORDER BY created_at ASC`,
+		roomID, start, end, domain.WaitlistStatusWaiting, domain.WaitlistStatusOffered)
+}
+
+func (r *waitlistRepository) GetLapsedOffers(now int64) ([]domain.WaitlistEntry, error) {
+	return r.queryEntries(`SELECT `+waitlistColumns+` FROM waitlist_entries
+		WHERE status = ? AND offer_expires_at <= ?
+		ORDER BY created_at ASC`,
+		domain.WaitlistStatusOffered, now)
+}
+
+func (r *waitlistRepository) Update(entry *domain.WaitlistEntry) error {
+	if entry == nil {
+		return domain.ErrInvalidInput
+	}
+
+	query := `
+		UPDATE waitlist_entries
+		SET status = ?, booking_id = ?, offer_expires_at = ?, updated_at = ?
+		WHERE id = ?
+	`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, query, entry.Status, entry.BookingID, entry.OfferExpiresAt, entry.UpdatedAt, entry.ID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
@@ -1,15 +1,29 @@
 package config
 
 import (
+	"fmt"
+	"log"
 	"os"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	JWT      JWTConfig
-	CORS     CORSConfig
+	Server        ServerConfig
+	Database      DatabaseConfig
+	JWT           JWTConfig
+	CORS          CORSConfig
+	Scheduling    domain.SchedulingRules
+	BookingPolicy domain.BookingPolicy
+	Mail          MailConfig
+	PasswordReset PasswordResetConfig
+	OIDC          OIDCConfig
+	Waitlist      WaitlistConfig
+	Approval      ApprovalConfig
+	CheckIn       CheckInConfig
 }
 
 type ServerConfig struct {
@@ -26,18 +40,90 @@ type DatabaseConfig struct {
 	ConnMaxLifetime time.Duration
 }
 
+// JWTConfig selects how access tokens are signed. HS256 signs with Secret;
+// RS256 and ES256 sign with the PEM PrivateKey and publish its public half
+// in the JWKS. VerificationKeys are extra PEM public keys that are still
+// accepted, so a retired signing key keeps working until its tokens expire.
+// Access tokens are kept short because clients renew them with a refresh
+// token.
 type JWTConfig struct {
-	Secret         string
-	ExpirationTime time.Duration
+	Algorithm            string
+	Secret               string
+	PrivateKey           string
+	PrivateKeyFile       string
+	VerificationKeys     string
+	VerificationKeysFile string
+	ExpirationTime       time.Duration
+	RefreshTokenTTL      time.Duration
 }
 
 type CORSConfig struct {
 	AllowedOrigins []string
 }
 
-func LoadConfig() *Config {
-	jwtSecret := os.Getenv("JWT_SECRET")
+// MailConfig selects how notifications are delivered: "log" (the default)
+// writes them to the application log, "file" appends them to FilePath and
+// "smtp" sends them as email.
+type MailConfig struct {
+	Driver       string
+	From         string
+	FilePath     string
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+}
+
+type PasswordResetConfig struct {
+	TokenTTL time.Duration
+	// ResetURL is the frontend page that accepts the token; the mailed link
+	// is ResetURL?token=<secret>. Without it the bare token is mailed.
+	ResetURL string
+}
+
+// OIDCConfig enables single sign-on when IssuerURL is set. Users signing in
+// for the first time are created with DefaultRole, unless one of their
+// GroupsClaim groups is listed in GroupRoles.
+type OIDCConfig struct {
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	GroupsClaim  string
+	GroupRoles   map[string]string
+	DefaultRole  string
+}
+
+// WaitlistConfig sets how long a user may claim a slot offered to them from
+// the waitlist before it goes to the next entry, and how often the server
+// expires offers nobody claimed.
+type WaitlistConfig struct {
+	ClaimWindow   time.Duration
+	SweepInterval time.Duration
+}
+
+// ApprovalConfig sets how long approvers have to answer a booking on a room
+// that requires approval, and how often the server expires unanswered ones.
+type ApprovalConfig struct {
+	Timeout       time.Duration
+	SweepInterval time.Duration
+}
+
+// CheckInConfig sets when check-in opens before a booking starts, how long
+// after the start a booking nobody checked in to is released as a no-show,
+// and how often the server looks for them.
+type CheckInConfig struct {
+	OpensBefore   time.Duration
+	Grace         time.Duration
+	SweepInterval time.Duration
+}
+
+func (c OIDCConfig) Enabled() bool {
+	return c.IssuerURL != ""
+}
 
+func LoadConfig() *Config {
 	dbPath := os.Getenv("DB_PATH")
 	if dbPath == "" {
 		dbPath = "./meeting_room_db.sqlite"
@@ -61,15 +147,335 @@ func LoadConfig() *Config {
 			MaxIdleConns:    5,
 			ConnMaxLifetime: 5 * time.Minute,
 		},
-		JWT: JWTConfig{
-			Secret:         jwtSecret,
-			ExpirationTime: 24 * time.Hour,
-		},
+		JWT: LoadJWTConfig(),
 		CORS: CORSConfig{
 			AllowedOrigins: []string{
 				"http://localhost:4200",
 				"http://127.0.0.1:4200",
 			},
 		},
+		Scheduling:    LoadSchedulingRules(),
+		BookingPolicy: LoadBookingPolicy(),
+		Mail:          LoadMailConfig(),
+		PasswordReset: LoadPasswordResetConfig(),
+		OIDC:          LoadOIDCConfig(),
+		Waitlist:      LoadWaitlistConfig(),
+		Approval:      LoadApprovalConfig(),
+		CheckIn:       LoadCheckInConfig(),
+	}
+}
+
+func LoadJWTConfig() JWTConfig {
+	cfg := JWTConfig{
+		Algorithm:            strings.ToUpper(strings.TrimSpace(os.Getenv("JWT_ALGORITHM"))),
+		Secret:               os.Getenv("JWT_SECRET"),
+		PrivateKey:           os.Getenv("JWT_PRIVATE_KEY"),
+		PrivateKeyFile:       os.Getenv("JWT_PRIVATE_KEY_FILE"),
+		VerificationKeys:     os.Getenv("JWT_VERIFICATION_KEYS"),
+		VerificationKeysFile: os.Getenv("JWT_VERIFICATION_KEYS_FILE"),
+		ExpirationTime:       15 * time.Minute,
+		RefreshTokenTTL:      7 * 24 * time.Hour,
+	}
+	if cfg.Algorithm == "" {
+		cfg.Algorithm = "HS256"
+	}
+
+	if value := os.Getenv("JWT_EXPIRATION"); value != "" {
+		if ttl, err := time.ParseDuration(value); err == nil && ttl > 0 {
+			cfg.ExpirationTime = ttl
+		} else {
+			log.Printf("Ignoring invalid JWT_EXPIRATION %q", value)
+		}
+	}
+	if value := os.Getenv("REFRESH_TOKEN_TTL"); value != "" {
+		if ttl, err := time.ParseDuration(value); err == nil && ttl > 0 {
+			cfg.RefreshTokenTTL = ttl
+		} else {
+			log.Printf("Ignoring invalid REFRESH_TOKEN_TTL %q", value)
+		}
+	}
+	return cfg
+}
+
+func LoadMailConfig() MailConfig {
+	cfg := MailConfig{
+		Driver:       strings.ToLower(strings.TrimSpace(os.Getenv("MAIL_DRIVER"))),
+		From:         os.Getenv("MAIL_FROM"),
+		FilePath:     os.Getenv("MAIL_FILE"),
+		SMTPHost:     os.Getenv("SMTP_HOST"),
+		SMTPPort:     os.Getenv("SMTP_PORT"),
+		SMTPUsername: os.Getenv("SMTP_USERNAME"),
+		SMTPPassword: os.Getenv("SMTP_PASSWORD"),
+	}
+	if cfg.Driver == "" {
+		cfg.Driver = "log"
+	}
+	if cfg.From == "" {
+		cfg.From = "no-reply@meeting-room.local"
+	}
+	if cfg.FilePath == "" {
+		cfg.FilePath = "./mail.log"
+	}
+	if cfg.SMTPPort == "" {
+		cfg.SMTPPort = "587"
+	}
+	return cfg
+}
+
+func LoadPasswordResetConfig() PasswordResetConfig {
+	cfg := PasswordResetConfig{
+		TokenTTL: 30 * time.Minute,
+		ResetURL: os.Getenv("PASSWORD_RESET_URL"),
+	}
+
+	if value := os.Getenv("PASSWORD_RESET_TTL"); value != "" {
+		if ttl, err := time.ParseDuration(value); err == nil && ttl > 0 {
+			cfg.TokenTTL = ttl
+		} else {
+			log.Printf("Ignoring invalid PASSWORD_RESET_TTL %q", value)
+		}
+	}
+	return cfg
+}
+
+func LoadWaitlistConfig() WaitlistConfig {
+	cfg := WaitlistConfig{ClaimWindow: 30 * time.Minute, SweepInterval: time.Minute}
+
+	if value := os.Getenv("WAITLIST_CLAIM_WINDOW"); value != "" {
+		if window, err := time.ParseDuration(value); err == nil && window > 0 {
+			cfg.ClaimWindow = window
+		} else {
+			log.Printf("Ignoring invalid WAITLIST_CLAIM_WINDOW %q", value)
+		}
+	}
+	if value := os.Getenv("WAITLIST_SWEEP_INTERVAL"); value != "" {
+		if interval, err := time.ParseDuration(value); err == nil && interval > 0 {
+			cfg.SweepInterval = interval
+		} else {
+			log.Printf("Ignoring invalid WAITLIST_SWEEP_INTERVAL %q", value)
+		}
+	}
+	return cfg
+}
+
+func LoadApprovalConfig() ApprovalConfig {
+	cfg := ApprovalConfig{Timeout: 48 * time.Hour, SweepInterval: time.Minute}
+
+	if value := os.Getenv("APPROVAL_TIMEOUT"); value != "" {
+		if timeout, err := time.ParseDuration(value); err == nil && timeout > 0 {
+			cfg.Timeout = timeout
+		} else {
+			log.Printf("Ignoring invalid APPROVAL_TIMEOUT %q", value)
+		}
+	}
+	if value := os.Getenv("APPROVAL_SWEEP_INTERVAL"); value != "" {
+		if interval, err := time.ParseDuration(value); err == nil && interval > 0 {
+			cfg.SweepInterval = interval
+		} else {
+			log.Printf("Ignoring invalid APPROVAL_SWEEP_INTERVAL %q", value)
+		}
+	}
+	return cfg
+}
+
+func LoadCheckInConfig() CheckInConfig {
+	cfg := CheckInConfig{OpensBefore: 15 * time.Minute, Grace: 15 * time.Minute, SweepInterval: time.Minute}
+
+	if value := os.Getenv("CHECK_IN_OPENS_BEFORE"); value != "" {
+		if opensBefore, err := time.ParseDuration(value); err == nil && opensBefore >= 0 {
+			cfg.OpensBefore = opensBefore
+		} else {
+			log.Printf("Ignoring invalid CHECK_IN_OPENS_BEFORE %q", value)
+		}
+	}
+	if value := os.Getenv("NO_SHOW_GRACE"); value != "" {
+		if grace, err := time.ParseDuration(value); err == nil && grace > 0 {
+			cfg.Grace = grace
+		} else {
+			log.Printf("Ignoring invalid NO_SHOW_GRACE %q", value)
+		}
+	}
+	if value := os.Getenv("NO_SHOW_SWEEP_INTERVAL"); value != "" {
+		if interval, err := time.ParseDuration(value); err == nil && interval > 0 {
+			cfg.SweepInterval = interval
+		} else {
+			log.Printf("Ignoring invalid NO_SHOW_SWEEP_INTERVAL %q", value)
+		}
+	}
+	return cfg
+}
+
+func LoadOIDCConfig() OIDCConfig {
+	cfg := OIDCConfig{
+		IssuerURL:    strings.TrimSuffix(strings.TrimSpace(os.Getenv("OIDC_ISSUER_URL")), "/"),
+		ClientID:     os.Getenv("OIDC_CLIENT_ID"),
+		ClientSecret: os.Getenv("OIDC_CLIENT_SECRET"),
+		RedirectURL:  os.Getenv("OIDC_REDIRECT_URL"),
+		Scopes:       strings.Fields(os.Getenv("OIDC_SCOPES")),
+		GroupsClaim:  os.Getenv("OIDC_GROUPS_CLAIM"),
+		GroupRoles:   map[string]string{},
+		DefaultRole:  strings.TrimSpace(os.Getenv("OIDC_DEFAULT_ROLE")),
+	}
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"openid", "email", "profile"}
+	}
+	if cfg.GroupsClaim == "" {
+		cfg.GroupsClaim = "groups"
+	}
+	if !domain.IsValidUserRole(cfg.DefaultRole) {
+		if cfg.DefaultRole != "" {
+			log.Printf("Ignoring invalid OIDC_DEFAULT_ROLE %q", cfg.DefaultRole)
+		}
+		cfg.DefaultRole = domain.UserRoleUser
+	}
+
+	// OIDC_GROUP_ROLES is "group=role;group=role".
+	if value := os.Getenv("OIDC_GROUP_ROLES"); value != "" {
+		for _, entry := range strings.Split(value, ";") {
+			group, role, found := strings.Cut(entry, "=")
+			role = strings.TrimSpace(role)
+			if !found || strings.TrimSpace(group) == "" || !domain.IsValidUserRole(role) {
+				log.Printf("Ignoring invalid OIDC_GROUP_ROLES entry %q", entry)
+				continue
+			}
+			cfg.GroupRoles[strings.TrimSpace(group)] = role
+		}
+	}
+	return cfg
+}
+
+func LoadSchedulingRules() domain.SchedulingRules {
+	rules := domain.SchedulingRules{
+		DefaultHours:    domain.WorkingHours{StartMinute: 9 * 60, EndMinute: 18 * 60},
+		BuildingHours:   map[string]domain.WorkingHours{},
+		SlotGranularity: 15 * time.Minute,
+		BufferTime:      0,
+		Location:        time.Local,
+	}
+
+	if value := os.Getenv("WORKING_HOURS"); value != "" {
+		if hours, err := parseWorkingHours(value); err == nil {
+			rules.DefaultHours = hours
+		} else {
+			log.Printf("Ignoring invalid WORKING_HOURS %q: %v", value, err)
+		}
+	}
+
+	if value := os.Getenv("BUILDING_WORKING_HOURS"); value != "" {
+		for _, entry := range strings.Split(value, ";") {
+			building, hoursStr, found := strings.Cut(entry, "=")
+			if !found {
+				log.Printf("Ignoring invalid BUILDING_WORKING_HOURS entry %q", entry)
+				continue
+			}
+			hours, err := parseWorkingHours(hoursStr)
+			if err != nil {
+				log.Printf("Ignoring invalid BUILDING_WORKING_HOURS entry %q: %v", entry, err)
+				continue
+			}
+			rules.BuildingHours[strings.TrimSpace(building)] = hours
+		}
+	}
+
+	if value := os.Getenv("SLOT_GRANULARITY"); value != "" {
+		if granularity, err := time.ParseDuration(value); err == nil && granularity >= time.Minute {
+			rules.SlotGranularity = granularity
+		} else {
+			log.Printf("Ignoring invalid SLOT_GRANULARITY %q", value)
+		}
+	}
+
+	if value := os.Getenv("BOOKING_BUFFER"); value != "" {
+		if buffer, err := time.ParseDuration(value); err == nil && buffer >= 0 {
+			rules.BufferTime = buffer
+		} else {
+			log.Printf("Ignoring invalid BOOKING_BUFFER %q", value)
+		}
+	}
+
+	if value := os.Getenv("SCHEDULE_TIMEZONE"); value != "" {
+		if location, err := time.LoadLocation(value); err == nil {
+			rules.Location = location
+		} else {
+			log.Printf("Ignoring invalid SCHEDULE_TIMEZONE %q: %v", value, err)
+		}
+	}
+
+	return rules
+}
+
+// LoadBookingPolicy reads the limits applied to bookings by users without
+// the bypass permission. Setting a limit to 0 switches it off.
+func LoadBookingPolicy() domain.BookingPolicy {
+	policy := domain.BookingPolicy{
+		MinDuration: 15 * time.Minute,
+		MaxDuration: 8 * time.Hour,
+		MaxAdvance:  90 * 24 * time.Hour,
+		Alignment:   15 * time.Minute,
+		Location:    time.Local,
+	}
+
+	durations := []struct {
+		env    string
+		target *time.Duration
+	}{
+		{"BOOKING_MIN_DURATION", &policy.MinDuration},
+		{"BOOKING_MAX_DURATION", &policy.MaxDuration},
+		{"BOOKING_MAX_ADVANCE", &policy.MaxAdvance},
+		{"BOOKING_ALIGNMENT", &policy.Alignment},
+	}
+	for _, d := range durations {
+		value := os.Getenv(d.env)
+		if value == "" {
+			continue
+		}
+		if duration, err := time.ParseDuration(value); err == nil && duration >= 0 {
+			*d.target = duration
+		} else {
+			log.Printf("Ignoring invalid %s %q", d.env, value)
+		}
+	}
+
+	if value := os.Getenv("BOOKING_MAX_ACTIVE"); value != "" {
+		if limit, err := strconv.Atoi(value); err == nil && limit >= 0 {
+			policy.MaxActiveBookings = limit
+		} else {
+			log.Printf("Ignoring invalid BOOKING_MAX_ACTIVE %q", value)
+		}
+	}
+
+	// Alignment is counted from local midnight, so it follows the schedule's
+	// timezone; LoadSchedulingRules already reports a bad one.
+	if value := os.Getenv("SCHEDULE_TIMEZONE"); value != "" {
+		if location, err := time.LoadLocation(value); err == nil {
+			policy.Location = location
+		}
+	}
+
+	return policy
+}
+
+func parseWorkingHours(value string) (domain.WorkingHours, error) {
+	startStr, endStr, found := strings.Cut(strings.TrimSpace(value), "-")
+	if !found {
+		return domain.WorkingHours{}, fmt.Errorf("expected HH:MM-HH:MM")
+	}
+
+	start, err := time.Parse("15:04", strings.TrimSpace(startStr))
+	if err != nil {
+		return domain.WorkingHours{}, err
+	}
+	end, err := time.Parse("15:04", strings.TrimSpace(endStr))
+	if err != nil {
+		return domain.WorkingHours{}, err
+	}
+
+	hours := domain.WorkingHours{
+		StartMinute: start.Hour()*60 + start.Minute(),
+		EndMinute:   end.Hour()*60 + end.Minute(),
+	}
+	if hours.EndMinute <= hours.StartMinute {
+		return domain.WorkingHours{}, fmt.Errorf("end must be after start")
 	}
+	return hours, nil
 }
@@ -0,0 +1,13 @@
+package domain
+
+// RoomBlock takes a room out of service for a window, e.g. for cleaning or
+// AV repairs, without creating a booking.
+type RoomBlock struct {
+	ID        string `json:"id"`
+	RoomID    string `json:"room_id"`
+	StartTime int64  `json:"start_time"`
+	EndTime   int64  `json:"end_time"`
+	Reason    string `json:"reason"`
+	CreatedBy string `json:"created_by"`
+	CreatedAt int64  `json:"created_at"`
+}
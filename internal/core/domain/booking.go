@@ -1,15 +1,107 @@
 package domain
 
+import "time"
+
+const (
+	BookingStatusConfirmed = "confirmed"
+	BookingStatusCancelled = "cancelled"
+	BookingStatusCompleted = "completed"
+	BookingStatusNoShow    = "no_show"
+	// Bookings on rooms that require approval start out pending and hold
+	// their slot until they are approved, rejected or left unanswered.
+	BookingStatusPending  = "pending"
+	BookingStatusRejected = "rejected"
+	BookingStatusExpired  = "expired"
+)
+
 type Booking struct {
-	ID        string `json:"id"`
-	UserID    string `json:"user_id"`
-	RoomID    string `json:"room_id"`
-	StartTime int64  `json:"start_time"`
-	EndTime   int64  `json:"end_time"`
-	Purpose   string `json:"purpose"`
-	Status    string `json:"status"`
-	CreatedAt int64  `json:"created_at"`
-	UpdatedAt int64  `json:"updated_at"`
+	ID                 string `json:"id"`
+	UserID             string `json:"user_id"`
+	RoomID             string `json:"room_id"`
+	StartTime          int64  `json:"start_time"`
+	EndTime            int64  `json:"end_time"`
+	Purpose            string `json:"purpose"`
+	Status             string `json:"status"`
+	SeriesID           string `json:"series_id,omitempty"`
+	GroupID            string `json:"group_id,omitempty"`
+	CancelledAt        int64  `json:"cancelled_at,omitempty"`
+	CancelledBy        string `json:"cancelled_by,omitempty"`
+	CancellationReason string `json:"cancellation_reason,omitempty"`
+	ApprovalExpiresAt  int64  `json:"approval_expires_at,omitempty"`
+	ReviewedBy         string `json:"reviewed_by,omitempty"`
+	ReviewedAt         int64  `json:"reviewed_at,omitempty"`
+	ReviewNote         string `json:"review_note,omitempty"`
+	CheckedInAt        int64  `json:"checked_in_at,omitempty"`
+	ReleasedAt         int64  `json:"released_at,omitempty"`
+	Sequence           int    `json:"sequence"`
+	CreatedAt          int64  `json:"created_at"`
+	UpdatedAt          int64  `json:"updated_at"`
+}
+
+// BookingUpdate holds the fields a caller wants to change; nil fields keep
+// their current value.
+type BookingUpdate struct {
+	RoomID    *string
+	StartTime *int64
+	EndTime   *int64
+	Purpose   *string
+}
+
+func IsValidBookingStatus(status string) bool {
+	switch status {
+	case BookingStatusConfirmed, BookingStatusCancelled, BookingStatusCompleted, BookingStatusNoShow,
+		BookingStatusPending, BookingStatusRejected, BookingStatusExpired:
+		return true
+	}
+	return false
+}
+
+// OccupiesRoom reports whether the booking still holds its time slot.
+// Cancelled, rejected and expired bookings and no-shows release the room.
+func (b Booking) OccupiesRoom() bool {
+	switch b.Status {
+	case BookingStatusCancelled, BookingStatusNoShow, BookingStatusRejected, BookingStatusExpired:
+		return false
+	}
+	return true
+}
+
+// IsActive reports whether the booking can still be changed or cancelled:
+// it is confirmed or waiting for approval.
+func (b Booking) IsActive() bool {
+	return b.Status == BookingStatusConfirmed || b.Status == BookingStatusPending
+}
+
+// IsApprovalOverdue reports whether a pending booking went unanswered past
+// its deadline.
+func (b Booking) IsApprovalOverdue(now int64) bool {
+	return b.Status == BookingStatusPending && b.ApprovalExpiresAt <= now
+}
+
+// RequestApproval marks the booking pending on room's approvers. Nobody can
+// answer once the meeting has started, so the request never outlives it.
+func (b *Booking) RequestApproval(timeout time.Duration, now int64) {
+	b.Status = BookingStatusPending
+	b.ApprovalExpiresAt = now + int64(timeout.Seconds())
+	if b.ApprovalExpiresAt > b.StartTime {
+		b.ApprovalExpiresAt = b.StartTime
+	}
+}
+
+// CheckInDeadline is when an unclaimed booking becomes a no-show: grace
+// after it starts, or when it ends if that comes first.
+func (b Booking) CheckInDeadline(grace time.Duration) int64 {
+	deadline := b.StartTime + int64(grace.Seconds())
+	if deadline > b.EndTime {
+		return b.EndTime
+	}
+	return deadline
+}
+
+// IsNoShow reports whether nobody checked in to a confirmed booking before
+// its check-in deadline.
+func (b Booking) IsNoShow(grace time.Duration, now int64) bool {
+	return b.Status == BookingStatusConfirmed && b.CheckedInAt == 0 && b.CheckInDeadline(grace) <= now
 }
 
 type TimeSlot struct {
@@ -18,6 +110,13 @@ type TimeSlot struct {
 	Duration  int
 }
 
+type AvailabilityResult struct {
+	Available           bool
+	ConflictingBookings []Booking
+	ConflictingBlocks   []RoomBlock
+	SuggestedSlots      []TimeSlot
+}
+
 type BookingWithDetails struct {
 	Booking
 	UserName   string
@@ -33,6 +132,8 @@ type ScheduleSlot struct {
 	BookingID *string `json:"bookingId,omitempty"`
 	UserName  string  `json:"userName,omitempty"`
 	Purpose   string  `json:"purpose,omitempty"`
+	BlockID   *string `json:"blockId,omitempty"`
+	Reason    string  `json:"reason,omitempty"`
 }
 
 type RoomScheduleResponse struct {
@@ -41,4 +142,5 @@ type RoomScheduleResponse struct {
 	RoomNumber int            `json:"roomNumber"`
 	Date       string         `json:"date"`
 	Bookings   []ScheduleSlot `json:"bookings"`
+	Blocks     []ScheduleSlot `json:"blocks"`
 }
@@ -0,0 +1,16 @@
+package domain
+
+// CalendarToken grants read access to iCalendar feeds through a secret in
+// the feed URL, since calendar clients cannot send a JWT. Only the hash of the
+// secret is stored.
+type CalendarToken struct {
+	ID        string
+	UserID    string
+	TokenHash string
+	CreatedAt int64
+}
+
+type CalendarFeed struct {
+	Name     string
+	Bookings []BookingWithDetails
+}
@@ -9,6 +9,32 @@ var (
 	ErrConflict     = errors.New("resource conflict")
 	ErrInternal     = errors.New("internal server error")
 
-	ErrRoomUnavailable  = errors.New("room not available for the selected time slot")
-	ErrTimeRangeInvalid = errors.New("invalid start or end time for booking")
+	ErrRoomUnavailable   = errors.New("room not available for the selected time slot")
+	ErrRoomNotBookable   = errors.New("room is not open for bookings")
+	ErrRoomBlocked       = errors.New("room is blocked for maintenance during the selected time")
+	ErrRoomHasBookings   = errors.New("room has upcoming bookings")
+	ErrTimeRangeInvalid  = errors.New("invalid start or end time for booking")
+	ErrBookingTooLong    = errors.New("booking is too long to be stored")
+	ErrBookingNotActive  = errors.New("booking is no longer active")
+	ErrNotInSeries       = errors.New("booking is not part of a recurring series")
+	ErrInvalidGroup      = errors.New("a group booking needs between 2 and 10 distinct rooms")
+	ErrGroupMember       = errors.New("booking is part of a group booking, change it through /api/bookings/group/{id}")
+	ErrBookingNotPending = errors.New("booking is not awaiting approval")
+	ErrNotApprover       = errors.New("only the room's approvers can decide on this booking")
+	ErrNotBookingOwner   = errors.New("only the booking's owner can check in")
+	ErrCheckInNotOpen    = errors.New("check-in for this booking has not opened yet")
+	ErrCheckInClosed     = errors.New("check-in for this booking has closed")
+	ErrAlreadyCheckedIn  = errors.New("booking is already checked in")
+
+	ErrRoomAvailable      = errors.New("room is available for the selected time slot, book it instead")
+	ErrWaitlistNotOffered = errors.New("waitlist entry has no open offer")
+
+	ErrUserDisabled      = errors.New("user account is disabled")
+	ErrIncorrectPassword = errors.New("current password is incorrect")
+	ErrInvalidResetToken = errors.New("password reset token is invalid or expired")
+	ErrInvalidRefresh    = errors.New("refresh token is invalid or expired")
+	ErrInvalidOIDCState  = errors.New("single sign-on request is invalid or expired")
+	ErrOIDCLoginFailed   = errors.New("single sign-on login failed")
+
+	ErrInvalidRecurrence = errors.New("invalid or unbounded recurrence rule")
 )
@@ -0,0 +1,33 @@
+package domain
+
+// MaxGroupRooms bounds how many rooms one group booking can hold.
+const MaxGroupRooms = 10
+
+// BookingGroup books several rooms for the same window at once, e.g. for a
+// hybrid meeting or a training split across rooms. Its bookings share ID as
+// their GroupID and are written, rescheduled and cancelled together.
+type BookingGroup struct {
+	ID        string
+	UserID    string
+	RoomIDs   []string
+	StartTime int64
+	EndTime   int64
+	Purpose   string
+}
+
+// RoomConflict names a room of a group that cannot be booked and why:
+// Reason is the error that room ran into.
+type RoomConflict struct {
+	RoomID                string
+	Reason                error
+	ConflictingBookingIDs []string
+	ConflictingBlockIDs   []string
+}
+
+// GroupResult reports a group operation. When it was refused nothing was
+// written and Conflicts names the rooms that were in the way.
+type GroupResult struct {
+	GroupID   string
+	Bookings  []Booking
+	Conflicts []RoomConflict
+}
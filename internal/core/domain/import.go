@@ -0,0 +1,30 @@
+package domain
+
+const (
+	ImportStatusCreated          = "created"
+	ImportStatusConflict         = "conflict"
+	ImportStatusUnknownRoom      = "unknown_room"
+	ImportStatusUnknownOrganizer = "unknown_organizer"
+	ImportStatusInvalid          = "invalid"
+	ImportStatusSkipped          = "skipped"
+)
+
+// ImportResult is the outcome for one imported event. In a dry run a
+// "created" result means the booking would have been created.
+type ImportResult struct {
+	UID       string
+	Summary   string
+	StartTime int64
+	EndTime   int64
+	RoomID    string
+	UserID    string
+	BookingID string
+	Status    string
+	Message   string
+}
+
+type ImportReport struct {
+	DryRun  bool
+	Counts  map[string]int
+	Results []ImportResult
+}
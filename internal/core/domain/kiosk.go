@@ -0,0 +1,12 @@
+package domain
+
+// KioskToken lets the device mounted at a room check in its bookings
+// without a signed-in user. Only the hash of the secret is stored.
+type KioskToken struct {
+	ID        string
+	RoomID    string
+	Name      string
+	TokenHash string
+	CreatedBy string
+	CreatedAt int64
+}
@@ -0,0 +1,10 @@
+package domain
+
+// Notification is a message for a single user, delivered by a ports.Notifier.
+type Notification struct {
+	UserID  string
+	Email   string
+	Name    string
+	Subject string
+	Message string
+}
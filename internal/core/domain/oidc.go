@@ -0,0 +1,39 @@
+package domain
+
+// OIDCIdentity is what the identity provider vouched for after a successful
+// login, taken from the validated ID token.
+type OIDCIdentity struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Groups        []string
+}
+
+// OIDCLoginState is kept between sending the user to the identity provider
+// and the provider redirecting back. It binds the callback to the request
+// (State), the ID token to the request (Nonce) and the code to us (the PKCE
+// CodeVerifier). Each state can be used once.
+type OIDCLoginState struct {
+	State        string
+	Nonce        string
+	CodeVerifier string
+	ExpiresAt    int64
+	CreatedAt    int64
+}
+
+// UserIdentity links an identity provider account to a user, so the user
+// keeps their account when their email changes at the provider.
+type UserIdentity struct {
+	Issuer    string
+	Subject   string
+	UserID    string
+	CreatedAt int64
+}
+
+// HasPassword reports whether the user can sign in with a password; users
+// created through single sign-on have none.
+func (u User) HasPassword() bool {
+	return u.Password != ""
+}
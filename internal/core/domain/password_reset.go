@@ -0,0 +1,17 @@
+package domain
+
+// PasswordResetToken lets a user who forgot their password set a new one. The
+// secret is mailed to the user and only its hash is stored; a token works
+// once and only until ExpiresAt.
+type PasswordResetToken struct {
+	ID        string
+	UserID    string
+	TokenHash string
+	ExpiresAt int64
+	UsedAt    int64
+	CreatedAt int64
+}
+
+func (t PasswordResetToken) IsUsable(now int64) bool {
+	return t.UsedAt == 0 && now < t.ExpiresAt
+}
@@ -0,0 +1,50 @@
+package domain
+
+// Permission names an action that only some roles may perform. Anything
+// without a permission is open to every signed-in user.
+type Permission string
+
+const (
+	PermRoomsWrite           Permission = "rooms:write"
+	PermBookingsViewAny      Permission = "bookings:view_any"
+	PermBookingsManageAny    Permission = "bookings:manage_any"
+	PermBookingsImport       Permission = "bookings:import"
+	PermBookingsApprove      Permission = "bookings:approve"
+	PermBookingsBypassPolicy Permission = "bookings:bypass_policy"
+	PermUsersAdmin           Permission = "users:admin"
+)
+
+// rolePermissions is the single policy both the HTTP server and the Lambda
+// authorizers enforce.
+var rolePermissions = map[string][]Permission{
+	UserRoleAdmin: {
+		PermRoomsWrite,
+		PermBookingsViewAny,
+		PermBookingsManageAny,
+		PermBookingsImport,
+		PermBookingsApprove,
+		PermBookingsBypassPolicy,
+		PermUsersAdmin,
+	},
+	UserRoleFacilities: {
+		PermRoomsWrite,
+		PermBookingsViewAny,
+		PermBookingsApprove,
+	},
+	UserRoleReceptionist: {
+		PermBookingsViewAny,
+		PermBookingsManageAny,
+	},
+	UserRoleUser: {},
+}
+
+// HasPermission reports whether role grants permission. Unknown roles have
+// no permissions.
+func HasPermission(role string, permission Permission) bool {
+	for _, granted := range rolePermissions[role] {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
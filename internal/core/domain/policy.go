@@ -0,0 +1,182 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	PolicyRuleMinDuration = "min_duration"
+	PolicyRuleMaxDuration = "max_duration"
+	PolicyRuleMaxAdvance  = "max_advance"
+	PolicyRulePastStart   = "past_start"
+	PolicyRuleAlignment   = "alignment"
+	PolicyRuleActiveQuota = "active_quota"
+)
+
+// BookingPolicy limits the shape of bookings users can make. A zero field
+// switches its rule off; starting in the past is always refused.
+type BookingPolicy struct {
+	MinDuration time.Duration
+	MaxDuration time.Duration
+	// MaxAdvance is how far ahead of now a booking may start.
+	MaxAdvance time.Duration
+	// Alignment makes start and end fall on multiples of it counted from
+	// midnight in Location, e.g. quarter past but not ten past.
+	Alignment time.Duration
+	// MaxActiveBookings caps the confirmed and pending bookings a user may
+	// hold that have not ended yet, across all rooms.
+	MaxActiveBookings int
+	Location          *time.Location
+}
+
+// RoomPolicy overrides the global policy for one room, in seconds; zero
+// fields keep the global value.
+type RoomPolicy struct {
+	MinDurationSeconds int64 `json:"minDurationSeconds,omitempty"`
+	MaxDurationSeconds int64 `json:"maxDurationSeconds,omitempty"`
+	MaxAdvanceSeconds  int64 `json:"maxAdvanceSeconds,omitempty"`
+	AlignmentSeconds   int64 `json:"alignmentSeconds,omitempty"`
+}
+
+func (p RoomPolicy) IsZero() bool {
+	return p == RoomPolicy{}
+}
+
+// IsValid rejects negative limits and a minimum above the maximum.
+func (p RoomPolicy) IsValid() bool {
+	if p.MinDurationSeconds < 0 || p.MaxDurationSeconds < 0 || p.MaxAdvanceSeconds < 0 || p.AlignmentSeconds < 0 {
+		return false
+	}
+	return p.MaxDurationSeconds == 0 || p.MinDurationSeconds <= p.MaxDurationSeconds
+}
+
+// ForRoom returns the policy with room's overrides applied.
+func (p BookingPolicy) ForRoom(room *Room) BookingPolicy {
+	if room == nil {
+		return p
+	}
+	override := room.BookingPolicy
+	if override.MinDurationSeconds > 0 {
+		p.MinDuration = time.Duration(override.MinDurationSeconds) * time.Second
+	}
+	if override.MaxDurationSeconds > 0 {
+		p.MaxDuration = time.Duration(override.MaxDurationSeconds) * time.Second
+	}
+	if override.MaxAdvanceSeconds > 0 {
+		p.MaxAdvance = time.Duration(override.MaxAdvanceSeconds) * time.Second
+	}
+	if override.AlignmentSeconds > 0 {
+		p.Alignment = time.Duration(override.AlignmentSeconds) * time.Second
+	}
+	return p
+}
+
+// CheckWindow lists the rules a booking from start to end made at now
+// breaks. The slot in progress stays bookable, so with Alignment set a start
+// up to one step in the past is accepted.
+func (p BookingPolicy) CheckWindow(start, end, now int64) []PolicyViolation {
+	var violations []PolicyViolation
+	duration := time.Duration(end-start) * time.Second
+
+	if p.MinDuration > 0 && duration < p.MinDuration {
+		violations = append(violations, PolicyViolation{
+			Rule:    PolicyRuleMinDuration,
+			Message: fmt.Sprintf("bookings must last at least %s", formatPolicyDuration(p.MinDuration)),
+		})
+	}
+	if p.MaxDuration > 0 && duration > p.MaxDuration {
+		violations = append(violations, PolicyViolation{
+			Rule:    PolicyRuleMaxDuration,
+			Message: fmt.Sprintf("bookings may last at most %s", formatPolicyDuration(p.MaxDuration)),
+		})
+	}
+	if start < now-int64(p.Alignment.Seconds()) {
+		violations = append(violations, PolicyViolation{
+			Rule:    PolicyRulePastStart,
+			Message: "bookings cannot start in the past",
+		})
+	}
+	if p.MaxAdvance > 0 && start > now+int64(p.MaxAdvance.Seconds()) {
+		violations = append(violations, PolicyViolation{
+			Rule:    PolicyRuleMaxAdvance,
+			Message: fmt.Sprintf("bookings can be made at most %s in advance", formatPolicyDuration(p.MaxAdvance)),
+		})
+	}
+	if p.Alignment > 0 && !(p.isAligned(start) && p.isAligned(end)) {
+		violations = append(violations, PolicyViolation{
+			Rule:    PolicyRuleAlignment,
+			Message: fmt.Sprintf("bookings must start and end on %s boundaries", formatPolicyDuration(p.Alignment)),
+		})
+	}
+	return violations
+}
+
+// CheckQuota reports a violation when adding bookings to the active ones a
+// user already holds goes over MaxActiveBookings.
+func (p BookingPolicy) CheckQuota(active, adding int) []PolicyViolation {
+	if p.MaxActiveBookings <= 0 || active+adding <= p.MaxActiveBookings {
+		return nil
+	}
+	return []PolicyViolation{{
+		Rule:    PolicyRuleActiveQuota,
+		Message: fmt.Sprintf("users may hold at most %d upcoming bookings", p.MaxActiveBookings),
+	}}
+}
+
+func (p BookingPolicy) isAligned(timestamp int64) bool {
+	location := p.Location
+	if location == nil {
+		location = time.Local
+	}
+	t := time.Unix(timestamp, 0).In(location)
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, location)
+	return t.Sub(midnight)%p.Alignment == 0
+}
+
+// formatPolicyDuration drops the zero units time.Duration prints, so 15m
+// reads "15m" rather than "15m0s".
+func formatPolicyDuration(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+type PolicyViolation struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// PolicyError is returned instead of a sentinel error when a booking breaks
+// the booking policy, so callers can report every rule it broke.
+type PolicyError struct {
+	Violations []PolicyViolation
+}
+
+func (e *PolicyError) Error() string {
+	messages := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		messages = append(messages, v.Message)
+	}
+	return "booking violates the booking policy: " + strings.Join(messages, "; ")
+}
+
+// NewPolicyError returns nil when there are no violations.
+func NewPolicyError(violations []PolicyViolation) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	return &PolicyError{Violations: violations}
+}
+
+// AsPolicyError unwraps err into a PolicyError when it is one.
+func AsPolicyError(err error) (*PolicyError, bool) {
+	policyErr, ok := err.(*PolicyError)
+	return policyErr, ok
+}
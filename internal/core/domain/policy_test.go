@@ -0,0 +1,158 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func violatedRules(violations []PolicyViolation) []string {
+	var rules []string
+	for _, v := range violations {
+		rules = append(rules, v.Rule)
+	}
+	return rules
+}
+
+func TestBookingPolicyCheckWindow(t *testing.T) {
+	location := time.UTC
+	now := time.Date(2030, 3, 4, 10, 7, 0, 0, location).Unix()
+	at := func(hour, minute int) int64 {
+		return time.Date(2030, 3, 4, hour, minute, 0, 0, location).Unix()
+	}
+	policy := BookingPolicy{
+		MinDuration: 15 * time.Minute,
+		MaxDuration: 4 * time.Hour,
+		MaxAdvance:  14 * 24 * time.Hour,
+		Alignment:   15 * time.Minute,
+		Location:    location,
+	}
+
+	tests := []struct {
+		name       string
+		policy     BookingPolicy
+		start, end int64
+		want       []string
+	}{
+		{name: "within every limit", policy: policy, start: at(11, 0), end: at(12, 0)},
+		{name: "exactly the minimum", policy: policy, start: at(11, 0), end: at(11, 15)},
+		{name: "shorter than the minimum", policy: BookingPolicy{MinDuration: 15 * time.Minute}, start: at(11, 0), end: at(11, 10), want: []string{PolicyRuleMinDuration}},
+		{name: "exactly the maximum", policy: policy, start: at(11, 0), end: at(15, 0)},
+		{name: "longer than the maximum", policy: policy, start: at(11, 0), end: at(15, 15), want: []string{PolicyRuleMaxDuration}},
+		{name: "inside the advance window", policy: policy, start: at(10, 15) + 14*86400 - 15*60, end: at(11, 0) + 14*86400},
+		{name: "beyond the advance window", policy: policy, start: at(11, 0) + 14*86400, end: at(12, 0) + 14*86400, want: []string{PolicyRuleMaxAdvance}},
+		{name: "in the past", policy: policy, start: at(9, 0), end: at(10, 0), want: []string{PolicyRulePastStart}},
+		{name: "slot in progress", policy: policy, start: at(10, 0), end: at(10, 30)},
+		{name: "in the past without alignment", policy: BookingPolicy{}, start: at(10, 0), end: at(10, 30), want: []string{PolicyRulePastStart}},
+		{name: "off the alignment grid", policy: policy, start: at(11, 10), end: at(12, 10), want: []string{PolicyRuleAlignment}},
+		{name: "end off the alignment grid", policy: policy, start: at(11, 0), end: at(11, 50), want: []string{PolicyRuleAlignment}},
+		{name: "every rule off", policy: BookingPolicy{}, start: at(11, 3), end: at(11, 4) + 400*86400},
+		{name: "several rules at once", policy: policy, start: at(9, 5), end: at(9, 10), want: []string{PolicyRuleMinDuration, PolicyRulePastStart, PolicyRuleAlignment}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := violatedRules(tt.policy.CheckWindow(tt.start, tt.end, now))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CheckWindow() broke %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBookingPolicyCheckWindowAlignsInItsLocation(t *testing.T) {
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	policy := BookingPolicy{Alignment: time.Hour, Location: kolkata}
+	start := time.Date(2030, 3, 4, 11, 0, 0, 0, kolkata).Unix()
+
+	// 11:00 in Kolkata is 05:30 UTC, so an hourly grid counted from UTC
+	// midnight would refuse it.
+	if got := violatedRules(policy.CheckWindow(start, start+3600, start-86400)); got != nil {
+		t.Errorf("CheckWindow() broke %v, want nothing", got)
+	}
+}
+
+func TestBookingPolicyCheckQuota(t *testing.T) {
+	tests := []struct {
+		name           string
+		max            int
+		active, adding int
+		want           []string
+	}{
+		{name: "no quota", max: 0, active: 50, adding: 1},
+		{name: "below the quota", max: 3, active: 1, adding: 1},
+		{name: "reaching the quota", max: 3, active: 2, adding: 1},
+		{name: "over the quota", max: 3, active: 3, adding: 1, want: []string{PolicyRuleActiveQuota}},
+		{name: "a series going over the quota", max: 3, active: 1, adding: 5, want: []string{PolicyRuleActiveQuota}},
+		{name: "already over but adding nothing", max: 3, active: 4, adding: 0, want: []string{PolicyRuleActiveQuota}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := violatedRules(BookingPolicy{MaxActiveBookings: tt.max}.CheckQuota(tt.active, tt.adding))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CheckQuota(%d, %d) broke %v, want %v", tt.active, tt.adding, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBookingPolicyForRoom(t *testing.T) {
+	global := BookingPolicy{
+		MinDuration:       15 * time.Minute,
+		MaxDuration:       4 * time.Hour,
+		MaxAdvance:        14 * 24 * time.Hour,
+		Alignment:         15 * time.Minute,
+		MaxActiveBookings: 5,
+		Location:          time.UTC,
+	}
+
+	tests := []struct {
+		name string
+		room *Room
+		want BookingPolicy
+	}{
+		{name: "no room", room: nil, want: global},
+		{name: "room without overrides", room: &Room{}, want: global},
+		{
+			name: "every override",
+			room: &Room{BookingPolicy: RoomPolicy{MinDurationSeconds: 3600, MaxDurationSeconds: 8 * 3600, MaxAdvanceSeconds: 90 * 86400, AlignmentSeconds: 1800}},
+			want: BookingPolicy{MinDuration: time.Hour, MaxDuration: 8 * time.Hour, MaxAdvance: 90 * 24 * time.Hour, Alignment: 30 * time.Minute, MaxActiveBookings: 5, Location: time.UTC},
+		},
+		{
+			name: "one override keeps the rest",
+			room: &Room{BookingPolicy: RoomPolicy{MaxDurationSeconds: 8 * 3600}},
+			want: BookingPolicy{MinDuration: 15 * time.Minute, MaxDuration: 8 * time.Hour, MaxAdvance: 14 * 24 * time.Hour, Alignment: 15 * time.Minute, MaxActiveBookings: 5, Location: time.UTC},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := global.ForRoom(tt.room); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ForRoom() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoomPolicyIsValid(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy RoomPolicy
+		want   bool
+	}{
+		{name: "empty", policy: RoomPolicy{}, want: true},
+		{name: "minimum below maximum", policy: RoomPolicy{MinDurationSeconds: 900, MaxDurationSeconds: 3600}, want: true},
+		{name: "minimum without maximum", policy: RoomPolicy{MinDurationSeconds: 900}, want: true},
+		{name: "minimum above maximum", policy: RoomPolicy{MinDurationSeconds: 7200, MaxDurationSeconds: 3600}, want: false},
+		{name: "negative advance", policy: RoomPolicy{MaxAdvanceSeconds: -1}, want: false},
+		{name: "negative alignment", policy: RoomPolicy{AlignmentSeconds: -900}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.IsValid(); got != tt.want {
+				t.Errorf("IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
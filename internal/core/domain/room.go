@@ -1,5 +1,13 @@
 package domain
 
+import "strings"
+
+const (
+	RoomStatusAvailable   = "Available"
+	RoomStatusMaintenance = "Maintenance"
+	RoomStatusRetired     = "Retired"
+)
+
 type Room struct {
 	ID          string   `json:"id"`
 	Name        string   `json:"name"`
@@ -10,6 +18,95 @@ type Room struct {
 	Status      string   `json:"status"`
 	Location    string   `json:"location"`
 	Description string   `json:"description,omitempty"`
-	CreatedAt   int64    `json:"created_at"`
-	UpdatedAt   int64    `json:"updated_at"`
+	// RequiresApproval makes new bookings wait as pending until one of the
+	// Approvers (user IDs) or someone allowed to approve any booking decides.
+	RequiresApproval bool     `json:"requiresApproval"`
+	Approvers        []string `json:"approvers,omitempty"`
+	// BookingPolicy overrides the global booking policy in this room.
+	BookingPolicy RoomPolicy `json:"bookingPolicy"`
+	DeletedAt     int64      `json:"deleted_at,omitempty"`
+	CreatedAt     int64      `json:"created_at"`
+	UpdatedAt     int64      `json:"updated_at"`
+}
+
+// RoomDeletion controls how DeleteRoomByID treats a room's upcoming bookings.
+// Without Force the deletion is refused while any exist; with it they are
+// cancelled with Reason and their owners notified.
+type RoomDeletion struct {
+	Force     bool
+	DeletedBy string
+	Reason    string
+}
+
+// RoomUpdate holds the room fields a caller wants to change; nil fields keep
+// their current value.
+type RoomUpdate struct {
+	Name             *string
+	RoomNumber       *int
+	Capacity         *int
+	Floor            *int
+	Amenities        *[]string
+	Status           *string
+	Location         *string
+	Description      *string
+	RequiresApproval *bool
+	Approvers        *[]string
+	BookingPolicy    *RoomPolicy
+}
+
+// NormalizeRoomStatus returns the canonical spelling of a room status given
+// in any case, and false for unknown statuses.
+func NormalizeRoomStatus(status string) (string, bool) {
+	for _, known := range []string{RoomStatusAvailable, RoomStatusMaintenance, RoomStatusRetired} {
+		if strings.EqualFold(status, known) {
+			return known, true
+		}
+	}
+	return "", false
+}
+
+// AcceptsBookings reports whether new bookings can be made in the room. Rooms
+// stored before statuses were enforced may have an empty or lowercase status.
+func (r Room) AcceptsBookings() bool {
+	if r.IsDeleted() {
+		return false
+	}
+	return r.Status == "" || strings.EqualFold(r.Status, RoomStatusAvailable)
+}
+
+// CanApprove reports whether user may approve or reject bookings in the room.
+func (r Room) CanApprove(user *User) bool {
+	if HasPermission(user.Role, PermBookingsApprove) {
+		return true
+	}
+	for _, approverID := range r.Approvers {
+		if approverID == user.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDeleted reports whether the room was soft-deleted. Deleted rooms are kept
+// so past bookings can still be reported against them.
+func (r Room) IsDeleted() bool {
+	return r.DeletedAt != 0
+}
+
+type RoomSearchFilter struct {
+	MinCapacity int
+	MaxCapacity int
+	Floor       *int
+	Amenities   []string
+	Status      string
+	StartTime   *int64
+	EndTime     *int64
+	Available   *bool
+}
+
+type RoomAvailability struct {
+	Room
+	IsAvailable      bool
+	NextAvailableAt  *int64
+	CurrentBookingID *string
 }
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+type WorkingHours struct {
+	StartMinute int
+	EndMinute   int
+}
+
+type SchedulingRules struct {
+	DefaultHours    WorkingHours
+	BuildingHours   map[string]WorkingHours
+	SlotGranularity time.Duration
+	BufferTime      time.Duration
+	Location        *time.Location
+}
+
+func (r SchedulingRules) HoursFor(roomLocation string) WorkingHours {
+	building := strings.TrimSpace(strings.Split(roomLocation, ",")[0])
+	for name, hours := range r.BuildingHours {
+		if strings.EqualFold(name, building) {
+			return hours
+		}
+	}
+	return r.DefaultHours
+}
+
+func (r SchedulingRules) TimeLocation() *time.Location {
+	if r.Location == nil {
+		return time.Local
+	}
+	return r.Location
+}
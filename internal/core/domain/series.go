@@ -0,0 +1,43 @@
+package domain
+
+const (
+	SeriesScopeThis      = "this"
+	SeriesScopeFollowing = "following"
+	SeriesScopeAll       = "all"
+)
+
+// BookingSeries is a recurring booking. StartTime and EndTime describe the
+// first occurrence; the others follow RRule in Timezone, skipping ExDates.
+type BookingSeries struct {
+	ID        string
+	UserID    string
+	RoomID    string
+	Purpose   string
+	RRule     string
+	Timezone  string
+	StartTime int64
+	EndTime   int64
+	ExDates   []int64
+	Status    string
+	CreatedAt int64
+	UpdatedAt int64
+}
+
+type OccurrenceConflict struct {
+	StartTime             int64
+	EndTime               int64
+	ConflictingBookingIDs []string
+	ConflictingBlockIDs   []string
+}
+
+// SeriesResult reports which occurrences of a series operation were written
+// and which were left out because the room was taken.
+type SeriesResult struct {
+	Series    *BookingSeries
+	Bookings  []Booking
+	Conflicts []OccurrenceConflict
+}
+
+func IsValidSeriesScope(scope string) bool {
+	return scope == SeriesScopeThis || scope == SeriesScopeFollowing || scope == SeriesScopeAll
+}
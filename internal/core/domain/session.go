@@ -0,0 +1,35 @@
+package domain
+
+// RefreshToken belongs to a login session. Every refresh consumes the token
+// and issues the next one in the same session, so presenting a used token
+// means it was stolen and the whole session is revoked.
+type RefreshToken struct {
+	ID        string
+	UserID    string
+	SessionID string
+	TokenHash string
+	ExpiresAt int64
+	UsedAt    int64
+	CreatedAt int64
+}
+
+func (t RefreshToken) IsUsable(now int64) bool {
+	return t.UsedAt == 0 && now < t.ExpiresAt
+}
+
+// AuthTokens is what a login or refresh hands back to the client.
+// ExpiresIn is the access token lifetime in seconds.
+type AuthTokens struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    int64
+}
+
+// AccessTokenClaims are the parts of a validated access token needed to
+// revoke it on logout.
+type AccessTokenClaims struct {
+	UserID    string
+	TokenID   string
+	SessionID string
+	ExpiresAt int64
+}
@@ -1,11 +1,70 @@
 package domain
 
+const (
+	UserRoleAdmin        = "admin"
+	UserRoleFacilities   = "facilities"
+	UserRoleReceptionist = "receptionist"
+	UserRoleUser         = "user"
+)
+
+// UserRoles lists every role from most to least privileged.
+var UserRoles = []string{UserRoleAdmin, UserRoleFacilities, UserRoleReceptionist, UserRoleUser}
+
+const (
+	UserStatusActive   = "active"
+	UserStatusDisabled = "disabled"
+)
+
+// MinPasswordLength is the shortest password accepted when one is changed.
+const MinPasswordLength = 6
+
+// UnknownUserName is shown for bookings whose owner can no longer be found,
+// such as users hard-deleted before deactivation existed.
+const UnknownUserName = "Unknown user"
+
 type User struct {
-	ID        string `json:"id"`
-	Name      string `json:"name"`
-	Email     string `json:"email"`
-	Password  string `json:"-"`
-	Role      string `json:"role"`
-	CreatedAt int64  `json:"created_at"`
-	UpdatedAt int64  `json:"updated_at"`
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Email      string `json:"email"`
+	Password   string `json:"-"`
+	Role       string `json:"role"`
+	Status     string `json:"status"`
+	DisabledAt int64  `json:"disabled_at,omitempty"`
+	CreatedAt  int64  `json:"created_at"`
+	UpdatedAt  int64  `json:"updated_at"`
+}
+
+// IsDisabled reports whether the account was deactivated. Users stored before
+// statuses existed have an empty status and count as active.
+func (u User) IsDisabled() bool {
+	return u.Status == UserStatusDisabled
+}
+
+func IsValidUserRole(role string) bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+// UserUpdate holds the profile fields a caller wants to change; nil fields
+// keep their current value.
+type UserUpdate struct {
+	Name  *string
+	Email *string
+	Role  *string
+}
+
+// UserDeactivation controls what happens to a user's upcoming bookings when
+// the account is disabled. With a DelegateID they are handed over to that
+// user; otherwise they are cancelled with Reason.
+type UserDeactivation struct {
+	DelegateID string
+	DisabledBy string
+	Reason     string
+}
+
+// UserDeactivationResult lists the upcoming bookings a deactivation cancelled
+// or reassigned.
+type UserDeactivationResult struct {
+	Cancelled  []Booking
+	Reassigned []Booking
 }
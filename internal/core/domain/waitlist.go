@@ -0,0 +1,34 @@
+package domain
+
+const (
+	WaitlistStatusWaiting   = "waiting"
+	WaitlistStatusOffered   = "offered"
+	WaitlistStatusBooked    = "booked"
+	WaitlistStatusExpired   = "expired"
+	WaitlistStatusCancelled = "cancelled"
+)
+
+// WaitlistEntry records a user's interest in a room for a time window that
+// was fully booked. When a conflicting booking is cancelled the entry is
+// either booked straight away (AutoBook) or offered to the user, who can
+// claim it until OfferExpiresAt.
+type WaitlistEntry struct {
+	ID             string `json:"id"`
+	UserID         string `json:"user_id"`
+	RoomID         string `json:"room_id"`
+	StartTime      int64  `json:"start_time"`
+	EndTime        int64  `json:"end_time"`
+	Purpose        string `json:"purpose"`
+	AutoBook       bool   `json:"auto_book"`
+	Status         string `json:"status"`
+	BookingID      string `json:"booking_id,omitempty"`
+	OfferExpiresAt int64  `json:"offer_expires_at,omitempty"`
+	CreatedAt      int64  `json:"created_at"`
+	UpdatedAt      int64  `json:"updated_at"`
+}
+
+// HasOpenOffer reports whether the entry was offered and the claim window has
+// not passed yet.
+func (e WaitlistEntry) HasOpenOffer(now int64) bool {
+	return e.Status == WaitlistStatusOffered && e.OfferExpiresAt > now
+}
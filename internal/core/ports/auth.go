@@ -1,10 +1,33 @@
 package ports
 
+import (
+	"time"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+)
+
 type TokenGenerator interface {
-	GenerateToken(userID, role string) (string, error)
+	GenerateToken(userID, role, sessionID string) (token string, expiresAt time.Time, err error)
 }
 
 type PasswordHasher interface {
 	HashPassword(password string) (string, error)
 	VerifyPassword(hashed, plain string) bool
 }
+
+type RefreshTokenRepository interface {
+	Create(token *domain.RefreshToken) error
+	GetByHash(tokenHash string) (*domain.RefreshToken, error)
+	MarkUsed(tokenHash string, usedAt int64) error
+	GetByUserID(userID string) ([]domain.RefreshToken, error)
+	DeleteSession(userID, sessionID string) error
+	DeleteByUserID(userID string) error
+}
+
+// TokenRevocationStore holds the IDs of revoked access tokens (jti) and
+// sessions (sid). Entries are only needed until expiresAt, after which the
+// tokens they refer to have expired anyway.
+type TokenRevocationStore interface {
+	Revoke(id string, expiresAt int64) error
+	IsRevoked(ids ...string) (bool, error)
+}
@@ -0,0 +1,11 @@
+package ports
+
+import "github.com/amangirdhar210/meeting-room/internal/core/domain"
+
+type RoomBlockRepository interface {
+	Create(block *domain.RoomBlock) error
+	GetByID(id string) (*domain.RoomBlock, error)
+	GetByRoomID(roomID string) ([]domain.RoomBlock, error)
+	GetByRoomAndTime(roomID string, start, end int64) ([]domain.RoomBlock, error)
+	Delete(id string) error
+}
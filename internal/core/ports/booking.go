@@ -9,7 +9,34 @@ type BookingRepository interface {
 	GetByRoomAndTime(roomID string, start, end int64) ([]domain.Booking, error)
 	GetByRoomID(roomID string) ([]domain.Booking, error)
 	GetByUserID(userID string) ([]domain.Booking, error)
-	Cancel(id string) error
+	GetBySeriesID(seriesID string) ([]domain.Booking, error)
+	GetByGroupID(groupID string) ([]domain.Booking, error)
+	Update(booking *domain.Booking) error
+	Cancel(id, cancelledBy, reason string, cancelledAt int64) error
+	// CreateAll, UpdateAll, CancelAll and ReassignAll write every booking or
+	// none: they fail with ErrRoomUnavailable when any room is taken and with
+	// ErrBookingNotActive when any booking can no longer be changed.
+	CreateAll(bookings []domain.Booking) error
+	UpdateAll(bookings []domain.Booking) error
+	CancelAll(ids []string, cancelledBy, reason string, cancelledAt int64) error
+	ReassignAll(ids []string, userID string, updatedAt int64) error
+	// Review records the decision on a pending booking and fails with
+	// ErrBookingNotPending once it is no longer pending.
+	Review(id, status, reviewedBy, note string, reviewedAt int64) error
+	// CheckIn and MarkNoShow fail with ErrBookingNotActive unless the
+	// booking is confirmed, and with ErrAlreadyCheckedIn once someone has
+	// checked in.
+	CheckIn(id string, checkedInAt int64) error
+	MarkNoShow(id string, releasedAt int64) error
+	// GetAwaitingCheckIn returns the confirmed bookings nobody has checked
+	// in to that started by startedBefore and end after endsAfter.
+	GetAwaitingCheckIn(startedBefore, endsAfter int64) ([]domain.Booking, error)
+	// CompleteEnded marks confirmed bookings completed once they have ended:
+	// checked-in ones by endedBefore, the rest by unclaimedEndedBefore. It
+	// returns how many it completed.
+	CompleteEnded(endedBefore, unclaimedEndedBefore, completedAt int64) (int, error)
+	GetByStatus(status string) ([]domain.Booking, error)
+	GetByUserIDAndStatus(userID, status string) ([]domain.Booking, error)
 	GetByDateRange(startDate, endDate int64) ([]domain.Booking, error)
 	GetByRoomIDAndDate(roomID string, date int64) ([]domain.Booking, error)
 }
@@ -0,0 +1,10 @@
+package ports
+
+import "github.com/amangirdhar210/meeting-room/internal/core/domain"
+
+type CalendarTokenRepository interface {
+	Create(token *domain.CalendarToken) error
+	GetByHash(tokenHash string) (*domain.CalendarToken, error)
+	GetByUserID(userID string) ([]domain.CalendarToken, error)
+	Delete(id, userID string) error
+}
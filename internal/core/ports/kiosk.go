@@ -0,0 +1,10 @@
+package ports
+
+import "github.com/amangirdhar210/meeting-room/internal/core/domain"
+
+type KioskTokenRepository interface {
+	Create(token *domain.KioskToken) error
+	GetByHash(tokenHash string) (*domain.KioskToken, error)
+	GetByRoomID(roomID string) ([]domain.KioskToken, error)
+	Delete(id, roomID string) error
+}
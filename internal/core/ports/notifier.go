@@ -0,0 +1,7 @@
+package ports
+
+import "github.com/amangirdhar210/meeting-room/internal/core/domain"
+
+type Notifier interface {
+	Notify(notification domain.Notification) error
+}
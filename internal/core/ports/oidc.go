@@ -0,0 +1,22 @@
+package ports
+
+import "github.com/amangirdhar210/meeting-room/internal/core/domain"
+
+// OIDCProvider talks to the identity provider. Exchange redeems the
+// authorization code with the PKCE verifier and returns the identity from
+// the validated ID token, whose nonce must match.
+type OIDCProvider interface {
+	AuthCodeURL(state, nonce, codeChallenge string) (string, error)
+	Exchange(code, codeVerifier, nonce string) (*domain.OIDCIdentity, error)
+}
+
+type OIDCLoginStateRepository interface {
+	Create(state *domain.OIDCLoginState) error
+	// Consume returns the state and deletes it, so a state works once.
+	Consume(state string) (*domain.OIDCLoginState, error)
+}
+
+type UserIdentityRepository interface {
+	Create(identity *domain.UserIdentity) error
+	GetBySubject(issuer, subject string) (*domain.UserIdentity, error)
+}
@@ -0,0 +1,10 @@
+package ports
+
+import "github.com/amangirdhar210/meeting-room/internal/core/domain"
+
+type PasswordResetTokenRepository interface {
+	Create(token *domain.PasswordResetToken) error
+	GetByHash(tokenHash string) (*domain.PasswordResetToken, error)
+	MarkUsed(tokenHash string, usedAt int64) error
+	DeleteByUserID(userID string) error
+}
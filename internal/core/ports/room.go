@@ -6,7 +6,8 @@ type RoomRepository interface {
 	Create(room *domain.Room) error
 	GetAll() ([]domain.Room, error)
 	GetByID(id string) (*domain.Room, error)
+	Update(room *domain.Room) error
 	UpdateAvailability(id string, status string) error
-	DeleteByID(id string) error
-	SearchWithFilters(minCapacity, maxCapacity int, floor *int) ([]domain.Room, error)
+	SoftDelete(id string, deletedAt int64) error
+	SearchWithFilters(filter domain.RoomSearchFilter) ([]domain.Room, error)
 }
@@ -0,0 +1,10 @@
+package ports
+
+import "github.com/amangirdhar210/meeting-room/internal/core/domain"
+
+type BookingSeriesRepository interface {
+	Create(series *domain.BookingSeries) error
+	GetByID(id string) (*domain.BookingSeries, error)
+	Update(series *domain.BookingSeries) error
+	Delete(id string) error
+}
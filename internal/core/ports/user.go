@@ -7,5 +7,7 @@ type UserRepository interface {
 	FindByEmail(email string) (*domain.User, error)
 	GetByID(id string) (*domain.User, error)
 	GetAll() ([]domain.User, error)
-	DeleteByID(id string) error
+	Update(user *domain.User) error
+	UpdatePassword(id, passwordHash string, updatedAt int64) error
+	Disable(id string, disabledAt int64) error
 }
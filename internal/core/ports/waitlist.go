@@ -0,0 +1,16 @@
+package ports
+
+import "github.com/amangirdhar210/meeting-room/internal/core/domain"
+
+type WaitlistRepository interface {
+	Create(entry *domain.WaitlistEntry) error
+	GetByID(id string) (*domain.WaitlistEntry, error)
+	GetByUserID(userID string) ([]domain.WaitlistEntry, error)
+	// GetPendingByRoomAndTime returns the waiting and offered entries on
+	// roomID that overlap [start, end), oldest first.
+	GetPendingByRoomAndTime(roomID string, start, end int64) ([]domain.WaitlistEntry, error)
+	// GetLapsedOffers returns the offered entries whose claim window closed
+	// at or before now, oldest first.
+	GetLapsedOffers(now int64) ([]domain.WaitlistEntry, error)
+	Update(entry *domain.WaitlistEntry) error
+}
@@ -0,0 +1,241 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/ports"
+)
+
+type approvalService struct {
+	bookingRepo    ports.BookingRepository
+	roomRepo       ports.RoomRepository
+	userRepo       ports.UserRepository
+	bookingService BookingService
+	notifier       ports.Notifier
+}
+
+func NewApprovalService(bRepo ports.BookingRepository, rRepo ports.RoomRepository, uRepo ports.UserRepository, bookingService BookingService, notifier ports.Notifier) ApprovalService {
+	return &approvalService{
+		bookingRepo:    bRepo,
+		roomRepo:       rRepo,
+		userRepo:       uRepo,
+		bookingService: bookingService,
+		notifier:       notifier,
+	}
+}
+
+// GetPending lists the pending bookings approverID may decide on, the ones
+// closest to expiring first.
+func (s *approvalService) GetPending(approverID string) ([]domain.BookingWithDetails, error) {
+	approver, err := s.approver(approverID)
+	if err != nil {
+		return nil, err
+	}
+
+	bookings, err := s.bookingRepo.GetByStatus(domain.BookingStatusPending)
+	if err != nil && err != domain.ErrNotFound {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	rooms := map[string]*domain.Room{}
+	pending := []domain.BookingWithDetails{}
+	for _, booking := range bookings {
+		if booking.IsApprovalOverdue(now) {
+			continue
+		}
+		room, ok := rooms[booking.RoomID]
+		if !ok {
+			room, err = s.roomRepo.GetByID(booking.RoomID)
+			if err != nil && err != domain.ErrNotFound {
+				return nil, err
+			}
+			rooms[booking.RoomID] = room
+		}
+		if room == nil || !room.CanApprove(approver) {
+			continue
+		}
+
+		detailed := domain.BookingWithDetails{
+			Booking:    booking,
+			UserName:   domain.UnknownUserName,
+			RoomName:   room.Name,
+			RoomNumber: room.RoomNumber,
+		}
+		if user, err := s.userRepo.GetByID(booking.UserID); err == nil && user != nil {
+			detailed.UserName = user.Name
+			detailed.UserEmail = user.Email
+		}
+		pending = append(pending, detailed)
+	}
+
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].ApprovalExpiresAt < pending[j].ApprovalExpiresAt
+	})
+	return pending, nil
+}
+
+func (s *approvalService) Approve(bookingID, approverID, note string) (*domain.Booking, error) {
+	return s.decide(bookingID, approverID, domain.BookingStatusConfirmed, note)
+}
+
+func (s *approvalService) Reject(bookingID, approverID, note string) (*domain.Booking, error) {
+	return s.decide(bookingID, approverID, domain.BookingStatusRejected, note)
+}
+
+func (s *approvalService) decide(bookingID, approverID, status, note string) (*domain.Booking, error) {
+	if bookingID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+	approver, err := s.approver(approverID)
+	if err != nil {
+		return nil, err
+	}
+
+	booking, err := s.bookingRepo.GetByID(bookingID)
+	if err != nil {
+		return nil, err
+	}
+	room, err := s.roomRepo.GetByID(booking.RoomID)
+	if err != nil {
+		return nil, err
+	}
+	if !room.CanApprove(approver) {
+		return nil, domain.ErrNotApprover
+	}
+	if booking.Status != domain.BookingStatusPending {
+		return nil, domain.ErrBookingNotPending
+	}
+
+	now := time.Now().Unix()
+	if booking.IsApprovalOverdue(now) {
+		// Its approval window has closed; expire it here rather than wait
+		// for ExpireOverdue.
+		if err := s.expire(booking, room, now); err != nil && err != domain.ErrBookingNotPending {
+			return nil, err
+		}
+		return nil, domain.ErrBookingNotPending
+	}
+
+	note = strings.TrimSpace(note)
+	if err := s.bookingRepo.Review(booking.ID, status, approver.ID, note, now); err != nil {
+		return nil, err
+	}
+	booking.Status = status
+	booking.ReviewedBy = approver.ID
+	booking.ReviewedAt = now
+	booking.ReviewNote = note
+	booking.UpdatedAt = now
+	booking.Sequence++
+
+	s.notifyDecision(*booking, room)
+	if status == domain.BookingStatusRejected {
+		s.bookingService.ReleaseSlot(booking.RoomID, booking.StartTime, booking.EndTime)
+	}
+	return booking, nil
+}
+
+// ExpireOverdue marks every pending booking whose approvers did not answer
+// in time as expired, releasing its slot, and returns how many it expired.
+func (s *approvalService) ExpireOverdue() (int, error) {
+	bookings, err := s.bookingRepo.GetByStatus(domain.BookingStatusPending)
+	if err != nil && err != domain.ErrNotFound {
+		return 0, err
+	}
+
+	now := time.Now().Unix()
+	expired := 0
+	for i := range bookings {
+		booking := &bookings[i]
+		if !booking.IsApprovalOverdue(now) {
+			continue
+		}
+		room, err := s.roomRepo.GetByID(booking.RoomID)
+		if err != nil && err != domain.ErrNotFound {
+			return expired, err
+		}
+		if room == nil {
+			room = &domain.Room{ID: booking.RoomID, Name: booking.RoomID}
+		}
+
+		err = s.expire(booking, room, now)
+		if err == domain.ErrBookingNotPending {
+			continue
+		}
+		if err != nil {
+			return expired, err
+		}
+		expired++
+	}
+	return expired, nil
+}
+
+func (s *approvalService) expire(booking *domain.Booking, room *domain.Room, now int64) error {
+	if err := s.bookingRepo.Review(booking.ID, domain.BookingStatusExpired, "", "", now); err != nil {
+		return err
+	}
+	booking.Status = domain.BookingStatusExpired
+	booking.ReviewedAt = now
+	booking.UpdatedAt = now
+	booking.Sequence++
+
+	s.notifyDecision(*booking, room)
+	s.bookingService.ReleaseSlot(booking.RoomID, booking.StartTime, booking.EndTime)
+	return nil
+}
+
+func (s *approvalService) approver(approverID string) (*domain.User, error) {
+	if approverID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+	approver, err := s.userRepo.GetByID(approverID)
+	if err != nil {
+		return nil, err
+	}
+	if approver.IsDisabled() {
+		return nil, domain.ErrUserDisabled
+	}
+	return approver, nil
+}
+
+func (s *approvalService) notifyDecision(booking domain.Booking, room *domain.Room) {
+	var subject, message string
+	at := time.Unix(booking.StartTime, 0).UTC().Format(time.RFC3339)
+	switch booking.Status {
+	case domain.BookingStatusConfirmed:
+		subject = "Booking approved"
+		message = fmt.Sprintf("Your booking %q in %s at %s was approved.", booking.Purpose, room.Name, at)
+	case domain.BookingStatusRejected:
+		subject = "Booking rejected"
+		message = fmt.Sprintf("Your booking %q in %s at %s was rejected.", booking.Purpose, room.Name, at)
+	case domain.BookingStatusExpired:
+		subject = "Booking request expired"
+		message = fmt.Sprintf("Nobody approved your booking %q in %s at %s in time, so the room has been released.", booking.Purpose, room.Name, at)
+	default:
+		return
+	}
+	if booking.ReviewNote != "" {
+		message += " Note from the approver: " + booking.ReviewNote
+	}
+
+	user, err := s.userRepo.GetByID(booking.UserID)
+	if err != nil {
+		log.Printf("Cannot notify owner of booking %s: %v", booking.ID, err)
+		return
+	}
+	err = s.notifier.Notify(domain.Notification{
+		UserID:  user.ID,
+		Email:   user.Email,
+		Name:    user.Name,
+		Subject: subject,
+		Message: message,
+	})
+	if err != nil {
+		log.Printf("Failed to notify %s about booking %s: %v", user.Email, booking.ID, err)
+	}
+}
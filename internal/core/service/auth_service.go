@@ -3,51 +3,233 @@ package service
 import (
 	"log"
 	"strings"
+	"time"
 
 	"github.com/amangirdhar210/meeting-room/internal/core/domain"
 	"github.com/amangirdhar210/meeting-room/internal/core/ports"
+	"github.com/amangirdhar210/meeting-room/internal/pkg/utils"
+	"github.com/google/uuid"
 )
 
 type authService struct {
 	userRepo       ports.UserRepository
+	refreshRepo    ports.RefreshTokenRepository
+	revocations    ports.TokenRevocationStore
 	tokenGenerator ports.TokenGenerator
 	passwordHasher ports.PasswordHasher
+	refreshTTL     time.Duration
 }
 
-func NewAuthService(repo ports.UserRepository, tokenGen ports.TokenGenerator, hasher ports.PasswordHasher) AuthService {
+func NewAuthService(repo ports.UserRepository, refreshRepo ports.RefreshTokenRepository, revocations ports.TokenRevocationStore, tokenGen ports.TokenGenerator, hasher ports.PasswordHasher, refreshTTL time.Duration) AuthService {
 	return &authService{
 		userRepo:       repo,
+		refreshRepo:    refreshRepo,
+		revocations:    revocations,
 		tokenGenerator: tokenGen,
 		passwordHasher: hasher,
+		refreshTTL:     refreshTTL,
 	}
 }
 
-func (s *authService) Login(email, password string) (string, *domain.User, error) {
+func (s *authService) Login(email, password string) (*domain.AuthTokens, *domain.User, error) {
 	email = strings.TrimSpace(email)
 	password = strings.TrimSpace(password)
 
 	if email == "" || password == "" {
-		return "", nil, domain.ErrInvalidInput
+		return nil, nil, domain.ErrInvalidInput
 	}
 
 	user, err := s.userRepo.FindByEmail(email)
 	if err != nil {
-		return "", nil, err
+		return nil, nil, err
 	}
 	if user == nil {
-		return "", nil, domain.ErrUnauthorized
+		return nil, nil, domain.ErrUnauthorized
 	}
 
 	log.Println(user)
 
 	if !s.passwordHasher.VerifyPassword(user.Password, password) {
-		return "", nil, domain.ErrUnauthorized
+		return nil, nil, domain.ErrUnauthorized
+	}
+	if user.IsDisabled() {
+		return nil, nil, domain.ErrUserDisabled
+	}
+
+	tokens, err := s.StartSession(user)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return tokens, user, nil
+}
+
+func (s *authService) StartSession(user *domain.User) (*domain.AuthTokens, error) {
+	if user.IsDisabled() {
+		return nil, domain.ErrUserDisabled
+	}
+	return s.issueTokens(user, uuid.New().String())
+}
+
+// Refresh exchanges a refresh token for a new access and refresh token in
+// the same session. The role is read again from the user record, so role
+// changes take effect from the next refresh.
+func (s *authService) Refresh(refreshToken string) (*domain.AuthTokens, error) {
+	refreshToken = strings.TrimSpace(refreshToken)
+	if refreshToken == "" {
+		return nil, domain.ErrInvalidInput
 	}
 
-	token, err := s.tokenGenerator.GenerateToken(user.ID, user.Role)
+	tokenHash := utils.HashToken(refreshToken)
+	token, err := s.refreshRepo.GetByHash(tokenHash)
+	if err == domain.ErrNotFound {
+		return nil, domain.ErrInvalidRefresh
+	}
 	if err != nil {
-		return "", nil, err
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	if token.UsedAt != 0 {
+		// A rotated token coming back means someone else holds a copy.
+		s.revokeReusedSession(token)
+		return nil, domain.ErrInvalidRefresh
+	}
+	if !token.IsUsable(now) {
+		return nil, domain.ErrInvalidRefresh
+	}
+
+	revoked, err := s.revocations.IsRevoked(token.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, domain.ErrInvalidRefresh
+	}
+
+	user, err := s.userRepo.GetByID(token.UserID)
+	if err == domain.ErrNotFound {
+		return nil, domain.ErrInvalidRefresh
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user.IsDisabled() {
+		return nil, domain.ErrUserDisabled
+	}
+
+	if err := s.refreshRepo.MarkUsed(tokenHash, now); err != nil {
+		if err == domain.ErrNotFound {
+			s.revokeReusedSession(token)
+			return nil, domain.ErrInvalidRefresh
+		}
+		return nil, err
+	}
+
+	return s.issueTokens(user, token.SessionID)
+}
+
+// Logout revokes the presented access token and the session it belongs to,
+// which invalidates the session's refresh token as well.
+func (s *authService) Logout(claims domain.AccessTokenClaims) error {
+	if claims.UserID == "" || claims.TokenID == "" || claims.SessionID == "" {
+		return domain.ErrInvalidInput
+	}
+
+	if err := s.revocations.Revoke(claims.TokenID, claims.ExpiresAt); err != nil {
+		return err
+	}
+	return s.revokeSession(claims.UserID, claims.SessionID)
+}
+
+func (s *authService) IsRevoked(claims domain.AccessTokenClaims) (bool, error) {
+	return s.revocations.IsRevoked(claims.TokenID, claims.SessionID)
+}
+
+// RevokeUserSessions ends every session of the user, including the access
+// tokens already handed out in them.
+func (s *authService) RevokeUserSessions(userID string) error {
+	tokens, err := s.refreshRepo.GetByUserID(userID)
+	if err != nil {
+		return err
+	}
+
+	expiresAt := time.Now().Add(s.refreshTTL).Unix()
+	revoked := make(map[string]bool)
+	for _, token := range tokens {
+		if revoked[token.SessionID] {
+			continue
+		}
+		if err := s.revocations.Revoke(token.SessionID, expiresAt); err != nil {
+			return err
+		}
+		revoked[token.SessionID] = true
+	}
+	return s.refreshRepo.DeleteByUserID(userID)
+}
+
+// RevokeOtherSessions ends every session of the user except keepSessionID,
+// the one the user is acting from.
+func (s *authService) RevokeOtherSessions(userID, keepSessionID string) error {
+	tokens, err := s.refreshRepo.GetByUserID(userID)
+	if err != nil {
+		return err
+	}
+
+	revoked := map[string]bool{keepSessionID: true}
+	for _, token := range tokens {
+		if revoked[token.SessionID] {
+			continue
+		}
+		if err := s.revokeSession(userID, token.SessionID); err != nil {
+			return err
+		}
+		revoked[token.SessionID] = true
+	}
+	return nil
+}
+
+func (s *authService) revokeSession(userID, sessionID string) error {
+	// No token of the session can outlive a freshly issued refresh token.
+	if err := s.revocations.Revoke(sessionID, time.Now().Add(s.refreshTTL).Unix()); err != nil {
+		return err
+	}
+	return s.refreshRepo.DeleteSession(userID, sessionID)
+}
+
+func (s *authService) revokeReusedSession(token *domain.RefreshToken) {
+	log.Printf("Refresh token reuse detected for user %s, revoking session %s", token.UserID, token.SessionID)
+	if err := s.revokeSession(token.UserID, token.SessionID); err != nil {
+		log.Printf("Failed to revoke session %s: %v", token.SessionID, err)
+	}
+}
+
+func (s *authService) issueTokens(user *domain.User, sessionID string) (*domain.AuthTokens, error) {
+	accessToken, expiresAt, err := s.tokenGenerator.GenerateToken(user.ID, user.Role, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := utils.GenerateSecureToken()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	refreshToken := &domain.RefreshToken{
+		ID:        uuid.New().String(),
+		UserID:    user.ID,
+		SessionID: sessionID,
+		TokenHash: utils.HashToken(secret),
+		ExpiresAt: now.Add(s.refreshTTL).Unix(),
+		CreatedAt: now.Unix(),
+	}
+	if err := s.refreshRepo.Create(refreshToken); err != nil {
+		return nil, err
 	}
 
-	return token, user, nil
+	return &domain.AuthTokens{
+		AccessToken:  accessToken,
+		RefreshToken: secret,
+		ExpiresIn:    int64(expiresAt.Sub(now).Round(time.Second).Seconds()),
+	}, nil
 }
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"strings"
 	"time"
 
 	"github.com/amangirdhar210/meeting-room/internal/core/domain"
@@ -10,20 +11,56 @@ import (
 )
 
 type bookingService struct {
-	repo     ports.BookingRepository
-	roomRepo ports.RoomRepository
-	userRepo ports.UserRepository
+	repo         ports.BookingRepository
+	roomRepo     ports.RoomRepository
+	userRepo     ports.UserRepository
+	blockRepo    ports.RoomBlockRepository
+	waitlistRepo ports.WaitlistRepository
+	notifier     ports.Notifier
+	claimWindow  time.Duration
+	// approvalTimeout is how long approvers have to answer a booking on a
+	// room that requires approval.
+	approvalTimeout time.Duration
+	policy          domain.BookingPolicy
 }
 
-func NewBookingService(bRepo ports.BookingRepository, rRepo ports.RoomRepository, uRepo ports.UserRepository) BookingService {
+func NewBookingService(bRepo ports.BookingRepository, rRepo ports.RoomRepository, uRepo ports.UserRepository, blRepo ports.RoomBlockRepository, wRepo ports.WaitlistRepository, notifier ports.Notifier, claimWindow, approvalTimeout time.Duration, policy domain.BookingPolicy) BookingService {
 	return &bookingService{
-		repo:     bRepo,
-		roomRepo: rRepo,
-		userRepo: uRepo,
+		repo:            bRepo,
+		roomRepo:        rRepo,
+		userRepo:        uRepo,
+		blockRepo:       blRepo,
+		waitlistRepo:    wRepo,
+		notifier:        notifier,
+		claimWindow:     claimWindow,
+		approvalTimeout: approvalTimeout,
+		policy:          policy,
 	}
 }
 
-func (s *bookingService) CreateBooking(booking *domain.Booking) error {
+// CreateBooking books a room for booking.UserID on behalf of an actor with
+// actorRole, who may be exempt from the booking policy.
+func (s *bookingService) CreateBooking(booking *domain.Booking, actorRole string) error {
+	return s.createBooking(booking, actorRole, false)
+}
+
+// ImportBooking is CreateBooking for a meeting taken over from an existing
+// calendar. Such a booking records a meeting rather than planning one, so it
+// may start in the past or further ahead than the policy allows.
+func (s *bookingService) ImportBooking(booking *domain.Booking, actorRole string) error {
+	return s.createBooking(booking, actorRole, true)
+}
+
+// createOwnBooking is CreateBooking with the booking's owner as the actor.
+func (s *bookingService) createOwnBooking(booking *domain.Booking) error {
+	owner, err := s.userRepo.GetByID(booking.UserID)
+	if err != nil {
+		return err
+	}
+	return s.CreateBooking(booking, owner.Role)
+}
+
+func (s *bookingService) createBooking(booking *domain.Booking, actorRole string, imported bool) error {
 	if booking == nil {
 		return domain.ErrInvalidInput
 	}
@@ -31,8 +68,8 @@ func (s *bookingService) CreateBooking(booking *domain.Booking) error {
 	if booking.UserID == "" || booking.RoomID == "" {
 		return domain.ErrInvalidInput
 	}
-	if !utils.IsTimeRangeValid(booking.StartTime, booking.EndTime) {
-		return domain.ErrTimeRangeInvalid
+	if err := checkTimeRange(booking.StartTime, booking.EndTime); err != nil {
+		return err
 	}
 
 	user, err := s.userRepo.GetByID(booking.UserID)
@@ -42,6 +79,9 @@ func (s *bookingService) CreateBooking(booking *domain.Booking) error {
 	if user == nil {
 		return domain.ErrNotFound
 	}
+	if user.IsDisabled() {
+		return domain.ErrUserDisabled
+	}
 
 	room, err := s.roomRepo.GetByID(booking.RoomID)
 	if err != nil {
@@ -50,22 +90,17 @@ func (s *bookingService) CreateBooking(booking *domain.Booking) error {
 	if room == nil {
 		return domain.ErrNotFound
 	}
-
-	existingBookings, err := s.repo.GetByRoomAndTime(booking.RoomID, booking.StartTime, booking.EndTime)
-	if err != nil {
+	if err := s.CheckPolicy(user, actorRole, room, booking.StartTime, booking.EndTime, imported); err != nil {
 		return err
 	}
-
-	for _, b := range existingBookings {
-		if utils.Overlaps(booking.StartTime, booking.EndTime, b.StartTime, b.EndTime) {
-			return domain.ErrRoomUnavailable
-		}
+	if err := checkSlot(s.repo, s.blockRepo, room, booking.StartTime, booking.EndTime); err != nil {
+		return err
 	}
 
 	booking.ID = uuid.New().String()
-	booking.Status = "confirmed"
 	booking.CreatedAt = time.Now().Unix()
 	booking.UpdatedAt = time.Now().Unix()
+	settleApproval(booking, room, true, s.approvalTimeout, booking.CreatedAt)
 
 	err = s.repo.Create(booking)
 	if err != nil {
@@ -91,7 +126,104 @@ func (s *bookingService) GetBookingByID(bookingID string) (*domain.Booking, erro
 	return booking, nil
 }
 
-func (s *bookingService) CancelBooking(bookingID string) error {
+func (s *bookingService) UpdateBooking(bookingID string, update domain.BookingUpdate, actorRole string) (*domain.Booking, error) {
+	if bookingID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+	if update.RoomID == nil && update.StartTime == nil && update.EndTime == nil && update.Purpose == nil {
+		return nil, domain.ErrInvalidInput
+	}
+
+	booking, err := s.repo.GetByID(bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if booking == nil {
+		return nil, domain.ErrNotFound
+	}
+	if !booking.IsActive() {
+		return nil, domain.ErrBookingNotActive
+	}
+	// The rooms of a group are rescheduled together or not at all.
+	if booking.GroupID != "" {
+		return nil, domain.ErrGroupMember
+	}
+	previous := *booking
+
+	if update.RoomID != nil {
+		if *update.RoomID == "" {
+			return nil, domain.ErrInvalidInput
+		}
+		booking.RoomID = *update.RoomID
+	}
+	if update.StartTime != nil {
+		booking.StartTime = *update.StartTime
+	}
+	if update.EndTime != nil {
+		booking.EndTime = *update.EndTime
+	}
+	if update.Purpose != nil {
+		booking.Purpose = *update.Purpose
+	}
+	if err := checkTimeRange(booking.StartTime, booking.EndTime); err != nil {
+		return nil, err
+	}
+
+	room, err := s.roomRepo.GetByID(booking.RoomID)
+	if err != nil {
+		return nil, err
+	}
+	if room == nil {
+		return nil, domain.ErrNotFound
+	}
+	rescheduled := update.RoomID != nil || update.StartTime != nil || update.EndTime != nil
+	if rescheduled && !room.AcceptsBookings() {
+		return nil, domain.ErrRoomNotBookable
+	}
+	if rescheduled {
+		owner, err := s.userRepo.GetByID(booking.UserID)
+		if err != nil {
+			return nil, err
+		}
+		window := policyWindow{start: booking.StartTime, end: booking.EndTime, movedStart: booking.StartTime != previous.StartTime}
+		if err := checkPolicy(s.policy, s.repo, owner, actorRole, room, []policyWindow{window}, 0); err != nil {
+			return nil, err
+		}
+
+		blocks, err := overlappingBlocks(s.blockRepo, booking.RoomID, booking.StartTime, booking.EndTime)
+		if err != nil {
+			return nil, err
+		}
+		if len(blocks) > 0 {
+			return nil, domain.ErrRoomBlocked
+		}
+	}
+
+	existingBookings, err := s.repo.GetByRoomAndTime(booking.RoomID, booking.StartTime, booking.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range existingBookings {
+		if b.ID != booking.ID && utils.Overlaps(booking.StartTime, booking.EndTime, b.StartTime, b.EndTime) {
+			return nil, domain.ErrRoomUnavailable
+		}
+	}
+
+	booking.UpdatedAt = time.Now().Unix()
+	if rescheduled {
+		settleApproval(booking, room, booking.RoomID != previous.RoomID, s.approvalTimeout, booking.UpdatedAt)
+	}
+	if err := s.repo.Update(booking); err != nil {
+		return nil, err
+	}
+	if rescheduled {
+		s.ReleaseSlot(previous.RoomID, previous.StartTime, previous.EndTime)
+	}
+
+	return booking, nil
+}
+
+func (s *bookingService) CancelBooking(bookingID, cancelledBy, reason string) error {
 	if bookingID == "" {
 		return domain.ErrInvalidInput
 	}
@@ -103,12 +235,19 @@ func (s *bookingService) CancelBooking(bookingID string) error {
 	if booking == nil {
 		return domain.ErrNotFound
 	}
+	if !booking.IsActive() {
+		return domain.ErrBookingNotActive
+	}
+	if booking.GroupID != "" {
+		return domain.ErrGroupMember
+	}
 
-	err = s.repo.Cancel(bookingID)
+	err = s.repo.Cancel(bookingID, cancelledBy, strings.TrimSpace(reason), time.Now().Unix())
 	if err != nil {
 		return err
 	}
 
+	s.ReleaseSlot(booking.RoomID, booking.StartTime, booking.EndTime)
 	return nil
 }
 
@@ -152,18 +291,21 @@ func (s *bookingService) GetBookingsWithDetailsByRoomID(roomID string) ([]domain
 
 	var detailedBookings []domain.BookingWithDetails
 	for _, booking := range bookings {
-		user, err := s.userRepo.GetByID(booking.UserID)
-		if err != nil {
-			continue
-		}
-
-		detailedBookings = append(detailedBookings, domain.BookingWithDetails{
+		detailed := domain.BookingWithDetails{
 			Booking:    booking,
-			UserName:   user.Name,
-			UserEmail:  user.Email,
+			UserName:   domain.UnknownUserName,
 			RoomName:   room.Name,
 			RoomNumber: room.RoomNumber,
-		})
+		}
+		user, err := s.userRepo.GetByID(booking.UserID)
+		if err != nil && err != domain.ErrNotFound {
+			return nil, err
+		}
+		if user != nil {
+			detailed.UserName = user.Name
+			detailed.UserEmail = user.Email
+		}
+		detailedBookings = append(detailedBookings, detailed)
 	}
 
 	return detailedBookings, nil
@@ -181,6 +323,30 @@ func (s *bookingService) GetBookingsByUserID(userID string) ([]domain.Booking, e
 	return bookings, nil
 }
 
+func (s *bookingService) GetBookingsByStatus(status string) ([]domain.Booking, error) {
+	if !domain.IsValidBookingStatus(status) {
+		return nil, domain.ErrInvalidInput
+	}
+
+	bookings, err := s.repo.GetByStatus(status)
+	if err != nil {
+		return nil, err
+	}
+	return bookings, nil
+}
+
+func (s *bookingService) GetBookingsByUserIDAndStatus(userID, status string) ([]domain.Booking, error) {
+	if userID == "" || !domain.IsValidBookingStatus(status) {
+		return nil, domain.ErrInvalidInput
+	}
+
+	bookings, err := s.repo.GetByUserIDAndStatus(userID, status)
+	if err != nil {
+		return nil, err
+	}
+	return bookings, nil
+}
+
 func (s *bookingService) GetBookingsByDateRange(startDate, endDate int64) ([]domain.Booking, error) {
 	bookings, err := s.repo.GetByDateRange(startDate, endDate)
 	if err != nil {
@@ -225,13 +391,145 @@ func (s *bookingService) GetRoomScheduleByDate(roomID string, targetDate int64)
 		})
 	}
 
+	day := time.Unix(targetDate, 0)
+	dayStart := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	blocks, err := overlappingBlocks(s.blockRepo, roomID, dayStart.Unix(), dayStart.AddDate(0, 0, 1).Unix())
+	if err != nil {
+		return nil, err
+	}
+	blockSlots := []domain.ScheduleSlot{}
+	for _, block := range blocks {
+		blockID := block.ID
+		blockSlots = append(blockSlots, domain.ScheduleSlot{
+			StartTime: time.Unix(block.StartTime, 0).Format(time.RFC3339),
+			EndTime:   time.Unix(block.EndTime, 0).Format(time.RFC3339),
+			IsBooked:  true,
+			BlockID:   &blockID,
+			Reason:    block.Reason,
+		})
+	}
+
 	response := &domain.RoomScheduleResponse{
 		RoomID:     room.ID,
 		RoomName:   room.Name,
 		RoomNumber: room.RoomNumber,
 		Date:       time.Unix(targetDate, 0).Format("2006-01-02"),
 		Bookings:   scheduleSlots,
+		Blocks:     blockSlots,
 	}
 
 	return response, nil
 }
+
+// settleApproval sets the status of a booking that is new or was moved. In a
+// room that requires approval it waits for its approvers afresh, unless it
+// was already approved there and only changed time; in any other room it is
+// confirmed.
+func settleApproval(booking *domain.Booking, room *domain.Room, roomChanged bool, timeout time.Duration, now int64) {
+	switch {
+	case !room.RequiresApproval:
+		booking.Status = domain.BookingStatusConfirmed
+		booking.ApprovalExpiresAt = 0
+	case roomChanged || booking.Status == domain.BookingStatusPending:
+		booking.RequestApproval(timeout, now)
+	}
+}
+
+// CheckPolicy applies the booking policy to a new booking for user in room,
+// made by an actor with actorRole. An imported booking is exempt from the
+// rules on when it starts, and counts against the quota only while it lasts.
+func (s *bookingService) CheckPolicy(user *domain.User, actorRole string, room *domain.Room, start, end int64, imported bool) error {
+	adding := 1
+	if imported && end <= time.Now().Unix() {
+		adding = 0
+	}
+	window := policyWindow{start: start, end: end, movedStart: true, imported: imported}
+	return checkPolicy(s.policy, s.repo, user, actorRole, room, []policyWindow{window}, adding)
+}
+
+type policyWindow struct {
+	start, end int64
+	// movedStart is false for a rescheduled booking that keeps its start,
+	// which may already have passed when an ongoing meeting is extended.
+	movedStart bool
+	// imported windows come from an existing calendar and may lie anywhere
+	// in time.
+	imported bool
+}
+
+// checkPolicy reports every rule of policy, as overridden by room, that
+// windows break when booked for user, counting adding new bookings against
+// the user's quota. Actors whose role may bypass the policy are exempt,
+// whoever the booking is for.
+func checkPolicy(policy domain.BookingPolicy, bookingRepo ports.BookingRepository, user *domain.User, actorRole string, room *domain.Room, windows []policyWindow, adding int) error {
+	if domain.HasPermission(actorRole, domain.PermBookingsBypassPolicy) {
+		return nil
+	}
+
+	now := time.Now().Unix()
+	policy = policy.ForRoom(room)
+	var violations []domain.PolicyViolation
+	seen := map[string]bool{}
+	for _, w := range windows {
+		for _, v := range policy.CheckWindow(w.start, w.end, now) {
+			if seen[v.Rule] || (v.Rule == domain.PolicyRulePastStart && !w.movedStart) {
+				continue
+			}
+			if w.imported && (v.Rule == domain.PolicyRulePastStart || v.Rule == domain.PolicyRuleMaxAdvance) {
+				continue
+			}
+			seen[v.Rule] = true
+			violations = append(violations, v)
+		}
+	}
+
+	if adding > 0 && policy.MaxActiveBookings > 0 {
+		bookings, err := bookingRepo.GetByUserID(user.ID)
+		if err != nil && err != domain.ErrNotFound {
+			return err
+		}
+		active := 0
+		for _, b := range bookings {
+			if b.IsActive() && b.EndTime > now {
+				active++
+			}
+		}
+		violations = append(violations, policy.CheckQuota(active, adding)...)
+	}
+	return domain.NewPolicyError(violations)
+}
+
+// checkTimeRange reports whether [start, end) is a window a booking can take.
+func checkTimeRange(start, end int64) error {
+	if !utils.IsTimeRangeValid(start, end) {
+		return domain.ErrTimeRangeInvalid
+	}
+	return nil
+}
+
+// checkSlot reports why room cannot be booked for [start, end), or nil when
+// it is free.
+func checkSlot(bookingRepo ports.BookingRepository, blockRepo ports.RoomBlockRepository, room *domain.Room, start, end int64) error {
+	if !room.AcceptsBookings() {
+		return domain.ErrRoomNotBookable
+	}
+
+	blocks, err := overlappingBlocks(blockRepo, room.ID, start, end)
+	if err != nil {
+		return err
+	}
+	if len(blocks) > 0 {
+		return domain.ErrRoomBlocked
+	}
+
+	existing, err := bookingRepo.GetByRoomAndTime(room.ID, start, end)
+	if err != nil {
+		return err
+	}
+	for _, b := range existing {
+		if utils.Overlaps(start, end, b.StartTime, b.EndTime) {
+			return domain.ErrRoomUnavailable
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+)
+
+func TestBookingServiceRefusesSingleChangesToGroupBookings(t *testing.T) {
+	st := newTestStore(t, domain.BookingPolicy{})
+	owner := st.addUser(t, domain.UserRoleUser)
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+
+	var group []*domain.Booking
+	for i := 0; i < 2; i++ {
+		room := st.addRoom(t, "Building A")
+		booking := &domain.Booking{
+			ID:        room.ID,
+			UserID:    owner.ID,
+			RoomID:    room.ID,
+			StartTime: start.Unix(),
+			EndTime:   start.Add(time.Hour).Unix(),
+			Purpose:   "Offsite",
+			Status:    domain.BookingStatusConfirmed,
+			GroupID:   "group-1",
+		}
+		if err := st.bookings.Create(booking); err != nil {
+			t.Fatalf("add group booking: %v", err)
+		}
+		group = append(group, booking)
+	}
+
+	later := start.Add(2 * time.Hour).Unix()
+	laterEnd := start.Add(3 * time.Hour).Unix()
+	_, err := st.bookingSv.UpdateBooking(group[0].ID, domain.BookingUpdate{StartTime: &later, EndTime: &laterEnd}, owner.Role)
+	if err != domain.ErrGroupMember {
+		t.Errorf("UpdateBooking = %v, want ErrGroupMember", err)
+	}
+	if err := st.bookingSv.CancelBooking(group[1].ID, owner.ID, ""); err != domain.ErrGroupMember {
+		t.Errorf("CancelBooking = %v, want ErrGroupMember", err)
+	}
+
+	for _, want := range group {
+		got, err := st.bookings.GetByID(want.ID)
+		if err != nil {
+			t.Fatalf("get booking: %v", err)
+		}
+		if got.Status != domain.BookingStatusConfirmed || got.StartTime != want.StartTime {
+			t.Errorf("booking %s = %s from %d, want it confirmed and unmoved", got.ID, got.Status, got.StartTime)
+		}
+	}
+
+	// Ungrouped bookings are unaffected.
+	single := st.addBooking(t, owner.ID, st.addRoom(t, "Building A").ID, start, start.Add(time.Hour))
+	if _, err := st.bookingSv.UpdateBooking(single.ID, domain.BookingUpdate{StartTime: &later, EndTime: &laterEnd}, owner.Role); err != nil {
+		t.Errorf("UpdateBooking of an ungrouped booking: %v", err)
+	}
+	if err := st.bookingSv.CancelBooking(single.ID, owner.ID, ""); err != nil {
+		t.Errorf("CancelBooking of an ungrouped booking: %v", err)
+	}
+}
+
+func TestBookingServiceCheckPolicyExemptsOnlyActorsWhoMayBypassIt(t *testing.T) {
+	st := newTestStore(t, domain.BookingPolicy{MaxDuration: time.Hour, MaxActiveBookings: 1})
+	owner := st.addUser(t, domain.UserRoleUser)
+	room := st.addRoom(t, "Building A")
+	longRoom := st.addRoom(t, "Building A")
+	longRoom.BookingPolicy = domain.RoomPolicy{MaxDurationSeconds: 3 * 3600}
+
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	st.addBooking(t, owner.ID, room.ID, start, start.Add(time.Hour))
+	other := st.addUser(t, domain.UserRoleUser)
+
+	tests := []struct {
+		name      string
+		user      *domain.User
+		actorRole string
+		room      *domain.Room
+		want      []string
+	}{
+		{name: "owner over both limits", user: owner, actorRole: domain.UserRoleUser, room: room, want: []string{domain.PolicyRuleMaxDuration, domain.PolicyRuleActiveQuota}},
+		{name: "receptionist booking for the owner", user: owner, actorRole: domain.UserRoleReceptionist, room: room, want: []string{domain.PolicyRuleMaxDuration, domain.PolicyRuleActiveQuota}},
+		{name: "admin booking for the owner", user: owner, actorRole: domain.UserRoleAdmin, room: room},
+		{name: "room override allows the length", user: other, actorRole: domain.UserRoleUser, room: longRoom},
+		{name: "quota counts across rooms", user: owner, actorRole: domain.UserRoleUser, room: longRoom, want: []string{domain.PolicyRuleActiveQuota}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from := start.Add(2 * time.Hour)
+			err := st.bookingSv.CheckPolicy(tt.user, tt.actorRole, tt.room, from.Unix(), from.Add(2*time.Hour).Unix(), false)
+			var got []string
+			if err != nil {
+				policyErr, ok := domain.AsPolicyError(err)
+				if !ok {
+					t.Fatalf("CheckPolicy() = %v, want a policy error or nil", err)
+				}
+				for _, v := range policyErr.Violations {
+					got = append(got, v.Rule)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CheckPolicy() broke %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"time"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/ports"
+	"github.com/amangirdhar210/meeting-room/internal/pkg/utils"
+	"github.com/google/uuid"
+)
+
+type calendarService struct {
+	tokenRepo   ports.CalendarTokenRepository
+	bookingRepo ports.BookingRepository
+	roomRepo    ports.RoomRepository
+	userRepo    ports.UserRepository
+}
+
+func NewCalendarService(tRepo ports.CalendarTokenRepository, bRepo ports.BookingRepository, rRepo ports.RoomRepository, uRepo ports.UserRepository) CalendarService {
+	return &calendarService{
+		tokenRepo:   tRepo,
+		bookingRepo: bRepo,
+		roomRepo:    rRepo,
+		userRepo:    uRepo,
+	}
+}
+
+func (s *calendarService) CreateToken(userID string) (string, *domain.CalendarToken, error) {
+	if userID == "" {
+		return "", nil, domain.ErrInvalidInput
+	}
+	if _, err := s.userRepo.GetByID(userID); err != nil {
+		return "", nil, err
+	}
+
+	secret, err := utils.GenerateSecureToken()
+	if err != nil {
+		return "", nil, err
+	}
+
+	token := &domain.CalendarToken{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		TokenHash: utils.HashToken(secret),
+		CreatedAt: time.Now().Unix(),
+	}
+	if err := s.tokenRepo.Create(token); err != nil {
+		return "", nil, err
+	}
+	return secret, token, nil
+}
+
+func (s *calendarService) ListTokens(userID string) ([]domain.CalendarToken, error) {
+	if userID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+	return s.tokenRepo.GetByUserID(userID)
+}
+
+func (s *calendarService) RevokeToken(userID, tokenID string) error {
+	if userID == "" || tokenID == "" {
+		return domain.ErrInvalidInput
+	}
+	return s.tokenRepo.Delete(tokenID, userID)
+}
+
+func (s *calendarService) GetUserFeed(userID, secret string) (*domain.CalendarFeed, error) {
+	owner, err := s.authorize(secret)
+	if err != nil {
+		return nil, err
+	}
+	if owner.ID != userID {
+		return nil, domain.ErrUnauthorized
+	}
+
+	bookings, err := s.bookingRepo.GetByUserID(userID)
+	if err != nil && err != domain.ErrNotFound {
+		return nil, err
+	}
+
+	rooms := make(map[string]*domain.Room)
+	feed := &domain.CalendarFeed{Name: owner.Name + " - Meeting Rooms"}
+	for _, booking := range bookings {
+		room, ok := rooms[booking.RoomID]
+		if !ok {
+			room, err = s.roomRepo.GetByID(booking.RoomID)
+			if err != nil && err != domain.ErrNotFound {
+				return nil, err
+			}
+			rooms[booking.RoomID] = room
+		}
+
+		detailed := domain.BookingWithDetails{Booking: booking, UserName: owner.Name, UserEmail: owner.Email}
+		if room != nil {
+			detailed.RoomName = room.Name
+			detailed.RoomNumber = room.RoomNumber
+		}
+		feed.Bookings = append(feed.Bookings, detailed)
+	}
+	return feed, nil
+}
+
+func (s *calendarService) GetRoomFeed(roomID, secret string) (*domain.CalendarFeed, error) {
+	if _, err := s.authorize(secret); err != nil {
+		return nil, err
+	}
+
+	room, err := s.roomRepo.GetByID(roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	bookings, err := s.bookingRepo.GetByRoomID(roomID)
+	if err != nil && err != domain.ErrNotFound {
+		return nil, err
+	}
+
+	users := make(map[string]*domain.User)
+	feed := &domain.CalendarFeed{Name: room.Name}
+	for _, booking := range bookings {
+		user, ok := users[booking.UserID]
+		if !ok {
+			user, err = s.userRepo.GetByID(booking.UserID)
+			if err != nil && err != domain.ErrNotFound {
+				return nil, err
+			}
+			users[booking.UserID] = user
+		}
+
+		detailed := domain.BookingWithDetails{Booking: booking, RoomName: room.Name, RoomNumber: room.RoomNumber}
+		if user != nil {
+			detailed.UserName = user.Name
+			detailed.UserEmail = user.Email
+		}
+		feed.Bookings = append(feed.Bookings, detailed)
+	}
+	return feed, nil
+}
+
+// authorize resolves a feed secret to the user who owns it. Unknown secrets
+// and secrets of deleted users are both reported as ErrUnauthorized.
+func (s *calendarService) authorize(secret string) (*domain.User, error) {
+	if secret == "" {
+		return nil, domain.ErrUnauthorized
+	}
+
+	token, err := s.tokenRepo.GetByHash(utils.HashToken(secret))
+	if err == domain.ErrNotFound {
+		return nil, domain.ErrUnauthorized
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(token.UserID)
+	if err == domain.ErrNotFound {
+		return nil, domain.ErrUnauthorized
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user.IsDisabled() {
+		return nil, domain.ErrUnauthorized
+	}
+	return user, nil
+}
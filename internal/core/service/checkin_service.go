@@ -0,0 +1,242 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/ports"
+	"github.com/amangirdhar210/meeting-room/internal/pkg/utils"
+	"github.com/google/uuid"
+)
+
+type checkInService struct {
+	bookingRepo    ports.BookingRepository
+	roomRepo       ports.RoomRepository
+	userRepo       ports.UserRepository
+	kioskRepo      ports.KioskTokenRepository
+	bookingService BookingService
+	notifier       ports.Notifier
+	// opensBefore is how long before the start check-in opens, grace how
+	// long after it a booking nobody checked in to is released.
+	opensBefore time.Duration
+	grace       time.Duration
+}
+
+func NewCheckInService(bRepo ports.BookingRepository, rRepo ports.RoomRepository, uRepo ports.UserRepository, kRepo ports.KioskTokenRepository, bookingService BookingService, notifier ports.Notifier, opensBefore, grace time.Duration) CheckInService {
+	return &checkInService{
+		bookingRepo:    bRepo,
+		roomRepo:       rRepo,
+		userRepo:       uRepo,
+		kioskRepo:      kRepo,
+		bookingService: bookingService,
+		notifier:       notifier,
+		opensBefore:    opensBefore,
+		grace:          grace,
+	}
+}
+
+// CheckIn checks in the owner of a booking. People who may manage any
+// booking can check in on someone's behalf.
+func (s *checkInService) CheckIn(bookingID, userID string) (*domain.Booking, error) {
+	if bookingID == "" || userID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsDisabled() {
+		return nil, domain.ErrUserDisabled
+	}
+
+	booking, err := s.bookingRepo.GetByID(bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if booking.UserID != user.ID && !domain.HasPermission(user.Role, domain.PermBookingsManageAny) {
+		return nil, domain.ErrNotBookingOwner
+	}
+	return s.checkIn(booking)
+}
+
+// KioskCheckIn checks in a booking from the kiosk of its room.
+func (s *checkInService) KioskCheckIn(bookingID, secret string) (*domain.Booking, error) {
+	if bookingID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+	if secret == "" {
+		return nil, domain.ErrUnauthorized
+	}
+	token, err := s.kioskRepo.GetByHash(utils.HashToken(secret))
+	if err == domain.ErrNotFound {
+		return nil, domain.ErrUnauthorized
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	booking, err := s.bookingRepo.GetByID(bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if booking.RoomID != token.RoomID {
+		return nil, domain.ErrUnauthorized
+	}
+	return s.checkIn(booking)
+}
+
+func (s *checkInService) checkIn(booking *domain.Booking) (*domain.Booking, error) {
+	if booking.Status != domain.BookingStatusConfirmed {
+		return nil, domain.ErrBookingNotActive
+	}
+	if booking.CheckedInAt != 0 {
+		return nil, domain.ErrAlreadyCheckedIn
+	}
+
+	now := time.Now().Unix()
+	if now < booking.StartTime-int64(s.opensBefore.Seconds()) {
+		return nil, domain.ErrCheckInNotOpen
+	}
+	if booking.IsNoShow(s.grace, now) {
+		// Nobody checked in within the grace period, so the booking is
+		// released on the spot instead of by the next ReleaseNoShows.
+		if err := s.release(booking, now); err != nil && err != domain.ErrBookingNotActive && err != domain.ErrAlreadyCheckedIn {
+			return nil, err
+		}
+		return nil, domain.ErrCheckInClosed
+	}
+
+	if err := s.bookingRepo.CheckIn(booking.ID, now); err != nil {
+		return nil, err
+	}
+	booking.CheckedInAt = now
+	booking.UpdatedAt = now
+	booking.Sequence++
+	return booking, nil
+}
+
+// ReleaseNoShows marks the bookings nobody checked in to as no-shows, frees
+// the rest of their time and returns how many it released. Bookings that
+// ended more than a grace period ago are left alone, so turning check-in on
+// does not rewrite history.
+func (s *checkInService) ReleaseNoShows() (int, error) {
+	now := time.Now().Unix()
+	bookings, err := s.bookingRepo.GetAwaitingCheckIn(now, now-int64(s.grace.Seconds()))
+	if err != nil && err != domain.ErrNotFound {
+		return 0, err
+	}
+
+	released := 0
+	for i := range bookings {
+		booking := &bookings[i]
+		if !booking.IsNoShow(s.grace, now) {
+			continue
+		}
+		err := s.release(booking, now)
+		if err == domain.ErrBookingNotActive || err == domain.ErrAlreadyCheckedIn {
+			continue
+		}
+		if err != nil {
+			return released, err
+		}
+		released++
+	}
+	return released, nil
+}
+
+// CompleteEnded marks confirmed bookings that are over as completed. A
+// booking nobody checked in to is only completed a grace period after it
+// ended, so ReleaseNoShows gets to release it as a no-show first.
+func (s *checkInService) CompleteEnded() (int, error) {
+	now := time.Now().Unix()
+	return s.bookingRepo.CompleteEnded(now, now-int64(s.grace.Seconds()), now)
+}
+
+func (s *checkInService) release(booking *domain.Booking, now int64) error {
+	if err := s.bookingRepo.MarkNoShow(booking.ID, now); err != nil {
+		return err
+	}
+	booking.Status = domain.BookingStatusNoShow
+	booking.ReleasedAt = now
+	booking.UpdatedAt = now
+	booking.Sequence++
+
+	s.notifyRelease(*booking)
+	s.bookingService.ReleaseSlot(booking.RoomID, booking.StartTime, booking.EndTime)
+	return nil
+}
+
+func (s *checkInService) notifyRelease(booking domain.Booking) {
+	roomName := booking.RoomID
+	if room, err := s.roomRepo.GetByID(booking.RoomID); err == nil && room != nil {
+		roomName = room.Name
+	}
+	user, err := s.userRepo.GetByID(booking.UserID)
+	if err != nil {
+		log.Printf("Cannot notify owner of booking %s: %v", booking.ID, err)
+		return
+	}
+
+	at := time.Unix(booking.StartTime, 0).UTC().Format(time.RFC3339)
+	err = s.notifier.Notify(domain.Notification{
+		UserID:  user.ID,
+		Email:   user.Email,
+		Name:    user.Name,
+		Subject: "Booking released",
+		Message: fmt.Sprintf("Nobody checked in to your booking %q in %s at %s, so the room has been released for others.", booking.Purpose, roomName, at),
+	})
+	if err != nil {
+		log.Printf("Failed to notify %s about booking %s: %v", user.Email, booking.ID, err)
+	}
+}
+
+func (s *checkInService) CreateKioskToken(roomID, name, createdBy string) (string, *domain.KioskToken, error) {
+	if roomID == "" || createdBy == "" {
+		return "", nil, domain.ErrInvalidInput
+	}
+	room, err := s.roomRepo.GetByID(roomID)
+	if err != nil {
+		return "", nil, err
+	}
+	if room.DeletedAt != 0 {
+		return "", nil, domain.ErrNotFound
+	}
+
+	secret, err := utils.GenerateSecureToken()
+	if err != nil {
+		return "", nil, err
+	}
+
+	token := &domain.KioskToken{
+		ID:        uuid.New().String(),
+		RoomID:    room.ID,
+		Name:      strings.TrimSpace(name),
+		TokenHash: utils.HashToken(secret),
+		CreatedBy: createdBy,
+		CreatedAt: time.Now().Unix(),
+	}
+	if err := s.kioskRepo.Create(token); err != nil {
+		return "", nil, err
+	}
+	return secret, token, nil
+}
+
+func (s *checkInService) ListKioskTokens(roomID string) ([]domain.KioskToken, error) {
+	if roomID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+	if _, err := s.roomRepo.GetByID(roomID); err != nil {
+		return nil, err
+	}
+	return s.kioskRepo.GetByRoomID(roomID)
+}
+
+func (s *checkInService) RevokeKioskToken(roomID, tokenID string) error {
+	if roomID == "" || tokenID == "" {
+		return domain.ErrInvalidInput
+	}
+	return s.kioskRepo.Delete(tokenID, roomID)
+}
@@ -0,0 +1,352 @@
+package service
+
+import (
+	"strings"
+	"time"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/ports"
+	"github.com/amangirdhar210/meeting-room/internal/pkg/utils"
+	"github.com/google/uuid"
+)
+
+type bookingGroupService struct {
+	bookingRepo    ports.BookingRepository
+	roomRepo       ports.RoomRepository
+	userRepo       ports.UserRepository
+	blockRepo      ports.RoomBlockRepository
+	bookingService BookingService
+	// approvalTimeout is how long approvers have to answer the bookings of
+	// a group in rooms that require approval.
+	approvalTimeout time.Duration
+	policy          domain.BookingPolicy
+}
+
+func NewBookingGroupService(bRepo ports.BookingRepository, rRepo ports.RoomRepository, uRepo ports.UserRepository, blRepo ports.RoomBlockRepository, bookingService BookingService, approvalTimeout time.Duration, policy domain.BookingPolicy) BookingGroupService {
+	return &bookingGroupService{
+		bookingRepo:     bRepo,
+		roomRepo:        rRepo,
+		userRepo:        uRepo,
+		blockRepo:       blRepo,
+		bookingService:  bookingService,
+		approvalTimeout: approvalTimeout,
+		policy:          policy,
+	}
+}
+
+func (s *bookingGroupService) CreateGroup(group *domain.BookingGroup) (*domain.GroupResult, error) {
+	if group == nil || group.UserID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+	if err := checkTimeRange(group.StartTime, group.EndTime); err != nil {
+		return nil, err
+	}
+	if !hasDistinctRooms(group.RoomIDs) {
+		return nil, domain.ErrInvalidGroup
+	}
+
+	user, err := s.userRepo.GetByID(group.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsDisabled() {
+		return nil, domain.ErrUserDisabled
+	}
+	rooms := make([]*domain.Room, 0, len(group.RoomIDs))
+	windows := make([]policyWindow, 0, len(group.RoomIDs))
+	for _, roomID := range group.RoomIDs {
+		room, err := s.roomRepo.GetByID(roomID)
+		if err != nil {
+			return nil, err
+		}
+		rooms = append(rooms, room)
+		windows = append(windows, policyWindow{start: group.StartTime, end: group.EndTime, movedStart: true})
+	}
+	if err := s.checkPolicy(user, user.Role, rooms, windows, len(rooms)); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	group.ID = uuid.New().String()
+	bookings := make([]domain.Booking, 0, len(rooms))
+	for _, room := range rooms {
+		booking := domain.Booking{
+			ID:        uuid.New().String(),
+			UserID:    group.UserID,
+			RoomID:    room.ID,
+			StartTime: group.StartTime,
+			EndTime:   group.EndTime,
+			Purpose:   group.Purpose,
+			GroupID:   group.ID,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		settleApproval(&booking, room, true, s.approvalTimeout, now)
+		bookings = append(bookings, booking)
+	}
+
+	return s.write(group.ID, rooms, bookings, s.bookingRepo.CreateAll)
+}
+
+func (s *bookingGroupService) GetGroup(groupID string) ([]domain.Booking, error) {
+	if groupID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	bookings, err := s.bookingRepo.GetByGroupID(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if len(bookings) == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return bookings, nil
+}
+
+// UpdateGroup moves or renames every active booking of the group together.
+// A group keeps its rooms, so update cannot change RoomID.
+func (s *bookingGroupService) UpdateGroup(groupID string, update domain.BookingUpdate, actorRole string) (*domain.GroupResult, error) {
+	if update.RoomID != nil || (update.StartTime == nil && update.EndTime == nil && update.Purpose == nil) {
+		return nil, domain.ErrInvalidInput
+	}
+
+	members, err := s.activeMembers(groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	rescheduled := update.StartTime != nil || update.EndTime != nil
+	previous := append([]domain.Booking(nil), members...)
+	now := time.Now().Unix()
+	rooms := make([]*domain.Room, 0, len(members))
+	windows := make([]policyWindow, 0, len(members))
+	for i := range members {
+		member := &members[i]
+		previousStart := member.StartTime
+		if update.StartTime != nil {
+			member.StartTime = *update.StartTime
+		}
+		if update.EndTime != nil {
+			member.EndTime = *update.EndTime
+		}
+		if update.Purpose != nil {
+			member.Purpose = *update.Purpose
+		}
+		if err := checkTimeRange(member.StartTime, member.EndTime); err != nil {
+			return nil, err
+		}
+		member.UpdatedAt = now
+
+		room, err := s.roomRepo.GetByID(member.RoomID)
+		if err != nil {
+			return nil, err
+		}
+		if rescheduled {
+			settleApproval(member, room, false, s.approvalTimeout, now)
+		}
+		rooms = append(rooms, room)
+		windows = append(windows, policyWindow{start: member.StartTime, end: member.EndTime, movedStart: member.StartTime != previousStart})
+	}
+
+	if !rescheduled {
+		if err := s.bookingRepo.UpdateAll(members); err != nil {
+			return nil, err
+		}
+		return &domain.GroupResult{GroupID: groupID, Bookings: members}, nil
+	}
+
+	owner, err := s.userRepo.GetByID(members[0].UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkPolicy(owner, actorRole, rooms, windows, 0); err != nil {
+		return nil, err
+	}
+	result, err := s.write(groupID, rooms, members, s.bookingRepo.UpdateAll)
+	if err != nil {
+		return result, err
+	}
+	for _, member := range previous {
+		s.bookingService.ReleaseSlot(member.RoomID, member.StartTime, member.EndTime)
+	}
+	return result, nil
+}
+
+// CancelGroup cancels every active booking of the group and offers the freed
+// slots to the waitlist.
+func (s *bookingGroupService) CancelGroup(groupID, cancelledBy, reason string) ([]domain.Booking, error) {
+	members, err := s.activeMembers(groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(members))
+	for i, member := range members {
+		ids[i] = member.ID
+	}
+	now := time.Now().Unix()
+	reason = strings.TrimSpace(reason)
+	if err := s.bookingRepo.CancelAll(ids, cancelledBy, reason, now); err != nil {
+		return nil, err
+	}
+	for i := range members {
+		members[i].Status = domain.BookingStatusCancelled
+		members[i].CancelledAt = now
+		members[i].CancelledBy = cancelledBy
+		members[i].CancellationReason = reason
+	}
+
+	for _, member := range members {
+		s.bookingService.ReleaseSlot(member.RoomID, member.StartTime, member.EndTime)
+	}
+
+	return members, nil
+}
+
+// write checks every room is free for its booking and then writes them all
+// at once. When a room is taken nothing is written and the result names it;
+// a room taken between the check and the write is found by checking again.
+func (s *bookingGroupService) write(groupID string, rooms []*domain.Room, bookings []domain.Booking, writeAll func([]domain.Booking) error) (*domain.GroupResult, error) {
+	result := &domain.GroupResult{}
+
+	conflicts, err := s.conflicts(rooms, bookings)
+	if err != nil {
+		return nil, err
+	}
+	if len(conflicts) > 0 {
+		result.Conflicts = conflicts
+		return result, domain.ErrRoomUnavailable
+	}
+
+	err = writeAll(bookings)
+	if err == domain.ErrRoomUnavailable {
+		result.Conflicts, err = s.conflicts(rooms, bookings)
+		if err != nil {
+			return nil, err
+		}
+		return result, domain.ErrRoomUnavailable
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result.GroupID = groupID
+	result.Bookings = bookings
+	return result, nil
+}
+
+// conflicts lists the rooms that cannot take their booking, in the order of
+// the group.
+func (s *bookingGroupService) conflicts(rooms []*domain.Room, bookings []domain.Booking) ([]domain.RoomConflict, error) {
+	var conflicts []domain.RoomConflict
+	for i, room := range rooms {
+		conflict, err := s.roomConflict(room, bookings[i])
+		if err != nil {
+			return nil, err
+		}
+		if conflict != nil {
+			conflicts = append(conflicts, *conflict)
+		}
+	}
+	return conflicts, nil
+}
+
+// roomConflict reports why booking cannot be held in room, or nil when the
+// room is open and free.
+func (s *bookingGroupService) roomConflict(room *domain.Room, booking domain.Booking) (*domain.RoomConflict, error) {
+	conflict := domain.RoomConflict{RoomID: room.ID}
+
+	existing, err := s.bookingRepo.GetByRoomAndTime(room.ID, booking.StartTime, booking.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range existing {
+		if b.ID != booking.ID && utils.Overlaps(booking.StartTime, booking.EndTime, b.StartTime, b.EndTime) {
+			conflict.ConflictingBookingIDs = append(conflict.ConflictingBookingIDs, b.ID)
+		}
+	}
+
+	blocks, err := overlappingBlocks(s.blockRepo, room.ID, booking.StartTime, booking.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	for _, block := range blocks {
+		conflict.ConflictingBlockIDs = append(conflict.ConflictingBlockIDs, block.ID)
+	}
+
+	switch {
+	case !room.AcceptsBookings():
+		conflict.Reason = domain.ErrRoomNotBookable
+	case len(conflict.ConflictingBlockIDs) > 0:
+		conflict.Reason = domain.ErrRoomBlocked
+	case len(conflict.ConflictingBookingIDs) > 0:
+		conflict.Reason = domain.ErrRoomUnavailable
+	default:
+		return nil, nil
+	}
+	return &conflict, nil
+}
+
+// checkPolicy holds each booking to the policy of its room, windows[i]
+// being booked in rooms[i], and counts adding new bookings against the
+// user's quota once for the whole group.
+func (s *bookingGroupService) checkPolicy(user *domain.User, actorRole string, rooms []*domain.Room, windows []policyWindow, adding int) error {
+	var violations []domain.PolicyViolation
+	seen := map[string]bool{}
+	collect := func(err error) error {
+		policyErr, ok := domain.AsPolicyError(err)
+		if !ok {
+			return err
+		}
+		for _, v := range policyErr.Violations {
+			if !seen[v.Rule] {
+				seen[v.Rule] = true
+				violations = append(violations, v)
+			}
+		}
+		return nil
+	}
+
+	for i, room := range rooms {
+		if err := collect(checkPolicy(s.policy, s.bookingRepo, user, actorRole, room, windows[i:i+1], 0)); err != nil {
+			return err
+		}
+	}
+	if err := collect(checkPolicy(s.policy, s.bookingRepo, user, actorRole, nil, nil, adding)); err != nil {
+		return err
+	}
+	return domain.NewPolicyError(violations)
+}
+
+// activeMembers returns the bookings of the group that can still be changed.
+func (s *bookingGroupService) activeMembers(groupID string) ([]domain.Booking, error) {
+	bookings, err := s.GetGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	var members []domain.Booking
+	for _, booking := range bookings {
+		if booking.IsActive() {
+			members = append(members, booking)
+		}
+	}
+	if len(members) == 0 {
+		return nil, domain.ErrBookingNotActive
+	}
+	return members, nil
+}
+
+func hasDistinctRooms(roomIDs []string) bool {
+	if len(roomIDs) < 2 || len(roomIDs) > domain.MaxGroupRooms {
+		return false
+	}
+	seen := make(map[string]bool, len(roomIDs))
+	for _, roomID := range roomIDs {
+		if roomID == "" || seen[roomID] {
+			return false
+		}
+		seen[roomID] = true
+	}
+	return true
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/ports"
+	"github.com/amangirdhar210/meeting-room/internal/pkg/ical"
+	"github.com/amangirdhar210/meeting-room/internal/pkg/utils"
+)
+
+type bookingImportService struct {
+	bookingService BookingService
+	bookingRepo    ports.BookingRepository
+	roomRepo       ports.RoomRepository
+	userRepo       ports.UserRepository
+	blockRepo      ports.RoomBlockRepository
+}
+
+func NewBookingImportService(bookingService BookingService, bRepo ports.BookingRepository, rRepo ports.RoomRepository, uRepo ports.UserRepository, blRepo ports.RoomBlockRepository) BookingImportService {
+	return &bookingImportService{
+		bookingService: bookingService,
+		bookingRepo:    bRepo,
+		roomRepo:       rRepo,
+		userRepo:       uRepo,
+		blockRepo:      blRepo,
+	}
+}
+
+// ImportICS creates a booking for every VEVENT in data. Events are matched to
+// rooms through LOCATION and to users through the ORGANIZER e-mail; each one
+// gets its own result so a bad event never stops the rest of the import. With
+// dryRun set nothing is written and the report shows what would happen.
+// Events are booked as imports by an actor with actorRole, see ImportBooking.
+func (s *bookingImportService) ImportICS(data io.Reader, dryRun bool, actorRole string) (*domain.ImportReport, error) {
+	cal, err := ical.Parse(data)
+	if err != nil {
+		return nil, domain.ErrInvalidInput
+	}
+
+	rooms, err := s.roomRepo.GetAll()
+	if err != nil && err != domain.ErrNotFound {
+		return nil, err
+	}
+
+	report := &domain.ImportReport{DryRun: dryRun, Counts: make(map[string]int)}
+	users := make(map[string]*domain.User)
+	var planned []domain.Booking
+	for _, component := range cal.Children("VEVENT") {
+		result, err := s.importEvent(component, rooms, users, &planned, dryRun, actorRole)
+		if err != nil {
+			return nil, err
+		}
+		report.Counts[result.Status]++
+		report.Results = append(report.Results, result)
+	}
+	return report, nil
+}
+
+func (s *bookingImportService) importEvent(component ical.Component, rooms []domain.Room, users map[string]*domain.User, planned *[]domain.Booking, dryRun bool, actorRole string) (domain.ImportResult, error) {
+	event, err := ical.DecodeEvent(component, time.UTC)
+	result := domain.ImportResult{UID: event.UID, Summary: event.Summary}
+	if !event.Start.IsZero() {
+		result.StartTime = event.Start.Unix()
+	}
+	if !event.End.IsZero() {
+		result.EndTime = event.End.Unix()
+	}
+	if err != nil {
+		result.Status = domain.ImportStatusInvalid
+		result.Message = err.Error()
+		return result, nil
+	}
+
+	switch {
+	case event.Status == ical.StatusCancelled:
+		result.Status = domain.ImportStatusSkipped
+		result.Message = "event is cancelled"
+		return result, nil
+	case event.RRule != "":
+		result.Status = domain.ImportStatusSkipped
+		result.Message = "recurring events are not imported, create a booking series instead"
+		return result, nil
+	}
+
+	room := matchRoom(rooms, event.Location)
+	if room == nil {
+		result.Status = domain.ImportStatusUnknownRoom
+		result.Message = "no room matches location " + strconv.Quote(event.Location)
+		return result, nil
+	}
+	result.RoomID = room.ID
+
+	user, err := s.findOrganizer(event.OrganizerEmail, users)
+	if err != nil {
+		return result, err
+	}
+	if user == nil {
+		result.Status = domain.ImportStatusUnknownOrganizer
+		result.Message = "no user with e-mail " + strconv.Quote(event.OrganizerEmail)
+		return result, nil
+	}
+	result.UserID = user.ID
+
+	booking := domain.Booking{
+		UserID:    user.ID,
+		RoomID:    room.ID,
+		StartTime: result.StartTime,
+		EndTime:   result.EndTime,
+		Purpose:   event.Summary,
+	}
+	if dryRun {
+		err = s.checkBooking(user, actorRole, *room, booking, *planned)
+	} else {
+		err = s.bookingService.ImportBooking(&booking, actorRole)
+	}
+
+	switch err {
+	case nil:
+		result.Status = domain.ImportStatusCreated
+		result.BookingID = booking.ID
+		*planned = append(*planned, booking)
+	case domain.ErrRoomUnavailable, domain.ErrRoomNotBookable, domain.ErrRoomBlocked:
+		result.Status = domain.ImportStatusConflict
+		result.Message = err.Error()
+	case domain.ErrTimeRangeInvalid, domain.ErrBookingTooLong, domain.ErrInvalidInput:
+		result.Status = domain.ImportStatusInvalid
+		result.Message = err.Error()
+	default:
+		if _, ok := domain.AsPolicyError(err); !ok {
+			return result, err
+		}
+		result.Status = domain.ImportStatusInvalid
+		result.Message = err.Error()
+	}
+	return result, nil
+}
+
+// checkBooking runs the same checks as ImportBooking without writing, also
+// counting the bookings earlier events of the same dry run would create.
+func (s *bookingImportService) checkBooking(user *domain.User, actorRole string, room domain.Room, booking domain.Booking, planned []domain.Booking) error {
+	if err := checkTimeRange(booking.StartTime, booking.EndTime); err != nil {
+		return err
+	}
+	if err := s.bookingService.CheckPolicy(user, actorRole, &room, booking.StartTime, booking.EndTime, true); err != nil {
+		return err
+	}
+	if !room.AcceptsBookings() {
+		return domain.ErrRoomNotBookable
+	}
+	blocks, err := overlappingBlocks(s.blockRepo, booking.RoomID, booking.StartTime, booking.EndTime)
+	if err != nil {
+		return err
+	}
+	if len(blocks) > 0 {
+		return domain.ErrRoomBlocked
+	}
+
+	existing, err := s.bookingRepo.GetByRoomAndTime(booking.RoomID, booking.StartTime, booking.EndTime)
+	if err != nil && err != domain.ErrNotFound {
+		return err
+	}
+	for _, b := range append(existing, planned...) {
+		if b.RoomID == booking.RoomID && utils.Overlaps(booking.StartTime, booking.EndTime, b.StartTime, b.EndTime) {
+			return domain.ErrRoomUnavailable
+		}
+	}
+	return nil
+}
+
+func (s *bookingImportService) findOrganizer(email string, cache map[string]*domain.User) (*domain.User, error) {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return nil, nil
+	}
+	if user, ok := cache[strings.ToLower(email)]; ok {
+		return user, nil
+	}
+
+	user, err := s.userRepo.FindByEmail(email)
+	if err == domain.ErrNotFound && email != strings.ToLower(email) {
+		user, err = s.userRepo.FindByEmail(strings.ToLower(email))
+	}
+	if err == domain.ErrNotFound {
+		user, err = nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	cache[strings.ToLower(email)] = user
+	return user, nil
+}
+
+var roomNumberPattern = regexp.MustCompile(`\d+`)
+
+// matchRoom finds the room a LOCATION refers to, first by name and then by
+// any room number mentioned in it. Locations of our own feeds look like
+// "Everest (Room 101)" and match on either.
+func matchRoom(rooms []domain.Room, location string) *domain.Room {
+	location = strings.TrimSpace(location)
+	if location == "" {
+		return nil
+	}
+
+	name, _, _ := strings.Cut(location, " (")
+	for _, candidate := range []string{location, name} {
+		for i := range rooms {
+			if strings.EqualFold(strings.TrimSpace(rooms[i].Name), candidate) {
+				return &rooms[i]
+			}
+		}
+	}
+
+	for _, digits := range roomNumberPattern.FindAllString(location, -1) {
+		number, err := strconv.Atoi(digits)
+		if err != nil {
+			continue
+		}
+		for i := range rooms {
+			if rooms[i].RoomNumber == number {
+				return &rooms[i]
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/ports"
+	"github.com/amangirdhar210/meeting-room/internal/pkg/utils"
+	"github.com/google/uuid"
+)
+
+// oidcLoginTimeout is how long a user has to finish signing in at the
+// identity provider.
+const oidcLoginTimeout = 10 * time.Minute
+
+type oidcService struct {
+	provider     ports.OIDCProvider
+	stateRepo    ports.OIDCLoginStateRepository
+	identityRepo ports.UserIdentityRepository
+	userRepo     ports.UserRepository
+	authService  AuthService
+	defaultRole  string
+	groupRoles   map[string]string
+}
+
+func NewOIDCService(provider ports.OIDCProvider, stateRepo ports.OIDCLoginStateRepository, identityRepo ports.UserIdentityRepository, userRepo ports.UserRepository, authService AuthService, defaultRole string, groupRoles map[string]string) OIDCService {
+	return &oidcService{
+		provider:     provider,
+		stateRepo:    stateRepo,
+		identityRepo: identityRepo,
+		userRepo:     userRepo,
+		authService:  authService,
+		defaultRole:  defaultRole,
+		groupRoles:   groupRoles,
+	}
+}
+
+func (s *oidcService) BeginLogin() (string, string, error) {
+	state, err := utils.GenerateSecureToken()
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := utils.GenerateSecureToken()
+	if err != nil {
+		return "", "", err
+	}
+	verifier, err := utils.GenerateSecureToken()
+	if err != nil {
+		return "", "", err
+	}
+
+	now := time.Now()
+	err = s.stateRepo.Create(&domain.OIDCLoginState{
+		State:        state,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		ExpiresAt:    now.Add(oidcLoginTimeout).Unix(),
+		CreatedAt:    now.Unix(),
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	challenge := sha256.Sum256([]byte(verifier))
+	authURL, err := s.provider.AuthCodeURL(state, nonce, base64.RawURLEncoding.EncodeToString(challenge[:]))
+	if err != nil {
+		return "", "", err
+	}
+	return authURL, state, nil
+}
+
+func (s *oidcService) CompleteLogin(state, code string) (*domain.AuthTokens, *domain.User, error) {
+	state = strings.TrimSpace(state)
+	code = strings.TrimSpace(code)
+	if state == "" || code == "" {
+		return nil, nil, domain.ErrInvalidInput
+	}
+
+	loginState, err := s.stateRepo.Consume(state)
+	if err == domain.ErrNotFound {
+		return nil, nil, domain.ErrInvalidOIDCState
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if time.Now().Unix() >= loginState.ExpiresAt {
+		return nil, nil, domain.ErrInvalidOIDCState
+	}
+
+	identity, err := s.provider.Exchange(code, loginState.CodeVerifier, loginState.Nonce)
+	if err != nil {
+		log.Printf("OIDC code exchange failed: %v", err)
+		return nil, nil, domain.ErrOIDCLoginFailed
+	}
+
+	user, err := s.resolveUser(identity)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tokens, err := s.authService.StartSession(user)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tokens, user, nil
+}
+
+// resolveUser finds the user linked to the identity. An unlinked identity is
+// linked to the user with the same verified email, or gets a new user.
+func (s *oidcService) resolveUser(identity *domain.OIDCIdentity) (*domain.User, error) {
+	link, err := s.identityRepo.GetBySubject(identity.Issuer, identity.Subject)
+	if err != nil && err != domain.ErrNotFound {
+		return nil, err
+	}
+	if link != nil {
+		user, err := s.userRepo.GetByID(link.UserID)
+		if err != nil {
+			return nil, err
+		}
+		return s.syncRole(user, identity)
+	}
+
+	email := strings.TrimSpace(identity.Email)
+	if email == "" {
+		log.Printf("OIDC login for subject %s has no email", identity.Subject)
+		return nil, domain.ErrOIDCLoginFailed
+	}
+
+	user, err := s.userRepo.FindByEmail(email)
+	if err != nil && err != domain.ErrNotFound {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	if user != nil {
+		// Taking over an existing account needs proof the email is theirs.
+		if !identity.EmailVerified {
+			log.Printf("OIDC login for %s rejected: email not verified by the identity provider", email)
+			return nil, domain.ErrOIDCLoginFailed
+		}
+	} else {
+		name := strings.TrimSpace(identity.Name)
+		if name == "" {
+			name, _, _ = strings.Cut(email, "@")
+		}
+		user = &domain.User{
+			ID:        uuid.New().String(),
+			Name:      name,
+			Email:     email,
+			Role:      s.roleFor(identity.Groups),
+			Status:    domain.UserStatusActive,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		if err := s.userRepo.Create(user); err != nil {
+			return nil, err
+		}
+		log.Printf("Provisioned user %s (%s) from single sign-on", user.ID, user.Email)
+	}
+
+	err = s.identityRepo.Create(&domain.UserIdentity{
+		Issuer:    identity.Issuer,
+		Subject:   identity.Subject,
+		UserID:    user.ID,
+		CreatedAt: now,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.syncRole(user, identity)
+}
+
+// syncRole keeps the role in line with the identity provider's groups when
+// a group mapping is configured; without one, roles are managed here.
+func (s *oidcService) syncRole(user *domain.User, identity *domain.OIDCIdentity) (*domain.User, error) {
+	if user.IsDisabled() {
+		return nil, domain.ErrUserDisabled
+	}
+	if len(s.groupRoles) == 0 {
+		return user, nil
+	}
+
+	role := s.roleFor(identity.Groups)
+	if role == user.Role {
+		return user, nil
+	}
+
+	// Tokens issued with the old role must not outlive the change.
+	if err := s.authService.RevokeUserSessions(user.ID); err != nil {
+		return nil, err
+	}
+	user.Role = role
+	user.UpdatedAt = time.Now().Unix()
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// roleFor returns the mapped role that comes first in domain.UserRoles, so
+// a user in several groups gets the most privileged one.
+func (s *oidcService) roleFor(groups []string) string {
+	mapped := make(map[string]bool)
+	for _, group := range groups {
+		if role, ok := s.groupRoles[group]; ok {
+			mapped[role] = true
+		}
+	}
+	for _, role := range domain.UserRoles {
+		if mapped[role] {
+			return role
+		}
+	}
+	return s.defaultRole
+}
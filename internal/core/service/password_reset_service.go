@@ -0,0 +1,161 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/ports"
+	"github.com/amangirdhar210/meeting-room/internal/pkg/utils"
+	"github.com/google/uuid"
+)
+
+type passwordResetService struct {
+	tokenRepo      ports.PasswordResetTokenRepository
+	userRepo       ports.UserRepository
+	passwordHasher ports.PasswordHasher
+	notifier       ports.Notifier
+	authService    AuthService
+	tokenTTL       time.Duration
+	resetURL       string
+}
+
+func NewPasswordResetService(tRepo ports.PasswordResetTokenRepository, uRepo ports.UserRepository, hasher ports.PasswordHasher, notifier ports.Notifier, authService AuthService, tokenTTL time.Duration, resetURL string) PasswordResetService {
+	return &passwordResetService{
+		tokenRepo:      tRepo,
+		userRepo:       uRepo,
+		passwordHasher: hasher,
+		notifier:       notifier,
+		authService:    authService,
+		tokenTTL:       tokenTTL,
+		resetURL:       resetURL,
+	}
+}
+
+// RequestReset mails a reset token to the account with the given email. It
+// succeeds for unknown and disabled accounts too, so callers cannot probe
+// which emails are registered.
+func (s *passwordResetService) RequestReset(email string) error {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return domain.ErrInvalidInput
+	}
+
+	user, err := s.userRepo.FindByEmail(email)
+	if err == domain.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	// Single sign-on users have no password here to reset.
+	if user.IsDisabled() || !user.HasPassword() {
+		return nil
+	}
+
+	// Only the newest token is valid.
+	if err := s.tokenRepo.DeleteByUserID(user.ID); err != nil {
+		return err
+	}
+
+	secret, err := utils.GenerateSecureToken()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	token := &domain.PasswordResetToken{
+		ID:        uuid.New().String(),
+		UserID:    user.ID,
+		TokenHash: utils.HashToken(secret),
+		ExpiresAt: now.Add(s.tokenTTL).Unix(),
+		CreatedAt: now.Unix(),
+	}
+	if err := s.tokenRepo.Create(token); err != nil {
+		return err
+	}
+
+	err = s.notifier.Notify(domain.Notification{
+		UserID:  user.ID,
+		Email:   user.Email,
+		Name:    user.Name,
+		Subject: "Reset your password",
+		Message: fmt.Sprintf("Someone asked to reset the password for your meeting room account.\n\n%s\n\nThis expires in %s and works once. If you did not ask for it, you can ignore this message.",
+			s.resetInstructions(secret), s.tokenTTL),
+	})
+	if err != nil {
+		// Failing the request here would reveal that the account exists.
+		log.Printf("Failed to send password reset to %s: %v", user.Email, err)
+	}
+	return nil
+}
+
+func (s *passwordResetService) resetInstructions(secret string) string {
+	if s.resetURL == "" {
+		return "Your reset token: " + secret
+	}
+	separator := "?"
+	if strings.Contains(s.resetURL, "?") {
+		separator = "&"
+	}
+	return "Set a new password at: " + s.resetURL + separator + "token=" + url.QueryEscape(secret)
+}
+
+func (s *passwordResetService) ResetPassword(secret, newPassword string) error {
+	secret = strings.TrimSpace(secret)
+	newPassword = strings.TrimSpace(newPassword)
+	if secret == "" || len(newPassword) < domain.MinPasswordLength {
+		return domain.ErrInvalidInput
+	}
+
+	tokenHash := utils.HashToken(secret)
+	token, err := s.tokenRepo.GetByHash(tokenHash)
+	if err == domain.ErrNotFound {
+		return domain.ErrInvalidResetToken
+	}
+	if err != nil {
+		return err
+	}
+	now := time.Now().Unix()
+	if !token.IsUsable(now) {
+		return domain.ErrInvalidResetToken
+	}
+
+	user, err := s.userRepo.GetByID(token.UserID)
+	if err == domain.ErrNotFound {
+		return domain.ErrInvalidResetToken
+	}
+	if err != nil {
+		return err
+	}
+	if user.IsDisabled() {
+		return domain.ErrInvalidResetToken
+	}
+
+	hashed, err := s.passwordHasher.HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+
+	// Consuming the token first means two concurrent resets cannot both win.
+	if err := s.tokenRepo.MarkUsed(tokenHash, now); err != nil {
+		if err == domain.ErrNotFound {
+			return domain.ErrInvalidResetToken
+		}
+		return err
+	}
+	if err := s.userRepo.UpdatePassword(user.ID, hashed, now); err != nil {
+		return err
+	}
+	// Whoever knew the old password may still be signed in with it.
+	if err := s.authService.RevokeUserSessions(user.ID); err != nil {
+		return err
+	}
+
+	if err := s.tokenRepo.DeleteByUserID(user.ID); err != nil {
+		log.Printf("Failed to clear password reset tokens for user %s: %v", user.ID, err)
+	}
+	return nil
+}
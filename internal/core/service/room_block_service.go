@@ -0,0 +1,146 @@
+package service
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/ports"
+	"github.com/amangirdhar210/meeting-room/internal/pkg/utils"
+	"github.com/google/uuid"
+)
+
+type roomBlockService struct {
+	repo        ports.RoomBlockRepository
+	roomRepo    ports.RoomRepository
+	bookingRepo ports.BookingRepository
+	userRepo    ports.UserRepository
+}
+
+func NewRoomBlockService(blRepo ports.RoomBlockRepository, rRepo ports.RoomRepository, bRepo ports.BookingRepository, uRepo ports.UserRepository) RoomBlockService {
+	return &roomBlockService{
+		repo:        blRepo,
+		roomRepo:    rRepo,
+		bookingRepo: bRepo,
+		userRepo:    uRepo,
+	}
+}
+
+// CreateBlock stores the block and returns the active bookings it collides
+// with. Those bookings are left in place so admins can notify or relocate
+// their organisers.
+func (s *roomBlockService) CreateBlock(block *domain.RoomBlock) ([]domain.BookingWithDetails, error) {
+	if block == nil || block.RoomID == "" || block.CreatedBy == "" {
+		return nil, domain.ErrInvalidInput
+	}
+	now := time.Now().Unix()
+	if !utils.IsTimeRangeValid(block.StartTime, block.EndTime) || block.EndTime <= now {
+		return nil, domain.ErrTimeRangeInvalid
+	}
+
+	room, err := s.roomRepo.GetByID(block.RoomID)
+	if err != nil {
+		return nil, err
+	}
+	if room == nil || room.IsDeleted() {
+		return nil, domain.ErrNotFound
+	}
+
+	block.ID = uuid.New().String()
+	block.Reason = strings.TrimSpace(block.Reason)
+	block.CreatedAt = now
+	if err := s.repo.Create(block); err != nil {
+		return nil, err
+	}
+
+	bookings, err := s.bookingRepo.GetByRoomAndTime(block.RoomID, block.StartTime, block.EndTime)
+	if err != nil && err != domain.ErrNotFound {
+		return nil, err
+	}
+
+	colliding := []domain.BookingWithDetails{}
+	for _, b := range bookings {
+		if !utils.Overlaps(block.StartTime, block.EndTime, b.StartTime, b.EndTime) {
+			continue
+		}
+		detail := domain.BookingWithDetails{
+			Booking:    b,
+			RoomName:   room.Name,
+			RoomNumber: room.RoomNumber,
+		}
+		if user, err := s.userRepo.GetByID(b.UserID); err == nil && user != nil {
+			detail.UserName = user.Name
+			detail.UserEmail = user.Email
+		}
+		colliding = append(colliding, detail)
+	}
+	sort.Slice(colliding, func(i, j int) bool {
+		return colliding[i].StartTime < colliding[j].StartTime
+	})
+	return colliding, nil
+}
+
+func (s *roomBlockService) GetBlocksByRoomID(roomID string) ([]domain.RoomBlock, error) {
+	if roomID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	room, err := s.roomRepo.GetByID(roomID)
+	if err != nil {
+		return nil, err
+	}
+	if room == nil {
+		return nil, domain.ErrNotFound
+	}
+
+	blocks, err := s.repo.GetByRoomID(roomID)
+	if err != nil {
+		return nil, err
+	}
+	if blocks == nil {
+		blocks = []domain.RoomBlock{}
+	}
+	return blocks, nil
+}
+
+func (s *roomBlockService) DeleteBlock(roomID, blockID string) error {
+	if roomID == "" || blockID == "" {
+		return domain.ErrInvalidInput
+	}
+
+	block, err := s.repo.GetByID(blockID)
+	if err != nil {
+		return err
+	}
+	if block == nil || block.RoomID != roomID {
+		return domain.ErrNotFound
+	}
+	return s.repo.Delete(blockID)
+}
+
+// overlappingBlocks returns the blocks on roomID that overlap [start, end).
+func overlappingBlocks(repo ports.RoomBlockRepository, roomID string, start, end int64) ([]domain.RoomBlock, error) {
+	blocks, err := repo.GetByRoomAndTime(roomID, start, end)
+	if err != nil && err != domain.ErrNotFound {
+		return nil, err
+	}
+
+	var overlapping []domain.RoomBlock
+	for _, block := range blocks {
+		if utils.Overlaps(start, end, block.StartTime, block.EndTime) {
+			overlapping = append(overlapping, block)
+		}
+	}
+	return overlapping, nil
+}
+
+// blocksAsBookings lets blocks take part in the free-slot calculations, which
+// work on booking intervals.
+func blocksAsBookings(blocks []domain.RoomBlock) []domain.Booking {
+	busy := make([]domain.Booking, len(blocks))
+	for i, block := range blocks {
+		busy[i] = domain.Booking{RoomID: block.RoomID, StartTime: block.StartTime, EndTime: block.EndTime}
+	}
+	return busy
+}
@@ -1,20 +1,45 @@
 package service
 
 import (
+	"fmt"
+	"log"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/amangirdhar210/meeting-room/internal/core/domain"
 	"github.com/amangirdhar210/meeting-room/internal/core/ports"
+	"github.com/amangirdhar210/meeting-room/internal/pkg/utils"
 	"github.com/google/uuid"
 )
 
+const (
+	maxSuggestedSlots      = 3
+	nextAvailableLookahead = 7 * 24 * 60 * 60
+)
+
 type roomService struct {
-	repo ports.RoomRepository
+	repo        ports.RoomRepository
+	bookingRepo ports.BookingRepository
+	blockRepo   ports.RoomBlockRepository
+	userRepo    ports.UserRepository
+	// bookingService hands the time of bookings cancelled along with a
+	// room to the waitlist.
+	bookingService BookingService
+	notifier       ports.Notifier
+	rules          domain.SchedulingRules
 }
 
-func NewRoomService(repo ports.RoomRepository) RoomService {
-	return &roomService{repo: repo}
+func NewRoomService(repo ports.RoomRepository, bookingRepo ports.BookingRepository, blockRepo ports.RoomBlockRepository, userRepo ports.UserRepository, bookingService BookingService, notifier ports.Notifier, rules domain.SchedulingRules) RoomService {
+	return &roomService{
+		repo:           repo,
+		bookingRepo:    bookingRepo,
+		blockRepo:      blockRepo,
+		userRepo:       userRepo,
+		bookingService: bookingService,
+		notifier:       notifier,
+		rules:          rules,
+	}
 }
 
 func (s *roomService) AddRoom(room *domain.Room) error {
@@ -22,24 +47,124 @@ func (s *roomService) AddRoom(room *domain.Room) error {
 		return domain.ErrInvalidInput
 	}
 
+	if room.Status == "" {
+		room.Status = domain.RoomStatusAvailable
+	}
+	if err := validateRoom(room); err != nil {
+		return err
+	}
+	if err := s.checkApprovers(room); err != nil {
+		return err
+	}
+
+	room.ID = uuid.New().String()
+	room.CreatedAt = time.Now().Unix()
+	room.UpdatedAt = time.Now().Unix()
+
+	return s.repo.Create(room)
+}
+
+func (s *roomService) UpdateRoom(id string, update domain.RoomUpdate) (*domain.Room, error) {
+	if id == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	room, err := s.GetRoomByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Name != nil {
+		room.Name = *update.Name
+	}
+	if update.RoomNumber != nil {
+		room.RoomNumber = *update.RoomNumber
+	}
+	if update.Capacity != nil {
+		room.Capacity = *update.Capacity
+	}
+	if update.Floor != nil {
+		room.Floor = *update.Floor
+	}
+	if update.Amenities != nil {
+		room.Amenities = *update.Amenities
+	}
+	if update.Status != nil {
+		room.Status = *update.Status
+	}
+	if update.Location != nil {
+		room.Location = *update.Location
+	}
+	if update.Description != nil {
+		room.Description = *update.Description
+	}
+	if update.RequiresApproval != nil {
+		room.RequiresApproval = *update.RequiresApproval
+	}
+	if update.Approvers != nil {
+		room.Approvers = *update.Approvers
+	}
+	if update.BookingPolicy != nil {
+		room.BookingPolicy = *update.BookingPolicy
+	}
+	if err := validateRoom(room); err != nil {
+		return nil, err
+	}
+	if err := s.checkApprovers(room); err != nil {
+		return nil, err
+	}
+
+	room.UpdatedAt = time.Now().Unix()
+	if err := s.repo.Update(room); err != nil {
+		return nil, err
+	}
+	return room, nil
+}
+
+// validateRoom trims and checks the fields shared by AddRoom and UpdateRoom
+// and normalises the status spelling.
+func validateRoom(room *domain.Room) error {
 	room.Name = strings.TrimSpace(room.Name)
 	room.Location = strings.TrimSpace(room.Location)
 
 	if room.Name == "" || room.Capacity <= 0 || room.Location == "" || room.RoomNumber <= 0 || room.Floor < 0 {
 		return domain.ErrInvalidInput
 	}
-	if room.Status == "" {
-		room.Status = "Available"
+	status, ok := domain.NormalizeRoomStatus(room.Status)
+	if !ok {
+		return domain.ErrInvalidInput
 	}
+	room.Status = status
 	if room.Amenities == nil {
 		room.Amenities = []string{}
 	}
+	if !room.BookingPolicy.IsValid() {
+		return domain.ErrInvalidInput
+	}
+	return nil
+}
 
-	room.ID = uuid.New().String()
-	room.CreatedAt = time.Now().Unix()
-	room.UpdatedAt = time.Now().Unix()
-
-	return s.repo.Create(room)
+// checkApprovers drops blank and repeated approver IDs and makes sure the
+// rest name existing users.
+func (s *roomService) checkApprovers(room *domain.Room) error {
+	approvers := []string{}
+	seen := map[string]bool{}
+	for _, approverID := range room.Approvers {
+		approverID = strings.TrimSpace(approverID)
+		if approverID == "" || seen[approverID] {
+			continue
+		}
+		if _, err := s.userRepo.GetByID(approverID); err != nil {
+			if err == domain.ErrNotFound {
+				return domain.ErrInvalidInput
+			}
+			return err
+		}
+		seen[approverID] = true
+		approvers = append(approvers, approverID)
+	}
+	room.Approvers = approvers
+	return nil
 }
 
 func (s *roomService) GetAllRooms() ([]domain.Room, error) {
@@ -61,38 +186,266 @@ func (s *roomService) GetRoomByID(id string) (*domain.Room, error) {
 	if err != nil {
 		return nil, err
 	}
-	if room == nil {
+	if room == nil || room.IsDeleted() {
 		return nil, domain.ErrNotFound
 	}
 	return room, nil
 }
 
-func (s *roomService) DeleteRoomByID(id string) error {
-	if id == "" {
-		return domain.ErrInvalidInput
+// DeleteRoomByID soft-deletes a room and returns its upcoming bookings: the
+// ones that blocked the deletion together with ErrRoomHasBookings, or the ones
+// cancelled when deletion.Force is set. Past bookings are left untouched.
+func (s *roomService) DeleteRoomByID(id string, deletion domain.RoomDeletion) ([]domain.Booking, error) {
+	room, err := s.GetRoomByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	upcoming, err := s.upcomingBookings(id)
+	if err != nil {
+		return nil, err
+	}
+	if len(upcoming) > 0 && !deletion.Force {
+		return upcoming, domain.ErrRoomHasBookings
+	}
+
+	now := time.Now().Unix()
+	if err := s.repo.SoftDelete(id, now); err != nil {
+		return nil, err
 	}
-	return s.repo.DeleteByID(id)
+
+	// The room no longer accepts bookings, so re-reading catches any booking
+	// made between the check above and the delete.
+	upcoming, err = s.upcomingBookings(id)
+	if err != nil {
+		return nil, err
+	}
+
+	reason := strings.TrimSpace(deletion.Reason)
+	if reason == "" {
+		reason = fmt.Sprintf("room %s has been removed", room.Name)
+	}
+	cancelled := make([]domain.Booking, 0, len(upcoming))
+	for _, booking := range upcoming {
+		if err := s.bookingRepo.Cancel(booking.ID, deletion.DeletedBy, reason, now); err != nil {
+			if err == domain.ErrBookingNotActive {
+				continue
+			}
+			return cancelled, err
+		}
+		booking.Status = domain.BookingStatusCancelled
+		booking.CancelledAt = now
+		booking.CancelledBy = deletion.DeletedBy
+		booking.CancellationReason = reason
+		cancelled = append(cancelled, booking)
+		s.notifyCancelled(room, booking)
+		s.bookingService.ReleaseSlot(booking.RoomID, booking.StartTime, booking.EndTime)
+	}
+	return cancelled, nil
+}
+
+func (s *roomService) upcomingBookings(roomID string) ([]domain.Booking, error) {
+	bookings, err := s.bookingRepo.GetByRoomID(roomID)
+	if err != nil && err != domain.ErrNotFound {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	var upcoming []domain.Booking
+	for _, b := range bookings {
+		if b.IsActive() && b.EndTime > now {
+			upcoming = append(upcoming, b)
+		}
+	}
+	sort.Slice(upcoming, func(i, j int) bool {
+		return upcoming[i].StartTime < upcoming[j].StartTime
+	})
+	return upcoming, nil
 }
 
-func (s *roomService) SearchRooms(minCapacity, maxCapacity int, floor *int, startTime, endTime *int64) ([]domain.Room, error) {
-	rooms, err := s.repo.SearchWithFilters(minCapacity, maxCapacity, floor)
+// notifyCancelled tells a booking's owner that it was cancelled. Delivery
+// failures are logged rather than undoing the deletion.
+func (s *roomService) notifyCancelled(room *domain.Room, booking domain.Booking) {
+	user, err := s.userRepo.GetByID(booking.UserID)
+	if err != nil || user == nil {
+		log.Printf("Cannot notify owner of cancelled booking %s: %v", booking.ID, err)
+		return
+	}
+
+	err = s.notifier.Notify(domain.Notification{
+		UserID:  user.ID,
+		Email:   user.Email,
+		Name:    user.Name,
+		Subject: "Booking cancelled",
+		Message: fmt.Sprintf("Your booking %q in %s at %s was cancelled: %s.",
+			booking.Purpose, room.Name, time.Unix(booking.StartTime, 0).UTC().Format(time.RFC3339), booking.CancellationReason),
+	})
+	if err != nil {
+		log.Printf("Failed to notify %s about cancelled booking %s: %v", user.Email, booking.ID, err)
+	}
+}
+
+func (s *roomService) SearchRooms(filter domain.RoomSearchFilter) ([]domain.RoomAvailability, error) {
+	if (filter.StartTime == nil) != (filter.EndTime == nil) {
+		return nil, domain.ErrInvalidInput
+	}
+	if filter.StartTime != nil && !utils.IsTimeRangeValid(*filter.StartTime, *filter.EndTime) {
+		return nil, domain.ErrTimeRangeInvalid
+	}
+	if filter.MinCapacity > 0 && filter.MaxCapacity > 0 && filter.MinCapacity > filter.MaxCapacity {
+		return nil, domain.ErrInvalidInput
+	}
+	if filter.Status != "" {
+		status, ok := domain.NormalizeRoomStatus(filter.Status)
+		if !ok {
+			return nil, domain.ErrInvalidInput
+		}
+		filter.Status = status
+	}
+	if filter.StartTime != nil && filter.Available == nil {
+		available := true
+		filter.Available = &available
+	}
+
+	// The repository only knows about bookings, so it may narrow the search to
+	// rooms without clashing bookings but cannot tell which rooms are busy;
+	// blocks and room status are applied below.
+	repoFilter := filter
+	if filter.Available != nil && !*filter.Available {
+		repoFilter.Available = nil
+	}
+	rooms, err := s.repo.SearchWithFilters(repoFilter)
 	if err != nil {
 		return nil, err
 	}
-	return rooms, nil
+
+	windowStart := time.Now().Unix()
+	windowEnd := windowStart + 1
+	if filter.StartTime != nil {
+		windowStart, windowEnd = *filter.StartTime, *filter.EndTime
+	}
+
+	results := make([]domain.RoomAvailability, 0, len(rooms))
+	for _, room := range rooms {
+		availability, err := s.roomAvailability(room, windowStart, windowEnd)
+		if err != nil {
+			return nil, err
+		}
+		if filter.Available != nil && availability.IsAvailable != *filter.Available {
+			continue
+		}
+		results = append(results, availability)
+	}
+	return results, nil
 }
 
-func (s *roomService) CheckAvailability(roomID string, startTime, endTime int64) (bool, []domain.Booking, error) {
+func (s *roomService) roomAvailability(room domain.Room, windowStart, windowEnd int64) (domain.RoomAvailability, error) {
+	result := domain.RoomAvailability{Room: room, IsAvailable: true}
+	if !room.AcceptsBookings() {
+		result.IsAvailable = false
+		return result, nil
+	}
+
+	bookings, err := s.bookingRepo.GetByRoomAndTime(room.ID, windowStart, windowStart+nextAvailableLookahead)
+	if err != nil {
+		return result, err
+	}
+	blocks, err := overlappingBlocks(s.blockRepo, room.ID, windowStart, windowStart+nextAvailableLookahead)
+	if err != nil {
+		return result, err
+	}
+	for _, block := range blocks {
+		if utils.Overlaps(windowStart, windowEnd, block.StartTime, block.EndTime) {
+			result.IsAvailable = false
+		}
+	}
+
+	var currentStart int64
+	for _, b := range bookings {
+		if !utils.Overlaps(windowStart, windowEnd, b.StartTime, b.EndTime) {
+			continue
+		}
+		if result.IsAvailable || b.StartTime < currentStart {
+			bookingID := b.ID
+			result.CurrentBookingID = &bookingID
+			currentStart = b.StartTime
+		}
+		result.IsAvailable = false
+	}
+	if result.IsAvailable {
+		return result, nil
+	}
+
+	duration := windowEnd - windowStart
+	busy := append(bookings, blocksAsBookings(blocks)...)
+	for _, gap := range freeGaps(windowStart, windowStart+nextAvailableLookahead, busy) {
+		if gap.EndTime-gap.StartTime >= duration {
+			nextAvailableAt := gap.StartTime
+			result.NextAvailableAt = &nextAvailableAt
+			break
+		}
+	}
+	return result, nil
+}
+
+func (s *roomService) CheckAvailability(roomID string, startTime, endTime int64) (*domain.AvailabilityResult, error) {
 	if roomID == "" {
-		return false, nil, domain.ErrInvalidInput
+		return nil, domain.ErrInvalidInput
+	}
+	if !utils.IsTimeRangeValid(startTime, endTime) {
+		return nil, domain.ErrTimeRangeInvalid
+	}
+
+	room, err := s.repo.GetByID(roomID)
+	if err != nil {
+		return nil, err
+	}
+	if room == nil {
+		return nil, domain.ErrNotFound
+	}
+
+	if !room.AcceptsBookings() {
+		return &domain.AvailabilityResult{
+			ConflictingBookings: []domain.Booking{},
+			SuggestedSlots:      []domain.TimeSlot{},
+		}, nil
+	}
+
+	existingBookings, err := s.bookingRepo.GetByRoomAndTime(roomID, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts := []domain.Booking{}
+	for _, b := range existingBookings {
+		if utils.Overlaps(startTime, endTime, b.StartTime, b.EndTime) {
+			conflicts = append(conflicts, b)
+		}
+	}
+	blocks, err := overlappingBlocks(s.blockRepo, roomID, startTime, endTime)
+	if err != nil {
+		return nil, err
 	}
 
-	_, err := s.repo.GetByID(roomID)
+	result := &domain.AvailabilityResult{
+		Available:           len(conflicts) == 0 && len(blocks) == 0,
+		ConflictingBookings: conflicts,
+		ConflictingBlocks:   blocks,
+		SuggestedSlots:      []domain.TimeSlot{},
+	}
+	if result.Available {
+		return result, nil
+	}
+
+	openAt, closeAt := s.workingWindow(room, startTime)
+	buffer := int64(s.rules.BufferTime.Seconds())
+	busy, err := s.busyIntervals(roomID, openAt-buffer, closeAt+buffer)
 	if err != nil {
-		return false, nil, err
+		return nil, err
 	}
 
-	return true, []domain.Booking{}, nil
+	result.SuggestedSlots = suggestSlots(openAt, closeAt, startTime, endTime-startTime, withBuffer(busy, buffer))
+	return result, nil
 }
 
 func (s *roomService) GetAvailableSlots(roomID string, date int64, slotDuration int) ([]domain.TimeSlot, error) {
@@ -100,10 +453,159 @@ func (s *roomService) GetAvailableSlots(roomID string, date int64, slotDuration
 		return nil, domain.ErrInvalidInput
 	}
 
-	_, err := s.repo.GetByID(roomID)
+	room, err := s.repo.GetByID(roomID)
 	if err != nil {
 		return nil, err
 	}
+	if room == nil {
+		return nil, domain.ErrNotFound
+	}
+
+	if !room.AcceptsBookings() {
+		return []domain.TimeSlot{}, nil
+	}
 
-	return []domain.TimeSlot{}, nil
+	day := time.Unix(date, 0).UTC()
+	dayStart := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, s.rules.TimeLocation()).Unix()
+	openAt, closeAt := s.workingWindow(room, dayStart)
+
+	buffer := int64(s.rules.BufferTime.Seconds())
+	bookings, err := s.busyIntervals(roomID, openAt-buffer, closeAt+buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	granularity := int64(s.rules.SlotGranularity.Seconds())
+	if granularity <= 0 {
+		granularity = 60
+	}
+	duration := int64(slotDuration) * 60
+	busy := withBuffer(bookings, buffer)
+	now := time.Now().Unix()
+
+	slots := []domain.TimeSlot{}
+	firstStart := dayStart + ((openAt-dayStart+granularity-1)/granularity)*granularity
+	for start := firstStart; start+duration <= closeAt; start += granularity {
+		if start < now {
+			continue
+		}
+		end := start + duration
+		free := true
+		for _, b := range busy {
+			if utils.Overlaps(start, end, b.StartTime, b.EndTime) {
+				free = false
+				break
+			}
+		}
+		if free {
+			slots = append(slots, domain.TimeSlot{
+				StartTime: start,
+				EndTime:   end,
+				Duration:  slotDuration,
+			})
+		}
+	}
+
+	return slots, nil
+}
+
+// busyIntervals returns the bookings and blocks of a room within a window as
+// booking intervals.
+func (s *roomService) busyIntervals(roomID string, start, end int64) ([]domain.Booking, error) {
+	bookings, err := s.bookingRepo.GetByRoomAndTime(roomID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	blocks, err := overlappingBlocks(s.blockRepo, roomID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	return append(bookings, blocksAsBookings(blocks)...), nil
+}
+
+func (s *roomService) workingWindow(room *domain.Room, timestamp int64) (int64, int64) {
+	t := time.Unix(timestamp, 0).In(s.rules.TimeLocation())
+	dayStart := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	hours := s.rules.HoursFor(room.Location)
+	openAt := dayStart.Add(time.Duration(hours.StartMinute) * time.Minute)
+	closeAt := dayStart.Add(time.Duration(hours.EndMinute) * time.Minute)
+	return openAt.Unix(), closeAt.Unix()
+}
+
+func withBuffer(bookings []domain.Booking, buffer int64) []domain.Booking {
+	padded := make([]domain.Booking, len(bookings))
+	for i, b := range bookings {
+		padded[i] = b
+		padded[i].StartTime -= buffer
+		padded[i].EndTime += buffer
+	}
+	return padded
+}
+
+func freeGaps(windowStart, windowEnd int64, bookings []domain.Booking) []domain.TimeSlot {
+	sorted := make([]domain.Booking, len(bookings))
+	copy(sorted, bookings)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].StartTime < sorted[j].StartTime
+	})
+
+	var gaps []domain.TimeSlot
+	cursor := windowStart
+	for _, b := range sorted {
+		if b.EndTime <= cursor {
+			continue
+		}
+		if b.StartTime >= windowEnd {
+			break
+		}
+		if b.StartTime > cursor {
+			gaps = append(gaps, domain.TimeSlot{StartTime: cursor, EndTime: b.StartTime})
+		}
+		cursor = b.EndTime
+	}
+	if cursor < windowEnd {
+		gaps = append(gaps, domain.TimeSlot{StartTime: cursor, EndTime: windowEnd})
+	}
+	return gaps
+}
+
+func suggestSlots(windowStart, windowEnd, requestedStart, duration int64, bookings []domain.Booking) []domain.TimeSlot {
+	var candidates []domain.TimeSlot
+	for _, gap := range freeGaps(windowStart, windowEnd, bookings) {
+		latestStart := gap.EndTime - duration
+		if latestStart < gap.StartTime {
+			continue
+		}
+
+		nearest := min(max(requestedStart, gap.StartTime), latestStart)
+		for k := int64(-maxSuggestedSlots); k <= maxSuggestedSlots; k++ {
+			start := nearest + k*duration
+			if start < gap.StartTime || start > latestStart {
+				continue
+			}
+			candidates = append(candidates, domain.TimeSlot{
+				StartTime: start,
+				EndTime:   start + duration,
+				Duration:  int(duration / 60),
+			})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return distance(candidates[i].StartTime, requestedStart) < distance(candidates[j].StartTime, requestedStart)
+	})
+	if len(candidates) > maxSuggestedSlots {
+		candidates = candidates[:maxSuggestedSlots]
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].StartTime < candidates[j].StartTime
+	})
+	return candidates
+}
+
+func distance(a, b int64) int64 {
+	if a > b {
+		return a - b
+	}
+	return b - a
 }
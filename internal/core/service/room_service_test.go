@@ -0,0 +1,226 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+)
+
+// testRules opens every building from 09:00 to 17:00 UTC except Building B,
+// which is open from 08:00 to noon.
+func testRules() domain.SchedulingRules {
+	return domain.SchedulingRules{
+		DefaultHours:    domain.WorkingHours{StartMinute: 9 * 60, EndMinute: 17 * 60},
+		BuildingHours:   map[string]domain.WorkingHours{"Building B": {StartMinute: 8 * 60, EndMinute: 12 * 60}},
+		SlotGranularity: 30 * time.Minute,
+		Location:        time.UTC,
+	}
+}
+
+func (st *testStore) roomService(rules domain.SchedulingRules) RoomService {
+	return NewRoomService(st.rooms, st.bookings, st.blocks, st.users, st.bookingSv, st.notifier, rules)
+}
+
+// testDay returns a day in the future and a function giving its times.
+func testDay() (time.Time, func(hour, minute int) time.Time) {
+	day := time.Now().UTC().AddDate(0, 0, 2).Truncate(24 * time.Hour)
+	return day, func(hour, minute int) time.Time {
+		return day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+}
+
+func slotTimes(slots []domain.TimeSlot) []string {
+	times := make([]string, 0, len(slots))
+	for _, slot := range slots {
+		times = append(times, time.Unix(slot.StartTime, 0).UTC().Format("15:04")+"-"+time.Unix(slot.EndTime, 0).UTC().Format("15:04"))
+	}
+	return times
+}
+
+func TestRoomServiceCheckAvailabilitySuggestsFreeSlots(t *testing.T) {
+	_, at := testDay()
+	type booking struct {
+		from, to  time.Time
+		cancelled bool
+	}
+
+	tests := []struct {
+		name          string
+		bookings      []booking
+		from, to      time.Time
+		wantAvailable bool
+		wantSlots     []string
+	}{
+		{
+			name:          "free",
+			bookings:      []booking{{from: at(9, 0), to: at(10, 0)}, {from: at(11, 0), to: at(12, 0)}},
+			from:          at(10, 0),
+			to:            at(11, 0),
+			wantAvailable: true,
+			wantSlots:     []string{},
+		},
+		{
+			name:      "back-to-back bookings from opening",
+			bookings:  []booking{{from: at(9, 0), to: at(10, 0)}, {from: at(10, 0), to: at(11, 0)}},
+			from:      at(10, 0),
+			to:        at(11, 0),
+			wantSlots: []string{"11:00-12:00", "12:00-13:00", "13:00-14:00"},
+		},
+		{
+			name:      "slots up to the edges of working hours",
+			bookings:  []booking{{from: at(12, 0), to: at(16, 0)}},
+			from:      at(14, 0),
+			to:        at(15, 0),
+			wantSlots: []string{"10:00-11:00", "11:00-12:00", "16:00-17:00"},
+		},
+		{
+			name:      "gap shorter than the request",
+			bookings:  []booking{{from: at(9, 0), to: at(13, 0)}, {from: at(13, 30), to: at(15, 0)}},
+			from:      at(13, 0),
+			to:        at(14, 0),
+			wantSlots: []string{"15:00-16:00", "16:00-17:00"},
+		},
+		{
+			name:          "only a cancelled booking in the way",
+			bookings:      []booking{{from: at(10, 0), to: at(11, 0), cancelled: true}},
+			from:          at(10, 0),
+			to:            at(11, 0),
+			wantAvailable: true,
+			wantSlots:     []string{},
+		},
+		{
+			name:      "cancelled booking leaves its slot free",
+			bookings:  []booking{{from: at(9, 0), to: at(11, 0)}, {from: at(11, 0), to: at(12, 0), cancelled: true}, {from: at(12, 0), to: at(17, 0)}},
+			from:      at(10, 0),
+			to:        at(11, 0),
+			wantSlots: []string{"11:00-12:00"},
+		},
+		{
+			name:      "fully booked day",
+			bookings:  []booking{{from: at(9, 0), to: at(17, 0)}},
+			from:      at(10, 0),
+			to:        at(11, 0),
+			wantSlots: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newTestStore(t, domain.BookingPolicy{})
+			owner := st.addUser(t, domain.UserRoleUser)
+			room := st.addRoom(t, "Building A, Floor 1")
+			for _, b := range tt.bookings {
+				booking := st.addBooking(t, owner.ID, room.ID, b.from, b.to)
+				if b.cancelled {
+					if err := st.bookings.Cancel(booking.ID, owner.ID, "", time.Now().Unix()); err != nil {
+						t.Fatalf("cancel booking: %v", err)
+					}
+				}
+			}
+
+			result, err := st.roomService(testRules()).CheckAvailability(room.ID, tt.from.Unix(), tt.to.Unix())
+			if err != nil {
+				t.Fatalf("CheckAvailability() failed: %v", err)
+			}
+			if result.Available != tt.wantAvailable {
+				t.Errorf("Available = %v, want %v", result.Available, tt.wantAvailable)
+			}
+			if got := slotTimes(result.SuggestedSlots); !reflect.DeepEqual(got, tt.wantSlots) {
+				t.Errorf("SuggestedSlots = %v, want %v", got, tt.wantSlots)
+			}
+			for _, conflict := range result.ConflictingBookings {
+				if conflict.Status == domain.BookingStatusCancelled {
+					t.Errorf("cancelled booking %s reported as a conflict", conflict.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestRoomServiceGetAvailableSlots(t *testing.T) {
+	day, at := testDay()
+	type booking struct {
+		from, to  time.Time
+		cancelled bool
+	}
+
+	tests := []struct {
+		name     string
+		location string
+		buffer   time.Duration
+		bookings []booking
+		duration int
+		want     []string
+	}{
+		{
+			name:     "empty day runs from opening to closing",
+			location: "Building A",
+			duration: 60,
+			want: []string{
+				"09:00-10:00", "09:30-10:30", "10:00-11:00", "10:30-11:30", "11:00-12:00", "11:30-12:30", "12:00-13:00", "12:30-13:30",
+				"13:00-14:00", "13:30-14:30", "14:00-15:00", "14:30-15:30", "15:00-16:00", "15:30-16:30", "16:00-17:00",
+			},
+		},
+		{
+			name:     "building hours",
+			location: "Building B, Floor 2",
+			duration: 120,
+			want:     []string{"08:00-10:00", "08:30-10:30", "09:00-11:00", "09:30-11:30", "10:00-12:00"},
+		},
+		{
+			name:     "back-to-back bookings",
+			location: "Building A",
+			bookings: []booking{{from: at(9, 0), to: at(10, 0)}, {from: at(10, 0), to: at(15, 0)}, {from: at(15, 0), to: at(16, 0)}},
+			duration: 60,
+			want:     []string{"16:00-17:00"},
+		},
+		{
+			name:     "buffer around bookings",
+			location: "Building A",
+			buffer:   15 * time.Minute,
+			bookings: []booking{{from: at(11, 0), to: at(15, 0)}},
+			duration: 60,
+			want:     []string{"09:00-10:00", "09:30-10:30", "15:30-16:30", "16:00-17:00"},
+		},
+		{
+			name:     "cancelled bookings are ignored",
+			location: "Building A",
+			bookings: []booking{{from: at(9, 0), to: at(12, 0), cancelled: true}, {from: at(12, 0), to: at(17, 0)}},
+			duration: 60,
+			want:     []string{"09:00-10:00", "09:30-10:30", "10:00-11:00", "10:30-11:30", "11:00-12:00"},
+		},
+		{
+			name:     "fully booked day",
+			location: "Building A",
+			bookings: []booking{{from: at(9, 0), to: at(17, 0)}},
+			duration: 30,
+			want:     []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newTestStore(t, domain.BookingPolicy{})
+			owner := st.addUser(t, domain.UserRoleUser)
+			room := st.addRoom(t, tt.location)
+			for _, b := range tt.bookings {
+				booking := st.addBooking(t, owner.ID, room.ID, b.from, b.to)
+				if b.cancelled {
+					if err := st.bookings.Cancel(booking.ID, owner.ID, "", time.Now().Unix()); err != nil {
+						t.Fatalf("cancel booking: %v", err)
+					}
+				}
+			}
+
+			rules := testRules()
+			rules.BufferTime = tt.buffer
+			slots, err := st.roomService(rules).GetAvailableSlots(room.ID, day.Unix(), tt.duration)
+			if err != nil {
+				t.Fatalf("GetAvailableSlots() failed: %v", err)
+			}
+			if got := slotTimes(slots); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetAvailableSlots() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,543 @@
+package service
+
+import (
+	"log"
+	"time"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/ports"
+	"github.com/amangirdhar210/meeting-room/internal/pkg/rrule"
+	"github.com/amangirdhar210/meeting-room/internal/pkg/utils"
+	"github.com/google/uuid"
+)
+
+// maxSeriesOccurrences bounds a single series to roughly a year of daily
+// meetings.
+const maxSeriesOccurrences = 366
+
+type bookingSeriesService struct {
+	repo        ports.BookingSeriesRepository
+	bookingRepo ports.BookingRepository
+	roomRepo    ports.RoomRepository
+	userRepo    ports.UserRepository
+	blockRepo   ports.RoomBlockRepository
+	// bookingService hands the time occurrences leave to the waitlist.
+	bookingService BookingService
+	// approvalTimeout is how long approvers have to answer occurrences in a
+	// room that requires approval.
+	approvalTimeout time.Duration
+	policy          domain.BookingPolicy
+}
+
+func NewBookingSeriesService(sRepo ports.BookingSeriesRepository, bRepo ports.BookingRepository, rRepo ports.RoomRepository, uRepo ports.UserRepository, blRepo ports.RoomBlockRepository, bookingService BookingService, approvalTimeout time.Duration, policy domain.BookingPolicy) BookingSeriesService {
+	return &bookingSeriesService{
+		repo:            sRepo,
+		bookingRepo:     bRepo,
+		roomRepo:        rRepo,
+		userRepo:        uRepo,
+		blockRepo:       blRepo,
+		bookingService:  bookingService,
+		approvalTimeout: approvalTimeout,
+		policy:          policy,
+	}
+}
+
+func (s *bookingSeriesService) CreateSeries(series *domain.BookingSeries, skipConflicts bool) (*domain.SeriesResult, error) {
+	if series == nil || series.UserID == "" || series.RoomID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+	if err := checkTimeRange(series.StartTime, series.EndTime); err != nil {
+		return nil, err
+	}
+	if series.Timezone == "" {
+		series.Timezone = "UTC"
+	}
+
+	starts, err := expandSeries(series)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(series.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsDisabled() {
+		return nil, domain.ErrUserDisabled
+	}
+	room, err := s.roomRepo.GetByID(series.RoomID)
+	if err != nil {
+		return nil, err
+	}
+	if !room.AcceptsBookings() {
+		return nil, domain.ErrRoomNotBookable
+	}
+
+	duration := series.EndTime - series.StartTime
+	result := &domain.SeriesResult{Series: series}
+	var free []int64
+	for _, start := range starts {
+		conflict, err := s.occurrenceConflict(series.RoomID, start, start+duration, nil)
+		if err != nil {
+			return nil, err
+		}
+		if conflict != nil {
+			result.Conflicts = append(result.Conflicts, *conflict)
+			continue
+		}
+		free = append(free, start)
+	}
+	if len(free) == 0 || (len(result.Conflicts) > 0 && !skipConflicts) {
+		return result, domain.ErrRoomUnavailable
+	}
+	// Only the occurrences that get booked are held to the policy and count
+	// against the user's quota; skipped conflicts are never written.
+	windows := make([]policyWindow, 0, len(free))
+	for _, start := range free {
+		windows = append(windows, policyWindow{start: start, end: start + duration, movedStart: true})
+	}
+	if err := checkPolicy(s.policy, s.bookingRepo, user, user.Role, room, windows, len(free)); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	series.ID = uuid.New().String()
+	series.Status = domain.BookingStatusConfirmed
+	series.CreatedAt = now
+	series.UpdatedAt = now
+	if err := s.repo.Create(series); err != nil {
+		return nil, err
+	}
+
+	bookings := make([]domain.Booking, 0, len(free))
+	for _, start := range free {
+		booking := domain.Booking{
+			ID:        uuid.New().String(),
+			UserID:    series.UserID,
+			RoomID:    series.RoomID,
+			StartTime: start,
+			EndTime:   start + duration,
+			Purpose:   series.Purpose,
+			SeriesID:  series.ID,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		settleApproval(&booking, room, true, s.approvalTimeout, now)
+		bookings = append(bookings, booking)
+	}
+	// Occurrences are written all or none, so a slot taken since the check
+	// above leaves no partial series behind.
+	if err := s.bookingRepo.CreateAll(bookings); err != nil {
+		if deleteErr := s.repo.Delete(series.ID); deleteErr != nil {
+			log.Printf("Failed to remove series %s after its occurrences failed: %v", series.ID, deleteErr)
+		}
+		series.ID = ""
+		if err == domain.ErrRoomUnavailable {
+			conflicts, err := s.conflicts(bookings, nil)
+			if err != nil {
+				return nil, err
+			}
+			result.Conflicts = append(result.Conflicts, conflicts...)
+			return result, domain.ErrRoomUnavailable
+		}
+		return nil, err
+	}
+
+	result.Bookings = bookings
+	return result, nil
+}
+
+func (s *bookingSeriesService) GetSeries(seriesID string) (*domain.BookingSeries, []domain.Booking, error) {
+	if seriesID == "" {
+		return nil, nil, domain.ErrInvalidInput
+	}
+
+	series, err := s.repo.GetByID(seriesID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	occurrences, err := s.bookingRepo.GetBySeriesID(seriesID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return series, occurrences, nil
+}
+
+func (s *bookingSeriesService) UpdateOccurrences(bookingID, scope string, update domain.BookingUpdate, actorRole string) (*domain.SeriesResult, error) {
+	if update.RoomID == nil && update.StartTime == nil && update.EndTime == nil && update.Purpose == nil {
+		return nil, domain.ErrInvalidInput
+	}
+	if update.RoomID != nil && *update.RoomID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	booking, series, targets, err := s.resolveScope(bookingID, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	var startShift, endShift int64
+	if update.StartTime != nil {
+		startShift = *update.StartTime - booking.StartTime
+	}
+	if update.EndTime != nil {
+		endShift = *update.EndTime - booking.EndTime
+	}
+	rescheduled := update.RoomID != nil || update.StartTime != nil || update.EndTime != nil
+	var room *domain.Room
+	if rescheduled {
+		roomID := booking.RoomID
+		if update.RoomID != nil {
+			roomID = *update.RoomID
+		}
+		room, err = s.roomRepo.GetByID(roomID)
+		if err != nil {
+			return nil, err
+		}
+		if !room.AcceptsBookings() {
+			return nil, domain.ErrRoomNotBookable
+		}
+	}
+
+	now := time.Now().Unix()
+	result := &domain.SeriesResult{Series: series}
+	updated := make([]domain.Booking, 0, len(targets))
+	moving := make(map[string]bool, len(targets))
+	for _, target := range targets {
+		previousRoomID := target.RoomID
+		target.StartTime += startShift
+		target.EndTime += endShift
+		if update.RoomID != nil {
+			target.RoomID = *update.RoomID
+		}
+		if update.Purpose != nil {
+			target.Purpose = *update.Purpose
+		}
+		if err := checkTimeRange(target.StartTime, target.EndTime); err != nil {
+			return nil, err
+		}
+		target.UpdatedAt = now
+		if rescheduled {
+			settleApproval(&target, room, target.RoomID != previousRoomID, s.approvalTimeout, now)
+		}
+		updated = append(updated, target)
+		moving[target.ID] = true
+	}
+	if rescheduled {
+		owner, err := s.userRepo.GetByID(series.UserID)
+		if err != nil {
+			return nil, err
+		}
+		windows := make([]policyWindow, 0, len(updated))
+		for _, target := range updated {
+			windows = append(windows, policyWindow{start: target.StartTime, end: target.EndTime, movedStart: startShift != 0})
+		}
+		if err := checkPolicy(s.policy, s.bookingRepo, owner, actorRole, room, windows, 0); err != nil {
+			return nil, err
+		}
+	}
+	result.Conflicts, err = s.conflicts(updated, moving)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Conflicts) > 0 {
+		return result, domain.ErrRoomUnavailable
+	}
+
+	// Editing this and the following occurrences splits them off into a
+	// series of their own, so the rule of the original one keeps describing
+	// the occurrences left in it.
+	var head, tail *domain.BookingSeries
+	switch {
+	case scope == domain.SeriesScopeAll,
+		scope == domain.SeriesScopeFollowing && booking.StartTime <= series.StartTime:
+		tail, err = movedSeries(*series, series.StartTime, update, startShift, endShift, now)
+	case scope == domain.SeriesScopeFollowing:
+		tail, err = movedSeries(*series, booking.StartTime, update, startShift, endShift, now)
+		if err == nil {
+			head, err = seriesEndingBefore(*series, booking.StartTime, now)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if head != nil {
+		tail.ID = uuid.New().String()
+		tail.CreatedAt = now
+		if err := s.repo.Create(tail); err != nil {
+			return nil, err
+		}
+		for i := range updated {
+			updated[i].SeriesID = tail.ID
+		}
+	}
+
+	if err := s.bookingRepo.UpdateAll(updated); err != nil {
+		if head != nil {
+			if deleteErr := s.repo.Delete(tail.ID); deleteErr != nil {
+				log.Printf("Failed to remove series %s split off %s after its occurrences failed: %v", tail.ID, series.ID, deleteErr)
+			}
+		}
+		if err == domain.ErrRoomUnavailable {
+			result.Conflicts, err = s.conflicts(updated, moving)
+			if err != nil {
+				return nil, err
+			}
+			return result, domain.ErrRoomUnavailable
+		}
+		return nil, err
+	}
+	result.Bookings = updated
+	if rescheduled {
+		for _, target := range targets {
+			s.bookingService.ReleaseSlot(target.RoomID, target.StartTime, target.EndTime)
+		}
+	}
+
+	switch {
+	case head != nil:
+		if err := s.repo.Update(head); err != nil {
+			return nil, err
+		}
+		result.Series = tail
+	case tail != nil:
+		if err := s.repo.Update(tail); err != nil {
+			return nil, err
+		}
+		result.Series = tail
+	}
+
+	return result, nil
+}
+
+func (s *bookingSeriesService) CancelOccurrences(bookingID, scope, cancelledBy, reason string) ([]domain.Booking, error) {
+	booking, series, targets, err := s.resolveScope(bookingID, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	var cancelled []domain.Booking
+	for _, target := range targets {
+		err := s.bookingRepo.Cancel(target.ID, cancelledBy, reason, now)
+		if err == domain.ErrBookingNotActive {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		cancelled = append(cancelled, target)
+		s.bookingService.ReleaseSlot(target.RoomID, target.StartTime, target.EndTime)
+	}
+
+	switch scope {
+	case domain.SeriesScopeThis:
+		series.ExDates = append(series.ExDates, booking.StartTime)
+	case domain.SeriesScopeFollowing:
+		rule, err := rrule.Parse(series.RRule)
+		if err != nil {
+			return nil, domain.ErrInvalidRecurrence
+		}
+		series.RRule = rule.EndingBefore(time.Unix(booking.StartTime, 0)).String()
+		if booking.StartTime <= series.StartTime {
+			series.Status = domain.BookingStatusCancelled
+		}
+	case domain.SeriesScopeAll:
+		series.Status = domain.BookingStatusCancelled
+	}
+	series.UpdatedAt = now
+	if err := s.repo.Update(series); err != nil {
+		return nil, err
+	}
+
+	return cancelled, nil
+}
+
+// resolveScope loads the booking, its series and the active occurrences the
+// scope covers: the booking alone, it and every later occurrence, or every
+// occurrence that has not ended yet.
+func (s *bookingSeriesService) resolveScope(bookingID, scope string) (*domain.Booking, *domain.BookingSeries, []domain.Booking, error) {
+	if bookingID == "" || !domain.IsValidSeriesScope(scope) {
+		return nil, nil, nil, domain.ErrInvalidInput
+	}
+
+	booking, err := s.bookingRepo.GetByID(bookingID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if booking.SeriesID == "" {
+		return nil, nil, nil, domain.ErrNotInSeries
+	}
+	if !booking.IsActive() {
+		return nil, nil, nil, domain.ErrBookingNotActive
+	}
+
+	series, err := s.repo.GetByID(booking.SeriesID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if scope == domain.SeriesScopeThis {
+		return booking, series, []domain.Booking{*booking}, nil
+	}
+
+	occurrences, err := s.bookingRepo.GetBySeriesID(series.ID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	now := time.Now().Unix()
+	var targets []domain.Booking
+	for _, occurrence := range occurrences {
+		if !occurrence.IsActive() {
+			continue
+		}
+		switch {
+		case occurrence.ID == booking.ID,
+			scope == domain.SeriesScopeFollowing && occurrence.StartTime >= booking.StartTime,
+			scope == domain.SeriesScopeAll && occurrence.EndTime > now:
+			targets = append(targets, occurrence)
+		}
+	}
+	return booking, series, targets, nil
+}
+
+// occurrenceConflict reports the bookings and blocks an occurrence at
+// [start, end) would collide with, or nil when the slot is free.
+func (s *bookingSeriesService) occurrenceConflict(roomID string, start, end int64, ignore map[string]bool) (*domain.OccurrenceConflict, error) {
+	existing, err := s.bookingRepo.GetByRoomAndTime(roomID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	conflict := domain.OccurrenceConflict{StartTime: start, EndTime: end}
+	for _, b := range existing {
+		if !ignore[b.ID] && utils.Overlaps(start, end, b.StartTime, b.EndTime) {
+			conflict.ConflictingBookingIDs = append(conflict.ConflictingBookingIDs, b.ID)
+		}
+	}
+
+	blocks, err := overlappingBlocks(s.blockRepo, roomID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	for _, block := range blocks {
+		conflict.ConflictingBlockIDs = append(conflict.ConflictingBlockIDs, block.ID)
+	}
+
+	if len(conflict.ConflictingBookingIDs) == 0 && len(conflict.ConflictingBlockIDs) == 0 {
+		return nil, nil
+	}
+	return &conflict, nil
+}
+
+// conflicts lists the bookings whose slot is taken by bookings outside
+// ignore or by blocks.
+func (s *bookingSeriesService) conflicts(bookings []domain.Booking, ignore map[string]bool) ([]domain.OccurrenceConflict, error) {
+	var conflicts []domain.OccurrenceConflict
+	for _, booking := range bookings {
+		conflict, err := s.occurrenceConflict(booking.RoomID, booking.StartTime, booking.EndTime, ignore)
+		if err != nil {
+			return nil, err
+		}
+		if conflict != nil {
+			conflicts = append(conflicts, *conflict)
+		}
+	}
+	return conflicts, nil
+}
+
+// movedSeries returns series reduced to its occurrences from the one
+// starting at from on, with update applied to them: they move by startShift
+// and endShift, and the rule and exception dates move along.
+func movedSeries(series domain.BookingSeries, from int64, update domain.BookingUpdate, startShift, endShift, now int64) (*domain.BookingSeries, error) {
+	rule, err := rrule.Parse(series.RRule)
+	if err != nil {
+		return nil, domain.ErrInvalidRecurrence
+	}
+	loc, err := time.LoadLocation(series.Timezone)
+	if err != nil {
+		return nil, domain.ErrInvalidInput
+	}
+
+	first := time.Unix(from, 0).In(loc)
+	moved := first.Add(time.Duration(startShift) * time.Second)
+	rule, err = rule.StartingAt(time.Unix(series.StartTime, 0).In(loc), first).Shift(moved.Sub(first), daysBetween(first, moved))
+	if err != nil {
+		return nil, domain.ErrInvalidRecurrence
+	}
+
+	duration := series.EndTime - series.StartTime
+	series.RRule = rule.String()
+	series.StartTime = from + startShift
+	series.EndTime = from + duration + endShift
+	var exdates []int64
+	for _, exdate := range series.ExDates {
+		if exdate >= from {
+			exdates = append(exdates, exdate+startShift)
+		}
+	}
+	series.ExDates = exdates
+	if update.RoomID != nil {
+		series.RoomID = *update.RoomID
+	}
+	if update.Purpose != nil {
+		series.Purpose = *update.Purpose
+	}
+	series.UpdatedAt = now
+	return &series, nil
+}
+
+// seriesEndingBefore returns series reduced to its occurrences before t.
+func seriesEndingBefore(series domain.BookingSeries, t, now int64) (*domain.BookingSeries, error) {
+	rule, err := rrule.Parse(series.RRule)
+	if err != nil {
+		return nil, domain.ErrInvalidRecurrence
+	}
+	series.RRule = rule.EndingBefore(time.Unix(t, 0)).String()
+	var exdates []int64
+	for _, exdate := range series.ExDates {
+		if exdate < t {
+			exdates = append(exdates, exdate)
+		}
+	}
+	series.ExDates = exdates
+	series.UpdatedAt = now
+	return &series, nil
+}
+
+// daysBetween counts the calendar days from the date of a to the date of b,
+// both in the location of a.
+func daysBetween(a, b time.Time) int {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.In(a.Location()).Date()
+	return int(time.Date(by, bm, bd, 0, 0, 0, 0, time.UTC).Sub(time.Date(ay, am, ad, 0, 0, 0, 0, time.UTC)).Hours() / 24)
+}
+
+func expandSeries(series *domain.BookingSeries) ([]int64, error) {
+	rule, err := rrule.Parse(series.RRule)
+	if err != nil {
+		return nil, domain.ErrInvalidRecurrence
+	}
+	loc, err := time.LoadLocation(series.Timezone)
+	if err != nil {
+		return nil, domain.ErrInvalidInput
+	}
+
+	exdates := make([]time.Time, len(series.ExDates))
+	for i, exdate := range series.ExDates {
+		exdates[i] = time.Unix(exdate, 0)
+	}
+
+	occurrences, err := rule.Expand(time.Unix(series.StartTime, 0).In(loc), exdates, maxSeriesOccurrences)
+	if err != nil {
+		return nil, domain.ErrInvalidRecurrence
+	}
+
+	starts := make([]int64, len(occurrences))
+	for i, occurrence := range occurrences {
+		starts[i] = occurrence.Unix()
+	}
+	return starts, nil
+}
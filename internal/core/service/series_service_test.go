@@ -0,0 +1,52 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+)
+
+func TestSeriesServiceHoldsOnlyBookedOccurrencesToThePolicy(t *testing.T) {
+	policy := domain.BookingPolicy{MaxAdvance: 3 * 24 * time.Hour}
+	st := newTestStore(t, policy)
+	series := NewBookingSeriesService(st.series, st.bookings, st.rooms, st.users, st.blocks, st.bookingSv, 48*time.Hour, policy)
+	owner := st.addUser(t, domain.UserRoleUser)
+	other := st.addUser(t, domain.UserRoleUser)
+	room := st.addRoom(t, "Building A")
+
+	// The fourth daily occurrence lies beyond the advance window.
+	start := time.Now().UTC().Add(24 * time.Hour).Truncate(time.Hour)
+	newSeries := func() *domain.BookingSeries {
+		return &domain.BookingSeries{
+			UserID:    owner.ID,
+			RoomID:    room.ID,
+			Purpose:   "Standup",
+			RRule:     "FREQ=DAILY;COUNT=4",
+			StartTime: start.Unix(),
+			EndTime:   start.Add(30 * time.Minute).Unix(),
+		}
+	}
+
+	_, err := series.CreateSeries(newSeries(), true)
+	policyErr, ok := domain.AsPolicyError(err)
+	if !ok || len(policyErr.Violations) != 1 || policyErr.Violations[0].Rule != domain.PolicyRuleMaxAdvance {
+		t.Fatalf("CreateSeries() = %v, want a max_advance violation", err)
+	}
+
+	// Once someone else holds the fourth slot it is skipped as a conflict
+	// and no longer held to the policy.
+	fourth := start.AddDate(0, 0, 3)
+	st.addBooking(t, other.ID, room.ID, fourth, fourth.Add(30*time.Minute))
+
+	if _, err := series.CreateSeries(newSeries(), false); err != domain.ErrRoomUnavailable {
+		t.Fatalf("CreateSeries() without skipping conflicts = %v, want ErrRoomUnavailable", err)
+	}
+	result, err := series.CreateSeries(newSeries(), true)
+	if err != nil {
+		t.Fatalf("CreateSeries() skipping conflicts failed: %v", err)
+	}
+	if len(result.Bookings) != 3 || len(result.Conflicts) != 1 {
+		t.Errorf("CreateSeries() booked %d and skipped %d occurrences, want 3 and 1", len(result.Bookings), len(result.Conflicts))
+	}
+}
@@ -1,36 +1,125 @@
 package service
 
-import "github.com/amangirdhar210/meeting-room/internal/core/domain"
+import (
+	"io"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+)
 
 type UserService interface {
 	Register(user *domain.User) error
 	GetAllUsers() ([]domain.User, error)
 	GetUserByID(id string) (*domain.User, error)
-	DeleteUserByID(id string) error
+	UpdateUser(id string, update domain.UserUpdate) (*domain.User, error)
+	// ChangePassword signs the user out of every session but sessionID.
+	ChangePassword(id, sessionID, currentPassword, newPassword string) error
+	DeactivateUser(id string, deactivation domain.UserDeactivation) (*domain.UserDeactivationResult, error)
+}
+
+type PasswordResetService interface {
+	RequestReset(email string) error
+	ResetPassword(token, newPassword string) error
 }
 
 type AuthService interface {
-	Login(email, password string) (token string, user *domain.User, err error)
+	Login(email, password string) (tokens *domain.AuthTokens, user *domain.User, err error)
+	Refresh(refreshToken string) (*domain.AuthTokens, error)
+	Logout(claims domain.AccessTokenClaims) error
+	IsRevoked(claims domain.AccessTokenClaims) (bool, error)
+	RevokeUserSessions(userID string) error
+	RevokeOtherSessions(userID, keepSessionID string) error
+	// StartSession signs in a user who was authenticated elsewhere, such as
+	// by single sign-on.
+	StartSession(user *domain.User) (*domain.AuthTokens, error)
+}
+
+type OIDCService interface {
+	BeginLogin() (authorizationURL, state string, err error)
+	CompleteLogin(state, code string) (tokens *domain.AuthTokens, user *domain.User, err error)
 }
 
 type RoomService interface {
 	AddRoom(room *domain.Room) error
 	GetAllRooms() ([]domain.Room, error)
 	GetRoomByID(id string) (*domain.Room, error)
-	DeleteRoomByID(id string) error
-	SearchRooms(minCapacity, maxCapacity int, floor *int, startTime, endTime *int64) ([]domain.Room, error)
-	CheckAvailability(roomID string, startTime, endTime int64) (bool, []domain.Booking, error)
+	UpdateRoom(id string, update domain.RoomUpdate) (*domain.Room, error)
+	DeleteRoomByID(id string, deletion domain.RoomDeletion) ([]domain.Booking, error)
+	SearchRooms(filter domain.RoomSearchFilter) ([]domain.RoomAvailability, error)
+	CheckAvailability(roomID string, startTime, endTime int64) (*domain.AvailabilityResult, error)
 	GetAvailableSlots(roomID string, date int64, slotDuration int) ([]domain.TimeSlot, error)
 }
 
+type RoomBlockService interface {
+	CreateBlock(block *domain.RoomBlock) ([]domain.BookingWithDetails, error)
+	GetBlocksByRoomID(roomID string) ([]domain.RoomBlock, error)
+	DeleteBlock(roomID, blockID string) error
+}
+
 type BookingService interface {
-	CreateBooking(booking *domain.Booking) error
+	CreateBooking(booking *domain.Booking, actorRole string) error
+	ImportBooking(booking *domain.Booking, actorRole string) error
 	GetBookingByID(bookingID string) (*domain.Booking, error)
-	CancelBooking(bookingID string) error
+	UpdateBooking(bookingID string, update domain.BookingUpdate, actorRole string) (*domain.Booking, error)
+	CancelBooking(bookingID, cancelledBy, reason string) error
 	GetAllBookings() ([]domain.Booking, error)
+	GetBookingsByStatus(status string) ([]domain.Booking, error)
+	GetBookingsByUserIDAndStatus(userID, status string) ([]domain.Booking, error)
 	GetBookingsByRoomID(roomID string) ([]domain.Booking, error)
 	GetBookingsByUserID(userID string) ([]domain.Booking, error)
 	GetBookingsWithDetailsByRoomID(roomID string) ([]domain.BookingWithDetails, error)
 	GetBookingsByDateRange(startDate, endDate int64) ([]domain.Booking, error)
 	GetRoomScheduleByDate(roomID string, date int64) (*domain.RoomScheduleResponse, error)
+	ReleaseSlot(roomID string, start, end int64)
+	CheckPolicy(user *domain.User, actorRole string, room *domain.Room, start, end int64, imported bool) error
+}
+
+type WaitlistService interface {
+	Join(entry *domain.WaitlistEntry) error
+	GetByUserID(userID string) ([]domain.WaitlistEntry, error)
+	Claim(entryID, userID string) (*domain.Booking, error)
+	Leave(entryID, userID string) error
+	ExpireOffers() (int, error)
+}
+
+type ApprovalService interface {
+	GetPending(approverID string) ([]domain.BookingWithDetails, error)
+	Approve(bookingID, approverID, note string) (*domain.Booking, error)
+	Reject(bookingID, approverID, note string) (*domain.Booking, error)
+	ExpireOverdue() (int, error)
+}
+
+type CheckInService interface {
+	CheckIn(bookingID, userID string) (*domain.Booking, error)
+	KioskCheckIn(bookingID, secret string) (*domain.Booking, error)
+	ReleaseNoShows() (int, error)
+	CompleteEnded() (int, error)
+	CreateKioskToken(roomID, name, createdBy string) (secret string, token *domain.KioskToken, err error)
+	ListKioskTokens(roomID string) ([]domain.KioskToken, error)
+	RevokeKioskToken(roomID, tokenID string) error
+}
+
+type BookingSeriesService interface {
+	CreateSeries(series *domain.BookingSeries, skipConflicts bool) (*domain.SeriesResult, error)
+	GetSeries(seriesID string) (*domain.BookingSeries, []domain.Booking, error)
+	UpdateOccurrences(bookingID, scope string, update domain.BookingUpdate, actorRole string) (*domain.SeriesResult, error)
+	CancelOccurrences(bookingID, scope, cancelledBy, reason string) ([]domain.Booking, error)
+}
+
+type BookingGroupService interface {
+	CreateGroup(group *domain.BookingGroup) (*domain.GroupResult, error)
+	GetGroup(groupID string) ([]domain.Booking, error)
+	UpdateGroup(groupID string, update domain.BookingUpdate, actorRole string) (*domain.GroupResult, error)
+	CancelGroup(groupID, cancelledBy, reason string) ([]domain.Booking, error)
+}
+
+type CalendarService interface {
+	CreateToken(userID string) (secret string, token *domain.CalendarToken, err error)
+	ListTokens(userID string) ([]domain.CalendarToken, error)
+	RevokeToken(userID, tokenID string) error
+	GetUserFeed(userID, secret string) (*domain.CalendarFeed, error)
+	GetRoomFeed(roomID, secret string) (*domain.CalendarFeed, error)
+}
+
+type BookingImportService interface {
+	ImportICS(data io.Reader, dryRun bool, actorRole string) (*domain.ImportReport, error)
 }
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"database/sql"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	repository "github.com/amangirdhar210/meeting-room/internal/adapters/repositories/sqlite"
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/ports"
+	"github.com/google/uuid"
+)
+
+// testStore backs the services under test with a real SQLite database, so
+// they see the same overlap and status handling as in production.
+type testStore struct {
+	db        *sql.DB
+	users     ports.UserRepository
+	rooms     ports.RoomRepository
+	bookings  ports.BookingRepository
+	blocks    ports.RoomBlockRepository
+	waitlist  ports.WaitlistRepository
+	series    ports.BookingSeriesRepository
+	notifier  *recordingNotifier
+	bookingSv *bookingService
+}
+
+func newTestStore(t *testing.T, policy domain.BookingPolicy) *testStore {
+	t.Helper()
+	db, err := repository.NewSQLiteConnection(repository.DBConfig{Path: filepath.Join(t.TempDir(), "service.db")})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := repository.InitSQLite(db); err != nil {
+		t.Fatalf("init db: %v", err)
+	}
+
+	st := &testStore{
+		db:       db,
+		users:    repository.NewUserRepository(db),
+		rooms:    repository.NewRoomRepository(db),
+		bookings: repository.NewBookingRepository(db),
+		blocks:   repository.NewRoomBlockRepository(db),
+		waitlist: repository.NewWaitlistRepository(db),
+		series:   repository.NewBookingSeriesRepository(db),
+		notifier: &recordingNotifier{},
+	}
+	st.bookingSv = NewBookingService(st.bookings, st.rooms, st.users, st.blocks, st.waitlist, st.notifier, 30*time.Minute, 48*time.Hour, policy).(*bookingService)
+	return st
+}
+
+func (st *testStore) addUser(t *testing.T, role string) *domain.User {
+	t.Helper()
+	id := uuid.New().String()
+	user := &domain.User{ID: id, Name: "User " + id[:8], Email: id + "@example.com", Password: "x", Role: role, Status: domain.UserStatusActive}
+	if err := st.users.Create(user); err != nil {
+		t.Fatalf("add user: %v", err)
+	}
+	return user
+}
+
+func (st *testStore) addRoom(t *testing.T, location string) *domain.Room {
+	t.Helper()
+	id := uuid.New().String()
+	room := &domain.Room{ID: id, Name: "Room " + id[:8], RoomNumber: int(time.Now().UnixNano() % 100000), Capacity: 4, Location: location, Status: domain.RoomStatusAvailable}
+	if err := st.rooms.Create(room); err != nil {
+		t.Fatalf("add room: %v", err)
+	}
+	return room
+}
+
+// addBooking writes a confirmed booking straight to the repository,
+// bypassing the policy.
+func (st *testStore) addBooking(t *testing.T, userID, roomID string, start, end time.Time) *domain.Booking {
+	t.Helper()
+	now := time.Now().Unix()
+	booking := &domain.Booking{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		RoomID:    roomID,
+		StartTime: start.Unix(),
+		EndTime:   end.Unix(),
+		Purpose:   "Meeting",
+		Status:    domain.BookingStatusConfirmed,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := st.bookings.Create(booking); err != nil {
+		t.Fatalf("add booking: %v", err)
+	}
+	return booking
+}
+
+type recordingNotifier struct {
+	mu   sync.Mutex
+	sent []domain.Notification
+}
+
+func (n *recordingNotifier) Notify(notification domain.Notification) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.sent = append(n.sent, notification)
+	return nil
+}
+
+func (n *recordingNotifier) subjectsFor(userID string) []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	var subjects []string
+	for _, sent := range n.sent {
+		if sent.UserID == userID {
+			subjects = append(subjects, sent.Subject)
+		}
+	}
+	return subjects
+}
@@ -1,6 +1,9 @@
 package service
 
 import (
+	"fmt"
+	"log"
+	"sort"
 	"strings"
 	"time"
 
@@ -11,13 +14,25 @@ import (
 
 type userService struct {
 	repo           ports.UserRepository
+	bookingRepo    ports.BookingRepository
+	roomRepo       ports.RoomRepository
+	seriesRepo     ports.BookingSeriesRepository
 	passwordHasher ports.PasswordHasher
+	bookingService BookingService
+	notifier       ports.Notifier
+	authService    AuthService
 }
 
-func NewUserService(repo ports.UserRepository, hasher ports.PasswordHasher) UserService {
+func NewUserService(repo ports.UserRepository, bookingRepo ports.BookingRepository, roomRepo ports.RoomRepository, seriesRepo ports.BookingSeriesRepository, hasher ports.PasswordHasher, bookingService BookingService, notifier ports.Notifier, authService AuthService) UserService {
 	return &userService{
 		repo:           repo,
+		bookingRepo:    bookingRepo,
+		roomRepo:       roomRepo,
+		seriesRepo:     seriesRepo,
 		passwordHasher: hasher,
+		bookingService: bookingService,
+		notifier:       notifier,
+		authService:    authService,
 	}
 }
 
@@ -31,7 +46,7 @@ func (s *userService) Register(user *domain.User) error {
 	user.Role = strings.TrimSpace(user.Role)
 	user.Password = strings.TrimSpace(user.Password)
 
-	if user.Email == "" || user.Password == "" || user.Name == "" || user.Role == "" {
+	if user.Email == "" || user.Password == "" || user.Name == "" || !domain.IsValidUserRole(user.Role) {
 		return domain.ErrInvalidInput
 	}
 
@@ -49,6 +64,7 @@ func (s *userService) Register(user *domain.User) error {
 	}
 	user.ID = uuid.New().String()
 	user.Password = hashed
+	user.Status = domain.UserStatusActive
 	user.CreatedAt = time.Now().Unix()
 	user.UpdatedAt = time.Now().Unix()
 
@@ -73,9 +89,295 @@ func (s *userService) GetUserByID(id string) (*domain.User, error) {
 	return s.repo.GetByID(id)
 }
 
-func (s *userService) DeleteUserByID(id string) error {
+func (s *userService) UpdateUser(id string, update domain.UserUpdate) (*domain.User, error) {
 	if id == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	user, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Name != nil {
+		user.Name = strings.TrimSpace(*update.Name)
+	}
+	emailChanged := false
+	if update.Email != nil {
+		email := strings.TrimSpace(*update.Email)
+		emailChanged = email != user.Email
+		user.Email = email
+	}
+	roleChanged := false
+	if update.Role != nil {
+		role := strings.TrimSpace(*update.Role)
+		roleChanged = role != user.Role
+		user.Role = role
+	}
+
+	if user.Name == "" || !strings.Contains(user.Email, "@") || !domain.IsValidUserRole(user.Role) {
+		return nil, domain.ErrInvalidInput
+	}
+
+	if emailChanged {
+		existing, err := s.repo.FindByEmail(user.Email)
+		if err != nil && err != domain.ErrNotFound {
+			return nil, err
+		}
+		if existing != nil && existing.ID != user.ID {
+			return nil, domain.ErrConflict
+		}
+	}
+
+	// Existing tokens carry the old role, so the user has to sign in again.
+	if roleChanged {
+		if err := s.authService.RevokeUserSessions(user.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	user.UpdatedAt = time.Now().Unix()
+	if err := s.repo.Update(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *userService) ChangePassword(id, sessionID, currentPassword, newPassword string) error {
+	currentPassword = strings.TrimSpace(currentPassword)
+	newPassword = strings.TrimSpace(newPassword)
+	if id == "" || sessionID == "" || currentPassword == "" || len(newPassword) < domain.MinPasswordLength {
 		return domain.ErrInvalidInput
 	}
-	return s.repo.DeleteByID(id)
+
+	user, err := s.repo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if !s.passwordHasher.VerifyPassword(user.Password, currentPassword) {
+		return domain.ErrIncorrectPassword
+	}
+
+	hashed, err := s.passwordHasher.HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.UpdatePassword(id, hashed, time.Now().Unix()); err != nil {
+		return err
+	}
+	return s.authService.RevokeOtherSessions(id, sessionID)
+}
+
+func (s *userService) DeactivateUser(id string, deactivation domain.UserDeactivation) (*domain.UserDeactivationResult, error) {
+	if id == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	user, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsDisabled() {
+		return nil, domain.ErrConflict
+	}
+
+	var delegate *domain.User
+	if delegateID := strings.TrimSpace(deactivation.DelegateID); delegateID != "" {
+		if delegateID == id {
+			return nil, domain.ErrInvalidInput
+		}
+		delegate, err = s.repo.GetByID(delegateID)
+		if err != nil {
+			return nil, err
+		}
+		if delegate.IsDisabled() {
+			return nil, domain.ErrUserDisabled
+		}
+	}
+
+	if err := s.authService.RevokeUserSessions(id); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	if err := s.repo.Disable(id, now); err != nil {
+		return nil, err
+	}
+
+	// Bookings are read after disabling so none made in between are missed;
+	// disabled users cannot create new ones.
+	upcoming, err := s.upcomingBookings(id, now)
+	if err != nil {
+		return nil, err
+	}
+
+	reason := strings.TrimSpace(deactivation.Reason)
+	if reason == "" {
+		reason = fmt.Sprintf("%s's account has been deactivated", user.Name)
+	}
+
+	result := &domain.UserDeactivationResult{Cancelled: []domain.Booking{}, Reassigned: []domain.Booking{}}
+	// The first occurrence each series lost, so the series can end before it.
+	seriesCancelledFrom := map[string]int64{}
+	for _, members := range groupedBookings(upcoming) {
+		ids := make([]string, len(members))
+		for i, booking := range members {
+			ids[i] = booking.ID
+		}
+
+		if delegate != nil {
+			if err := s.bookingRepo.ReassignAll(ids, delegate.ID, now); err != nil {
+				if err == domain.ErrBookingNotActive {
+					continue
+				}
+				return result, err
+			}
+			for _, booking := range members {
+				booking.UserID = delegate.ID
+				booking.Sequence++
+				booking.UpdatedAt = now
+				result.Reassigned = append(result.Reassigned, booking)
+				s.notify(delegate, "Booking reassigned to you", booking,
+					fmt.Sprintf("was handed over to you from %s", user.Name))
+			}
+			continue
+		}
+
+		if err := s.bookingRepo.CancelAll(ids, deactivation.DisabledBy, reason, now); err != nil {
+			if err == domain.ErrBookingNotActive {
+				continue
+			}
+			return result, err
+		}
+		for _, booking := range members {
+			booking.Status = domain.BookingStatusCancelled
+			booking.CancelledAt = now
+			booking.CancelledBy = deactivation.DisabledBy
+			booking.CancellationReason = reason
+			result.Cancelled = append(result.Cancelled, booking)
+			if _, seen := seriesCancelledFrom[booking.SeriesID]; booking.SeriesID != "" && !seen {
+				seriesCancelledFrom[booking.SeriesID] = booking.StartTime
+			}
+			s.notify(user, "Booking cancelled", booking, "was cancelled: "+reason)
+			s.bookingService.ReleaseSlot(booking.RoomID, booking.StartTime, booking.EndTime)
+		}
+	}
+
+	if delegate != nil {
+		return result, s.reassignSeries(id, delegate.ID, result.Reassigned, now)
+	}
+	return result, s.endSeries(seriesCancelledFrom, now)
+}
+
+// groupedBookings splits bookings into the units a deactivation changes at
+// once: all bookings of a group together, every other booking on its own.
+func groupedBookings(bookings []domain.Booking) [][]domain.Booking {
+	var units [][]domain.Booking
+	groupUnit := map[string]int{}
+	for _, booking := range bookings {
+		if booking.GroupID == "" {
+			units = append(units, []domain.Booking{booking})
+			continue
+		}
+		if i, ok := groupUnit[booking.GroupID]; ok {
+			units[i] = append(units[i], booking)
+			continue
+		}
+		groupUnit[booking.GroupID] = len(units)
+		units = append(units, []domain.Booking{booking})
+	}
+	return units
+}
+
+// reassignSeries hands the series of the reassigned bookings over to the
+// delegate as well, so later changes to them are the delegate's to make.
+func (s *userService) reassignSeries(userID, delegateID string, reassigned []domain.Booking, now int64) error {
+	done := map[string]bool{}
+	for _, booking := range reassigned {
+		if booking.SeriesID == "" || done[booking.SeriesID] {
+			continue
+		}
+		done[booking.SeriesID] = true
+
+		series, err := s.seriesRepo.GetByID(booking.SeriesID)
+		if err == domain.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if series.UserID != userID {
+			continue
+		}
+		series.UserID = delegateID
+		series.UpdatedAt = now
+		if err := s.seriesRepo.Update(series); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// endSeries ends each series before the first occurrence the deactivation
+// cancelled, and cancels the series outright when that was its first.
+func (s *userService) endSeries(cancelledFrom map[string]int64, now int64) error {
+	for seriesID, from := range cancelledFrom {
+		series, err := s.seriesRepo.GetByID(seriesID)
+		if err == domain.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		ended, err := seriesEndingBefore(*series, from, now)
+		if err != nil {
+			return err
+		}
+		if from <= series.StartTime {
+			ended.Status = domain.BookingStatusCancelled
+		}
+		if err := s.seriesRepo.Update(ended); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *userService) upcomingBookings(userID string, now int64) ([]domain.Booking, error) {
+	bookings, err := s.bookingRepo.GetByUserID(userID)
+	if err != nil && err != domain.ErrNotFound {
+		return nil, err
+	}
+
+	var upcoming []domain.Booking
+	for _, b := range bookings {
+		if b.IsActive() && b.EndTime > now {
+			upcoming = append(upcoming, b)
+		}
+	}
+	sort.Slice(upcoming, func(i, j int) bool {
+		return upcoming[i].StartTime < upcoming[j].StartTime
+	})
+	return upcoming, nil
+}
+
+// notify tells a user what happened to a booking during a deactivation.
+// Delivery failures are logged rather than undoing the deactivation.
+func (s *userService) notify(user *domain.User, subject string, booking domain.Booking, outcome string) {
+	roomName := booking.RoomID
+	if room, err := s.roomRepo.GetByID(booking.RoomID); err == nil && room != nil {
+		roomName = room.Name
+	}
+
+	err := s.notifier.Notify(domain.Notification{
+		UserID:  user.ID,
+		Email:   user.Email,
+		Name:    user.Name,
+		Subject: subject,
+		Message: fmt.Sprintf("The booking %q in %s at %s %s.",
+			booking.Purpose, roomName, time.Unix(booking.StartTime, 0).UTC().Format(time.RFC3339), outcome),
+	})
+	if err != nil {
+		log.Printf("Failed to notify %s about booking %s: %v", user.Email, booking.ID, err)
+	}
 }
@@ -0,0 +1,359 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/ports"
+	"github.com/amangirdhar210/meeting-room/internal/pkg/utils"
+	"github.com/google/uuid"
+)
+
+type waitlistService struct {
+	repo           ports.WaitlistRepository
+	bookingService BookingService
+	bookingRepo    ports.BookingRepository
+	roomRepo       ports.RoomRepository
+	userRepo       ports.UserRepository
+	blockRepo      ports.RoomBlockRepository
+}
+
+func NewWaitlistService(wRepo ports.WaitlistRepository, bookingService BookingService, bRepo ports.BookingRepository, rRepo ports.RoomRepository, uRepo ports.UserRepository, blRepo ports.RoomBlockRepository) WaitlistService {
+	return &waitlistService{
+		repo:           wRepo,
+		bookingService: bookingService,
+		bookingRepo:    bRepo,
+		roomRepo:       rRepo,
+		userRepo:       uRepo,
+		blockRepo:      blRepo,
+	}
+}
+
+// Join puts the user on the waitlist for a window that is taken by other
+// bookings. A free window fails with ErrRoomAvailable, and blocked or closed
+// rooms and windows the booking policy refuses fail the same way
+// CreateBooking would.
+func (s *waitlistService) Join(entry *domain.WaitlistEntry) error {
+	if entry == nil || entry.UserID == "" || entry.RoomID == "" {
+		return domain.ErrInvalidInput
+	}
+	if err := checkTimeRange(entry.StartTime, entry.EndTime); err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.GetByID(entry.UserID)
+	if err != nil {
+		return err
+	}
+	if user.IsDisabled() {
+		return domain.ErrUserDisabled
+	}
+	room, err := s.roomRepo.GetByID(entry.RoomID)
+	if err != nil {
+		return err
+	}
+	if err := s.bookingService.CheckPolicy(user, user.Role, room, entry.StartTime, entry.EndTime, false); err != nil {
+		return err
+	}
+
+	switch err := checkSlot(s.bookingRepo, s.blockRepo, room, entry.StartTime, entry.EndTime); err {
+	case domain.ErrRoomUnavailable:
+	case nil:
+		return domain.ErrRoomAvailable
+	default:
+		return err
+	}
+
+	pending, err := s.repo.GetPendingByRoomAndTime(entry.RoomID, entry.StartTime, entry.EndTime)
+	if err != nil {
+		return err
+	}
+	for _, existing := range pending {
+		if existing.UserID == entry.UserID && existing.StartTime == entry.StartTime && existing.EndTime == entry.EndTime {
+			return domain.ErrConflict
+		}
+	}
+
+	now := time.Now().Unix()
+	entry.ID = uuid.New().String()
+	entry.Purpose = strings.TrimSpace(entry.Purpose)
+	entry.Status = domain.WaitlistStatusWaiting
+	entry.BookingID = ""
+	entry.OfferExpiresAt = 0
+	entry.CreatedAt = now
+	entry.UpdatedAt = now
+	return s.repo.Create(entry)
+}
+
+func (s *waitlistService) GetByUserID(userID string) ([]domain.WaitlistEntry, error) {
+	if userID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+	return s.repo.GetByUserID(userID)
+}
+
+// Claim books an offered entry for its owner. An offer does not hold the
+// room, so when someone else booked it meanwhile the entry goes back to
+// waiting.
+func (s *waitlistService) Claim(entryID, userID string) (*domain.Booking, error) {
+	entry, err := s.ownEntry(entryID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	if !entry.HasOpenOffer(now) {
+		if entry.Status == domain.WaitlistStatusOffered {
+			// The offer lapsed; pass the time on to whoever is next.
+			s.bookingService.ReleaseSlot(entry.RoomID, entry.StartTime, entry.EndTime)
+		}
+		return nil, domain.ErrWaitlistNotOffered
+	}
+
+	user, err := s.userRepo.GetByID(entry.UserID)
+	if err != nil {
+		return nil, err
+	}
+	booking := &domain.Booking{
+		UserID:    entry.UserID,
+		RoomID:    entry.RoomID,
+		StartTime: entry.StartTime,
+		EndTime:   entry.EndTime,
+		Purpose:   entry.Purpose,
+	}
+	err = s.bookingService.CreateBooking(booking, user.Role)
+	if err == domain.ErrRoomUnavailable {
+		entry.Status = domain.WaitlistStatusWaiting
+		entry.OfferExpiresAt = 0
+		entry.UpdatedAt = now
+		if err := s.repo.Update(entry); err != nil {
+			return nil, err
+		}
+		return nil, domain.ErrRoomUnavailable
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	entry.Status = domain.WaitlistStatusBooked
+	entry.BookingID = booking.ID
+	entry.UpdatedAt = now
+	if err := s.repo.Update(entry); err != nil {
+		return nil, err
+	}
+	return booking, nil
+}
+
+// Leave takes the user off the waitlist. Turning down an open offer passes
+// it on to the next entry.
+func (s *waitlistService) Leave(entryID, userID string) error {
+	entry, err := s.ownEntry(entryID, userID)
+	if err != nil {
+		return err
+	}
+	if entry.Status != domain.WaitlistStatusWaiting && entry.Status != domain.WaitlistStatusOffered {
+		return domain.ErrConflict
+	}
+
+	wasOffered := entry.Status == domain.WaitlistStatusOffered
+	entry.Status = domain.WaitlistStatusCancelled
+	entry.OfferExpiresAt = 0
+	entry.UpdatedAt = time.Now().Unix()
+	if err := s.repo.Update(entry); err != nil {
+		return err
+	}
+
+	if wasOffered {
+		s.bookingService.ReleaseSlot(entry.RoomID, entry.StartTime, entry.EndTime)
+	}
+	return nil
+}
+
+// ExpireOffers closes offers whose claim window has passed and hands their
+// time to the next entries, so the queue moves on even when nobody comes
+// back to claim or turn down an offer.
+func (s *waitlistService) ExpireOffers() (int, error) {
+	now := time.Now().Unix()
+	entries, err := s.repo.GetLapsedOffers(now)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for i := range entries {
+		entry := &entries[i]
+		entry.Status = domain.WaitlistStatusExpired
+		entry.OfferExpiresAt = 0
+		entry.UpdatedAt = now
+		if err := s.repo.Update(entry); err != nil {
+			return expired, err
+		}
+		expired++
+		s.bookingService.ReleaseSlot(entry.RoomID, entry.StartTime, entry.EndTime)
+	}
+	return expired, nil
+}
+
+func (s *waitlistService) ownEntry(entryID, userID string) (*domain.WaitlistEntry, error) {
+	if entryID == "" || userID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+	entry, err := s.repo.GetByID(entryID)
+	if err != nil {
+		return nil, err
+	}
+	// Other users' entries are reported as missing rather than forbidden.
+	if entry.UserID != userID {
+		return nil, domain.ErrNotFound
+	}
+	return entry, nil
+}
+
+// ReleaseSlot passes time on roomID that a booking or an offer has just let
+// go of to the waitlist. Letting go has already happened and must not be
+// undone by a failing waitlist, so errors are only logged.
+func (s *bookingService) ReleaseSlot(roomID string, start, end int64) {
+	if end <= time.Now().Unix() {
+		return
+	}
+	if err := s.promoteWaitlist(roomID, start, end); err != nil {
+		log.Printf("Failed to promote waitlist for room %s between %d and %d: %v", roomID, start, end, err)
+	}
+}
+
+// promoteWaitlist hands free time in [start, end) on roomID to the
+// waitlist. Entries are taken oldest first; each one that now fits is
+// booked straight away when it asked for AutoBook, otherwise offered to its
+// owner for the claim window. An open offer keeps later entries for the same
+// time waiting until it is claimed, declined or lapses.
+func (s *bookingService) promoteWaitlist(roomID string, start, end int64) error {
+	entries, err := s.waitlistRepo.GetPendingByRoomAndTime(roomID, start, end)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	room, err := s.roomRepo.GetByID(roomID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().Unix()
+	var held []domain.WaitlistEntry
+	for _, entry := range entries {
+		if entry.HasOpenOffer(now) {
+			held = append(held, entry)
+		}
+	}
+
+	for i := range entries {
+		entry := &entries[i]
+		if entry.HasOpenOffer(now) {
+			continue
+		}
+
+		switch {
+		case entry.Status == domain.WaitlistStatusOffered || entry.StartTime <= now:
+			// Either the claim window or the requested time itself has
+			// passed.
+			entry.Status = domain.WaitlistStatusExpired
+			entry.OfferExpiresAt = 0
+		case overlapsEntry(held, *entry):
+			continue
+		case entry.AutoBook:
+			booking := &domain.Booking{
+				UserID:    entry.UserID,
+				RoomID:    entry.RoomID,
+				StartTime: entry.StartTime,
+				EndTime:   entry.EndTime,
+				Purpose:   entry.Purpose,
+			}
+			switch err := s.createOwnBooking(booking); err {
+			case nil:
+				entry.Status = domain.WaitlistStatusBooked
+				entry.BookingID = booking.ID
+			case domain.ErrUserDisabled, domain.ErrNotFound:
+				entry.Status = domain.WaitlistStatusCancelled
+			case domain.ErrRoomUnavailable, domain.ErrRoomBlocked, domain.ErrRoomNotBookable:
+				continue
+			default:
+				// The user may be at their quota now; leave the entry
+				// waiting rather than failing the whole promotion.
+				if _, ok := domain.AsPolicyError(err); ok {
+					continue
+				}
+				return err
+			}
+		default:
+			switch err := checkSlot(s.repo, s.blockRepo, room, entry.StartTime, entry.EndTime); err {
+			case nil:
+				entry.Status = domain.WaitlistStatusOffered
+				entry.OfferExpiresAt = now + int64(s.claimWindow.Seconds())
+				held = append(held, *entry)
+			case domain.ErrRoomUnavailable, domain.ErrRoomBlocked, domain.ErrRoomNotBookable:
+				continue
+			default:
+				return err
+			}
+		}
+
+		entry.UpdatedAt = now
+		if err := s.waitlistRepo.Update(entry); err != nil {
+			return err
+		}
+		s.notifyWaitlist(*entry, room)
+	}
+	return nil
+}
+
+func overlapsEntry(entries []domain.WaitlistEntry, entry domain.WaitlistEntry) bool {
+	for _, other := range entries {
+		if other.ID != entry.ID && utils.Overlaps(entry.StartTime, entry.EndTime, other.StartTime, other.EndTime) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *bookingService) notifyWaitlist(entry domain.WaitlistEntry, room *domain.Room) {
+	var subject, message string
+	at := time.Unix(entry.StartTime, 0).UTC().Format(time.RFC3339)
+	switch entry.Status {
+	case domain.WaitlistStatusBooked:
+		subject = "Waitlisted booking confirmed"
+		message = fmt.Sprintf("%s became free at %s, so %q is now booked for you.", room.Name, at, entry.Purpose)
+		if room.RequiresApproval {
+			subject = "Waitlisted booking awaiting approval"
+			message = fmt.Sprintf("%s became free at %s, so %q has been requested for you and is waiting for approval.", room.Name, at, entry.Purpose)
+		}
+	case domain.WaitlistStatusOffered:
+		subject = "Room available from the waitlist"
+		message = fmt.Sprintf("%s became free at %s for %q. Claim it before %s, after that it goes to the next person waiting.",
+			room.Name, at, entry.Purpose, time.Unix(entry.OfferExpiresAt, 0).UTC().Format(time.RFC3339))
+	case domain.WaitlistStatusExpired:
+		subject = "Waitlist entry expired"
+		message = fmt.Sprintf("Your waitlist entry for %s at %s expired.", room.Name, at)
+	default:
+		return
+	}
+
+	user, err := s.userRepo.GetByID(entry.UserID)
+	if err != nil {
+		log.Printf("Cannot notify owner of waitlist entry %s: %v", entry.ID, err)
+		return
+	}
+	err = s.notifier.Notify(domain.Notification{
+		UserID:  user.ID,
+		Email:   user.Email,
+		Name:    user.Name,
+		Subject: subject,
+		Message: message,
+	})
+	if err != nil {
+		log.Printf("Failed to notify %s about waitlist entry %s: %v", user.Email, entry.ID, err)
+	}
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+)
+
+func TestWaitlistExpireOffersPassesLapsedOfferToNextEntry(t *testing.T) {
+	st := newTestStore(t, domain.BookingPolicy{})
+	waitlist := NewWaitlistService(st.waitlist, st.bookingSv, st.bookings, st.rooms, st.users, st.blocks)
+
+	owner := st.addUser(t, domain.UserRoleUser)
+	first := st.addUser(t, domain.UserRoleUser)
+	second := st.addUser(t, domain.UserRoleUser)
+	room := st.addRoom(t, "Building A")
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	booking := st.addBooking(t, owner.ID, room.ID, start, start.Add(time.Hour))
+
+	var entries []*domain.WaitlistEntry
+	for i, user := range []*domain.User{first, second} {
+		entry := &domain.WaitlistEntry{
+			ID:        user.ID,
+			UserID:    user.ID,
+			RoomID:    room.ID,
+			StartTime: booking.StartTime,
+			EndTime:   booking.EndTime,
+			Purpose:   "Retro",
+			Status:    domain.WaitlistStatusWaiting,
+			CreatedAt: time.Now().Unix() - int64(10-i),
+		}
+		if err := st.waitlist.Create(entry); err != nil {
+			t.Fatalf("add waitlist entry: %v", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := st.bookingSv.CancelBooking(booking.ID, owner.ID, ""); err != nil {
+		t.Fatalf("cancel: %v", err)
+	}
+	offered, err := st.waitlist.GetByID(entries[0].ID)
+	if err != nil {
+		t.Fatalf("get first entry: %v", err)
+	}
+	if offered.Status != domain.WaitlistStatusOffered {
+		t.Fatalf("first entry status = %q, want offered", offered.Status)
+	}
+
+	// An open offer is left alone.
+	if expired, err := waitlist.ExpireOffers(); err != nil || expired != 0 {
+		t.Fatalf("ExpireOffers with an open offer = %d, %v; want 0, nil", expired, err)
+	}
+
+	offered.OfferExpiresAt = time.Now().Add(-time.Minute).Unix()
+	if err := st.waitlist.Update(offered); err != nil {
+		t.Fatalf("lapse offer: %v", err)
+	}
+	expired, err := waitlist.ExpireOffers()
+	if err != nil {
+		t.Fatalf("ExpireOffers: %v", err)
+	}
+	if expired != 1 {
+		t.Fatalf("ExpireOffers = %d, want 1", expired)
+	}
+
+	got, _ := st.waitlist.GetByID(entries[0].ID)
+	if got.Status != domain.WaitlistStatusExpired {
+		t.Errorf("first entry status = %q, want expired", got.Status)
+	}
+	got, _ = st.waitlist.GetByID(entries[1].ID)
+	if got.Status != domain.WaitlistStatusOffered || got.OfferExpiresAt <= time.Now().Unix() {
+		t.Errorf("second entry = %q until %d, want an open offer", got.Status, got.OfferExpiresAt)
+	}
+	if subjects := st.notifier.subjectsFor(second.ID); len(subjects) != 1 {
+		t.Errorf("second user got %v, want one offer", subjects)
+	}
+}
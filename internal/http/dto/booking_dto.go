@@ -8,14 +8,61 @@ type CreateBookingRequest struct {
 	Purpose   string `json:"purpose" validate:"required"`
 }
 
-type BookingDTO struct {
-	ID        string `json:"id"`
-	UserID    string `json:"user_id"`
-	RoomID    string `json:"room_id"`
-	StartTime int64  `json:"start_time"`
-	EndTime   int64  `json:"end_time"`
+type UpdateBookingRequest struct {
+	RoomID    *string `json:"room_id"`
+	StartTime *string `json:"start_time"`
+	EndTime   *string `json:"end_time"`
+	Purpose   *string `json:"purpose"`
+}
+
+type CancelBookingRequest struct {
+	Reason string `json:"reason"`
+}
+
+// JoinWaitlistRequest asks to be put on the waitlist for a booked-out slot.
+// With auto_book the booking is made as soon as the slot frees up; otherwise
+// the slot is offered and has to be claimed.
+type JoinWaitlistRequest struct {
+	RoomID    string `json:"room_id" validate:"required"`
+	StartTime string `json:"start_time" validate:"required,datetime"`
+	EndTime   string `json:"end_time" validate:"required,datetime"`
 	Purpose   string `json:"purpose"`
-	Status    string `json:"status,omitempty"`
+	AutoBook  bool   `json:"auto_book"`
+}
+
+type WaitlistEntryDTO struct {
+	ID             string `json:"id"`
+	UserID         string `json:"user_id"`
+	RoomID         string `json:"room_id"`
+	StartTime      int64  `json:"start_time"`
+	EndTime        int64  `json:"end_time"`
+	Purpose        string `json:"purpose"`
+	AutoBook       bool   `json:"auto_book"`
+	Status         string `json:"status"`
+	BookingID      string `json:"booking_id,omitempty"`
+	OfferExpiresAt int64  `json:"offer_expires_at,omitempty"`
+	CreatedAt      int64  `json:"created_at"`
+}
+
+type BookingDTO struct {
+	ID                 string `json:"id"`
+	UserID             string `json:"user_id"`
+	RoomID             string `json:"room_id"`
+	StartTime          int64  `json:"start_time"`
+	EndTime            int64  `json:"end_time"`
+	Purpose            string `json:"purpose"`
+	Status             string `json:"status,omitempty"`
+	CancelledAt        int64  `json:"cancelled_at,omitempty"`
+	CancelledBy        string `json:"cancelled_by,omitempty"`
+	CancellationReason string `json:"cancellation_reason,omitempty"`
+	SeriesID           string `json:"series_id,omitempty"`
+	GroupID            string `json:"group_id,omitempty"`
+	ApprovalExpiresAt  int64  `json:"approval_expires_at,omitempty"`
+	ReviewedBy         string `json:"reviewed_by,omitempty"`
+	ReviewedAt         int64  `json:"reviewed_at,omitempty"`
+	ReviewNote         string `json:"review_note,omitempty"`
+	CheckedInAt        int64  `json:"checked_in_at,omitempty"`
+	ReleasedAt         int64  `json:"released_at,omitempty"`
 }
 
 type DetailedBookingDTO struct {
@@ -33,6 +80,29 @@ type DetailedBookingDTO struct {
 	Status     string `json:"status"`
 }
 
+// ReviewBookingRequest is the optional body of the approve and reject
+// endpoints; the note is passed on to the booker.
+// PolicyViolationResponse is returned with 422 when a booking breaks the
+// booking policy, listing every rule it broke.
+type PolicyViolationResponse struct {
+	Error      string               `json:"error"`
+	Violations []PolicyViolationDTO `json:"violations"`
+}
+
+type PolicyViolationDTO struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+type ReviewBookingRequest struct {
+	Note string `json:"note"`
+}
+
+type PendingApprovalDTO struct {
+	DetailedBookingDTO
+	ApprovalExpiresAt int64 `json:"approval_expires_at"`
+}
+
 type ConflictingBookingDTO struct {
 	BookingID string `json:"bookingId"`
 	StartTime int64  `json:"startTime"`
@@ -61,4 +131,134 @@ type BookingDynamoDBItem struct {
 	Status    string `dynamodbav:"Status"`
 	CreatedAt int64  `dynamodbav:"CreatedAt"`
 	UpdatedAt int64  `dynamodbav:"UpdatedAt"`
+
+	SeriesID           string `dynamodbav:"SeriesID,omitempty"`
+	GroupID            string `dynamodbav:"GroupID,omitempty"`
+	CancelledAt        int64  `dynamodbav:"CancelledAt,omitempty"`
+	CancelledBy        string `dynamodbav:"CancelledBy,omitempty"`
+	CancellationReason string `dynamodbav:"CancellationReason,omitempty"`
+	Sequence           int    `dynamodbav:"Sequence,omitempty"`
+	ApprovalExpiresAt  int64  `dynamodbav:"ApprovalExpiresAt,omitempty"`
+	ReviewedBy         string `dynamodbav:"ReviewedBy,omitempty"`
+	ReviewedAt         int64  `dynamodbav:"ReviewedAt,omitempty"`
+	ReviewNote         string `dynamodbav:"ReviewNote,omitempty"`
+	CheckedInAt        int64  `dynamodbav:"CheckedInAt,omitempty"`
+	ReleasedAt         int64  `dynamodbav:"ReleasedAt,omitempty"`
+}
+
+type BookingSeriesDynamoDBItem struct {
+	PK        string  `dynamodbav:"PK"`
+	SK        string  `dynamodbav:"SK"`
+	ID        string  `dynamodbav:"ID"`
+	UserID    string  `dynamodbav:"UserID"`
+	RoomID    string  `dynamodbav:"RoomID"`
+	Purpose   string  `dynamodbav:"Purpose"`
+	RRule     string  `dynamodbav:"RRule"`
+	Timezone  string  `dynamodbav:"Timezone"`
+	StartTime int64   `dynamodbav:"StartTime"`
+	EndTime   int64   `dynamodbav:"EndTime"`
+	ExDates   []int64 `dynamodbav:"ExDates"`
+	Status    string  `dynamodbav:"Status"`
+	CreatedAt int64   `dynamodbav:"CreatedAt"`
+	UpdatedAt int64   `dynamodbav:"UpdatedAt"`
+}
+
+type CreateBookingSeriesRequest struct {
+	RoomID        string   `json:"room_id"`
+	StartTime     string   `json:"start_time"`
+	EndTime       string   `json:"end_time"`
+	Purpose       string   `json:"purpose"`
+	RRule         string   `json:"rrule"`
+	Timezone      string   `json:"timezone"`
+	ExDates       []string `json:"exdates"`
+	SkipConflicts bool     `json:"skip_conflicts"`
+}
+
+type BookingSeriesDTO struct {
+	ID        string  `json:"id"`
+	UserID    string  `json:"user_id"`
+	RoomID    string  `json:"room_id"`
+	Purpose   string  `json:"purpose"`
+	RRule     string  `json:"rrule"`
+	Timezone  string  `json:"timezone"`
+	StartTime int64   `json:"start_time"`
+	EndTime   int64   `json:"end_time"`
+	ExDates   []int64 `json:"exdates,omitempty"`
+	Status    string  `json:"status"`
+}
+
+type OccurrenceConflictDTO struct {
+	StartTime             int64    `json:"start_time"`
+	EndTime               int64    `json:"end_time"`
+	ConflictingBookingIDs []string `json:"conflicting_booking_ids,omitempty"`
+	ConflictingBlockIDs   []string `json:"conflicting_block_ids,omitempty"`
+}
+
+type BookingSeriesResponse struct {
+	Series    *BookingSeriesDTO       `json:"series,omitempty"`
+	Bookings  []BookingDTO            `json:"bookings"`
+	Conflicts []OccurrenceConflictDTO `json:"conflicts"`
+}
+
+type CreateBookingGroupRequest struct {
+	RoomIDs   []string `json:"room_ids"`
+	StartTime string   `json:"start_time"`
+	EndTime   string   `json:"end_time"`
+	Purpose   string   `json:"purpose"`
+}
+
+// UpdateBookingGroupRequest reschedules or renames every booking of a group;
+// the group's rooms cannot be changed.
+type UpdateBookingGroupRequest struct {
+	StartTime *string `json:"start_time"`
+	EndTime   *string `json:"end_time"`
+	Purpose   *string `json:"purpose"`
+}
+
+type RoomConflictDTO struct {
+	RoomID                string   `json:"room_id"`
+	Reason                string   `json:"reason"`
+	ConflictingBookingIDs []string `json:"conflicting_booking_ids,omitempty"`
+	ConflictingBlockIDs   []string `json:"conflicting_block_ids,omitempty"`
+}
+
+type BookingGroupResponse struct {
+	GroupID   string            `json:"group_id,omitempty"`
+	Bookings  []BookingDTO      `json:"bookings"`
+	Conflicts []RoomConflictDTO `json:"conflicts"`
+}
+
+type ImportResultDTO struct {
+	UID       string `json:"uid,omitempty"`
+	Summary   string `json:"summary,omitempty"`
+	StartTime int64  `json:"start_time,omitempty"`
+	EndTime   int64  `json:"end_time,omitempty"`
+	RoomID    string `json:"room_id,omitempty"`
+	UserID    string `json:"user_id,omitempty"`
+	BookingID string `json:"booking_id,omitempty"`
+	Status    string `json:"status"`
+	Message   string `json:"message,omitempty"`
+}
+
+type ImportReportDTO struct {
+	DryRun  bool              `json:"dry_run"`
+	Summary map[string]int    `json:"summary"`
+	Results []ImportResultDTO `json:"results"`
+}
+
+type WaitlistEntryDynamoDBItem struct {
+	PK             string `dynamodbav:"PK"`
+	SK             string `dynamodbav:"SK"`
+	ID             string `dynamodbav:"ID"`
+	UserID         string `dynamodbav:"UserID"`
+	RoomID         string `dynamodbav:"RoomID"`
+	StartTime      int64  `dynamodbav:"StartTime"`
+	EndTime        int64  `dynamodbav:"EndTime"`
+	Purpose        string `dynamodbav:"Purpose"`
+	AutoBook       bool   `dynamodbav:"AutoBook"`
+	Status         string `dynamodbav:"Status"`
+	BookingID      string `dynamodbav:"BookingID,omitempty"`
+	OfferExpiresAt int64  `dynamodbav:"OfferExpiresAt"`
+	CreatedAt      int64  `dynamodbav:"CreatedAt"`
+	UpdatedAt      int64  `dynamodbav:"UpdatedAt"`
 }
@@ -0,0 +1,22 @@
+package dto
+
+type CalendarTokenDTO struct {
+	ID        string `json:"id"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+type CreateCalendarTokenResponse struct {
+	ID        string `json:"id"`
+	Token     string `json:"token"`
+	UserFeed  string `json:"user_feed"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+type CalendarTokenDynamoDBItem struct {
+	PK        string `dynamodbav:"PK"`
+	SK        string `dynamodbav:"SK"`
+	ID        string `dynamodbav:"ID"`
+	UserID    string `dynamodbav:"UserID"`
+	TokenHash string `dynamodbav:"TokenHash"`
+	CreatedAt int64  `dynamodbav:"CreatedAt"`
+}
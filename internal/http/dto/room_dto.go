@@ -9,6 +9,52 @@ type AddRoomRequest struct {
 	Status      string   `json:"status"`
 	Location    string   `json:"location" validate:"required"`
 	Description string   `json:"description,omitempty"`
+
+	RequiresApproval bool           `json:"requiresApproval"`
+	Approvers        []string       `json:"approvers"`
+	BookingPolicy    *RoomPolicyDTO `json:"bookingPolicy"`
+}
+
+// UpdateRoomRequest backs both PUT and PATCH; nil fields are left unchanged
+// on PATCH.
+type UpdateRoomRequest struct {
+	Name        *string   `json:"name"`
+	RoomNumber  *int      `json:"roomNumber"`
+	Capacity    *int      `json:"capacity"`
+	Floor       *int      `json:"floor"`
+	Amenities   *[]string `json:"amenities"`
+	Status      *string   `json:"status"`
+	Location    *string   `json:"location"`
+	Description *string   `json:"description"`
+
+	RequiresApproval *bool          `json:"requiresApproval"`
+	Approvers        *[]string      `json:"approvers"`
+	BookingPolicy    *RoomPolicyDTO `json:"bookingPolicy"`
+}
+
+// RoomPolicyDTO overrides the global booking policy for one room. Limits are
+// in seconds; omitted or zero fields keep the global value.
+type RoomPolicyDTO struct {
+	MinDurationSeconds int64 `json:"minDurationSeconds,omitempty"`
+	MaxDurationSeconds int64 `json:"maxDurationSeconds,omitempty"`
+	MaxAdvanceSeconds  int64 `json:"maxAdvanceSeconds,omitempty"`
+	AlignmentSeconds   int64 `json:"alignmentSeconds,omitempty"`
+}
+
+type DeleteRoomRequest struct {
+	Reason string `json:"reason"`
+}
+
+type DeleteRoomResponse struct {
+	Message           string       `json:"message"`
+	CancelledBookings []BookingDTO `json:"cancelled_bookings"`
+}
+
+// RoomHasBookingsResponse is returned with 409 when a room with upcoming
+// bookings is deleted without force.
+type RoomHasBookingsResponse struct {
+	Error            string       `json:"error"`
+	UpcomingBookings []BookingDTO `json:"upcoming_bookings"`
 }
 
 type RoomDTO struct {
@@ -21,6 +67,10 @@ type RoomDTO struct {
 	Status      string   `json:"status"`
 	Location    string   `json:"location"`
 	Description string   `json:"description,omitempty"`
+
+	RequiresApproval bool           `json:"requiresApproval"`
+	Approvers        []string       `json:"approvers,omitempty"`
+	BookingPolicy    *RoomPolicyDTO `json:"bookingPolicy,omitempty"`
 }
 
 type RoomWithAvailabilityDTO struct {
@@ -33,6 +83,7 @@ type RoomWithAvailabilityDTO struct {
 	Status           string   `json:"status"`
 	Location         string   `json:"location"`
 	Description      string   `json:"description,omitempty"`
+	RequiresApproval bool     `json:"requiresApproval"`
 	IsAvailable      bool     `json:"isAvailable"`
 	NextAvailableAt  *int64   `json:"nextAvailableAt,omitempty"`
 	CurrentBookingID *string  `json:"currentBookingId,omitempty"`
@@ -43,6 +94,7 @@ type RoomSearchFilters struct {
 	MaxCapacity int      `json:"maxCapacity,omitempty"`
 	Floor       *int     `json:"floor,omitempty"`
 	Amenities   []string `json:"amenities,omitempty"`
+	Status      string   `json:"status,omitempty"`
 	StartTime   string   `json:"startTime,omitempty"`
 	EndTime     string   `json:"endTime,omitempty"`
 	Available   *bool    `json:"available,omitempty"`
@@ -55,13 +107,14 @@ type AvailabilityCheckRequest struct {
 }
 
 type AvailabilityCheckResponse struct {
-	Available        bool                    `json:"available"`
-	RoomID           string                  `json:"roomId"`
-	RoomName         string                  `json:"roomName"`
-	RequestedStart   int64                   `json:"requestedStart"`
-	RequestedEnd     int64                   `json:"requestedEnd"`
-	ConflictingSlots []ConflictingBookingDTO `json:"conflictingSlots,omitempty"`
-	SuggestedSlots   []TimeSlotDTO           `json:"suggestedSlots,omitempty"`
+	Available         bool                    `json:"available"`
+	RoomID            string                  `json:"roomId"`
+	RoomName          string                  `json:"roomName"`
+	RequestedStart    int64                   `json:"requestedStart"`
+	RequestedEnd      int64                   `json:"requestedEnd"`
+	ConflictingSlots  []ConflictingBookingDTO `json:"conflictingSlots,omitempty"`
+	ConflictingBlocks []RoomBlockDTO          `json:"conflictingBlocks,omitempty"`
+	SuggestedSlots    []TimeSlotDTO           `json:"suggestedSlots,omitempty"`
 }
 
 type TimeSlotDTO struct {
@@ -70,6 +123,14 @@ type TimeSlotDTO struct {
 	Duration  int   `json:"duration"`
 }
 
+type AvailableSlotsResponse struct {
+	RoomID   string        `json:"roomId"`
+	RoomName string        `json:"roomName"`
+	Date     string        `json:"date"`
+	Duration int           `json:"duration"`
+	Slots    []TimeSlotDTO `json:"slots"`
+}
+
 type RoomDynamoDBItem struct {
 	PK          string   `dynamodbav:"PK"`
 	SK          string   `dynamodbav:"SK"`
@@ -84,6 +145,85 @@ type RoomDynamoDBItem struct {
 	Status      string   `dynamodbav:"Status"`
 	Location    string   `dynamodbav:"Location"`
 	Description string   `dynamodbav:"Description,omitempty"`
+	DeletedAt   int64    `dynamodbav:"DeletedAt,omitempty"`
 	CreatedAt   int64    `dynamodbav:"CreatedAt"`
 	UpdatedAt   int64    `dynamodbav:"UpdatedAt"`
+
+	RequiresApproval bool                    `dynamodbav:"RequiresApproval,omitempty"`
+	Approvers        []string                `dynamodbav:"Approvers,omitempty"`
+	BookingPolicy    *RoomPolicyDynamoDBItem `dynamodbav:"BookingPolicy,omitempty"`
+}
+
+type RoomPolicyDynamoDBItem struct {
+	MinDurationSeconds int64 `dynamodbav:"MinDurationSeconds,omitempty"`
+	MaxDurationSeconds int64 `dynamodbav:"MaxDurationSeconds,omitempty"`
+	MaxAdvanceSeconds  int64 `dynamodbav:"MaxAdvanceSeconds,omitempty"`
+	AlignmentSeconds   int64 `dynamodbav:"AlignmentSeconds,omitempty"`
+}
+
+type CreateRoomBlockRequest struct {
+	StartTime string `json:"start_time" validate:"required"`
+	EndTime   string `json:"end_time" validate:"required"`
+	Reason    string `json:"reason"`
+}
+
+type RoomBlockDTO struct {
+	ID        string `json:"id"`
+	RoomID    string `json:"room_id"`
+	StartTime int64  `json:"start_time"`
+	EndTime   int64  `json:"end_time"`
+	Reason    string `json:"reason"`
+	CreatedBy string `json:"created_by"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// CreateRoomBlockResponse lists the bookings that overlap the new block; they
+// are not cancelled automatically.
+type CreateRoomBlockResponse struct {
+	Block             RoomBlockDTO         `json:"block"`
+	CollidingBookings []DetailedBookingDTO `json:"colliding_bookings"`
+}
+
+type RoomBlockDynamoDBItem struct {
+	PK        string `dynamodbav:"PK"`
+	SK        string `dynamodbav:"SK"`
+	ID        string `dynamodbav:"ID"`
+	RoomID    string `dynamodbav:"RoomID"`
+	StartTime int64  `dynamodbav:"StartTime"`
+	EndTime   int64  `dynamodbav:"EndTime"`
+	Reason    string `dynamodbav:"Reason"`
+	CreatedBy string `dynamodbav:"CreatedBy"`
+	CreatedAt int64  `dynamodbav:"CreatedAt"`
+}
+
+// KioskTokenHeader carries a room kiosk's secret on check-in requests.
+const KioskTokenHeader = "X-Kiosk-Token"
+
+type CreateKioskTokenRequest struct {
+	Name string `json:"name"`
+}
+
+type KioskTokenDTO struct {
+	ID        string `json:"id"`
+	RoomID    string `json:"room_id"`
+	Name      string `json:"name,omitempty"`
+	CreatedBy string `json:"created_by"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// CreateKioskTokenResponse carries the secret, which is shown only once.
+type CreateKioskTokenResponse struct {
+	KioskTokenDTO
+	Token string `json:"token"`
+}
+
+type KioskTokenDynamoDBItem struct {
+	PK        string `dynamodbav:"PK"`
+	SK        string `dynamodbav:"SK"`
+	ID        string `dynamodbav:"ID"`
+	RoomID    string `dynamodbav:"RoomID"`
+	Name      string `dynamodbav:"Name,omitempty"`
+	TokenHash string `dynamodbav:"TokenHash"`
+	CreatedBy string `dynamodbav:"CreatedBy"`
+	CreatedAt int64  `dynamodbav:"CreatedAt"`
 }
@@ -13,17 +13,80 @@ type LoginUserRequest struct {
 }
 
 type UserDTO struct {
-	ID        string `json:"id"`
-	Name      string `json:"name"`
-	Email     string `json:"email"`
-	Role      string `json:"role"`
-	CreatedAt int64  `json:"created_at,omitempty"`
-	UpdatedAt int64  `json:"updated_at,omitempty"`
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Email      string `json:"email"`
+	Role       string `json:"role"`
+	Status     string `json:"status,omitempty"`
+	DisabledAt int64  `json:"disabled_at,omitempty"`
+	CreatedAt  int64  `json:"created_at,omitempty"`
+	UpdatedAt  int64  `json:"updated_at,omitempty"`
+}
+
+// UpdateUserRequest is used by admins; omitted fields keep their value.
+type UpdateUserRequest struct {
+	Name  *string `json:"name"`
+	Email *string `json:"email"`
+	Role  *string `json:"role"`
+}
+
+// UpdateProfileRequest is used by users editing their own profile, which
+// cannot change their role.
+type UpdateProfileRequest struct {
+	Name  *string `json:"name"`
+	Email *string `json:"email"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,min=6"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required,min=6"`
+}
+
+type DeactivateUserRequest struct {
+	DelegateID string `json:"delegate_id"`
+	Reason     string `json:"reason"`
+}
+
+type DeactivateUserResponse struct {
+	Message            string       `json:"message"`
+	CancelledBookings  []BookingDTO `json:"cancelled_bookings"`
+	ReassignedBookings []BookingDTO `json:"reassigned_bookings"`
 }
 
 type LoginUserResponse struct {
-	Token string  `json:"token"`
-	User  UserDTO `json:"user,omitempty"`
+	Token        string  `json:"token"`
+	RefreshToken string  `json:"refresh_token"`
+	ExpiresIn    int64   `json:"expires_in"`
+	User         UserDTO `json:"user,omitempty"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type RefreshTokenResponse struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+type OIDCAuthorizeResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+	State            string `json:"state"`
+}
+
+type OIDCCallbackRequest struct {
+	Code  string `json:"code" validate:"required"`
+	State string `json:"state" validate:"required"`
 }
 
 type GenericResponse struct {
@@ -33,3 +96,55 @@ type GenericResponse struct {
 type ErrorResponse struct {
 	Error string `json:"error"`
 }
+
+type PasswordResetTokenDynamoDBItem struct {
+	PK        string `dynamodbav:"PK"`
+	SK        string `dynamodbav:"SK"`
+	ID        string `dynamodbav:"ID"`
+	UserID    string `dynamodbav:"UserID"`
+	TokenHash string `dynamodbav:"TokenHash"`
+	ExpiresAt int64  `dynamodbav:"ExpiresAt"`
+	UsedAt    int64  `dynamodbav:"UsedAt"`
+	CreatedAt int64  `dynamodbav:"CreatedAt"`
+}
+
+type RefreshTokenDynamoDBItem struct {
+	PK          string `dynamodbav:"PK"`
+	SK          string `dynamodbav:"SK"`
+	ID          string `dynamodbav:"ID"`
+	UserID      string `dynamodbav:"UserID"`
+	SessionID   string `dynamodbav:"SessionID"`
+	TokenHash   string `dynamodbav:"TokenHash"`
+	ExpiresAt   int64  `dynamodbav:"ExpiresAt"`
+	UsedAt      int64  `dynamodbav:"UsedAt"`
+	CreatedAt   int64  `dynamodbav:"CreatedAt"`
+	ExpireAfter int64  `dynamodbav:"ExpireAfter"`
+}
+
+type RevokedTokenDynamoDBItem struct {
+	PK          string `dynamodbav:"PK"`
+	SK          string `dynamodbav:"SK"`
+	ID          string `dynamodbav:"ID"`
+	ExpiresAt   int64  `dynamodbav:"ExpiresAt"`
+	ExpireAfter int64  `dynamodbav:"ExpireAfter"`
+}
+
+type OIDCLoginStateDynamoDBItem struct {
+	PK           string `dynamodbav:"PK"`
+	SK           string `dynamodbav:"SK"`
+	State        string `dynamodbav:"State"`
+	Nonce        string `dynamodbav:"Nonce"`
+	CodeVerifier string `dynamodbav:"CodeVerifier"`
+	ExpiresAt    int64  `dynamodbav:"ExpiresAt"`
+	CreatedAt    int64  `dynamodbav:"CreatedAt"`
+	ExpireAfter  int64  `dynamodbav:"ExpireAfter"`
+}
+
+type UserIdentityDynamoDBItem struct {
+	PK        string `dynamodbav:"PK"`
+	SK        string `dynamodbav:"SK"`
+	Issuer    string `dynamodbav:"Issuer"`
+	Subject   string `dynamodbav:"Subject"`
+	UserID    string `dynamodbav:"UserID"`
+	CreatedAt int64  `dynamodbav:"CreatedAt"`
+}
@@ -3,23 +3,34 @@ package main
 import (
 	"context"
 	"log"
-	"os"
 	"strings"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
 
 	"github.com/amangirdhar210/meeting-room/internal/adapters/auth"
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/service"
+	"github.com/amangirdhar210/meeting-room/internal/lambda/shared"
 )
 
 var jwtGenerator *auth.JWTGenerator
+var authService service.AuthService
 
 func init() {
-	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		jwtSecret = "your-secret-key"
+	dynamoClient, tableName, err := shared.InitDynamoDB()
+	if err != nil {
+		panic(err)
+	}
+
+	jwtGenerator, err = shared.JWTGenerator()
+	if err != nil {
+		panic(err)
+	}
+	authService, err = shared.AuthService(dynamoClient, tableName)
+	if err != nil {
+		panic(err)
 	}
-	jwtGenerator = auth.NewJWTGenerator(jwtSecret, 0)
 }
 
 func handler(ctx context.Context, request events.APIGatewayV2CustomAuthorizerV2Request) (events.APIGatewayV2CustomAuthorizerSimpleResponse, error) {
@@ -58,13 +69,30 @@ func handler(ctx context.Context, request events.APIGatewayV2CustomAuthorizerV2R
 		}, nil
 	}
 
+	revoked, err := authService.IsRevoked(domain.AccessTokenClaims{
+		UserID:    claims.UserID,
+		TokenID:   claims.ID,
+		SessionID: claims.SessionID,
+		ExpiresAt: claims.ExpiresAt.Unix(),
+	})
+	if err != nil || revoked {
+		log.Printf("Unauthorized: Token of user %s is revoked or could not be checked: %v\n", claims.UserID, err)
+		return events.APIGatewayV2CustomAuthorizerSimpleResponse{
+			IsAuthorized: false,
+		}, nil
+	}
+
+	if !shared.CanAccessRoute(claims.Role, request.RouteKey) {
+		log.Printf("Unauthorized: Role %s of user %s may not call %s\n", claims.Role, claims.UserID, request.RouteKey)
+		return events.APIGatewayV2CustomAuthorizerSimpleResponse{
+			IsAuthorized: false,
+		}, nil
+	}
+
 	log.Printf("Authorized: User %s with role %s\n", claims.UserID, claims.Role)
 	return events.APIGatewayV2CustomAuthorizerSimpleResponse{
 		IsAuthorized: true,
-		Context: map[string]any{
-			"userId": claims.UserID,
-			"role":   claims.Role,
-		},
+		Context:      shared.AuthorizerContext(claims),
 	}, nil
 }
 
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/service"
+	"github.com/amangirdhar210/meeting-room/internal/lambda/shared"
+)
+
+var approvalService service.ApprovalService
+
+func init() {
+	dynamoClient, tableName, err := shared.InitDynamoDB()
+	if err != nil {
+		panic(err)
+	}
+
+	approvalService = shared.ApprovalService(dynamoClient, tableName)
+}
+
+func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	return shared.ReviewBooking(request, approvalService.Approve)
+}
+
+func main() {
+	lambda.Start(handler)
+}
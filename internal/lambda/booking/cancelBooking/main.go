@@ -2,16 +2,24 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"strings"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
 
 	dynamodbRepo "github.com/amangirdhar210/meeting-room/internal/adapters/repositories/dynamoDB"
+	"github.com/amangirdhar210/meeting-room/internal/config"
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
 	"github.com/amangirdhar210/meeting-room/internal/core/service"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
 	"github.com/amangirdhar210/meeting-room/internal/lambda/shared"
 )
 
-var bookingService service.BookingService
+var (
+	bookingService service.BookingService
+	seriesService  service.BookingSeriesService
+)
 
 func init() {
 	dynamoClient, tableName, err := shared.InitDynamoDB()
@@ -21,8 +29,11 @@ func init() {
 
 	bookingRepo := dynamodbRepo.NewBookingRepositoryDynamoDB(dynamoClient, tableName)
 	roomRepo := dynamodbRepo.NewRoomRepositoryDynamoDB(dynamoClient, tableName)
+	roomBlockRepo := dynamodbRepo.NewRoomBlockRepositoryDynamoDB(dynamoClient, tableName)
 	userRepo := dynamodbRepo.NewUserRepositoryDynamoDB(dynamoClient, tableName)
-	bookingService = service.NewBookingService(bookingRepo, roomRepo, userRepo)
+	seriesRepo := dynamodbRepo.NewBookingSeriesRepositoryDynamoDB(dynamoClient, tableName)
+	bookingService = service.NewBookingService(bookingRepo, roomRepo, userRepo, roomBlockRepo, dynamodbRepo.NewWaitlistRepositoryDynamoDB(dynamoClient, tableName), shared.Notifier(), config.LoadWaitlistConfig().ClaimWindow, config.LoadApprovalConfig().Timeout, config.LoadBookingPolicy())
+	seriesService = service.NewBookingSeriesService(seriesRepo, bookingRepo, roomRepo, userRepo, roomBlockRepo, bookingService, config.LoadApprovalConfig().Timeout, config.LoadBookingPolicy())
 }
 
 func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
@@ -31,7 +42,64 @@ func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 		return shared.Response(400, map[string]string{"error": "Booking ID is required"})
 	}
 
-	if err := bookingService.CancelBooking(bookingID); err != nil {
+	var userID, role string
+	if authContext, ok := request.RequestContext.Authorizer["lambda"].(map[string]any); ok {
+		if uid, exists := authContext["userId"].(string); exists {
+			userID = uid
+		}
+		if r, exists := authContext["role"].(string); exists {
+			role = r
+		}
+	}
+
+	if userID == "" {
+		return shared.Response(401, map[string]string{"error": "Unauthorized"})
+	}
+
+	var req dto.CancelBookingRequest
+	if strings.TrimSpace(request.Body) != "" {
+		if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+			return shared.Response(400, map[string]string{"error": "Invalid request body"})
+		}
+	}
+
+	if !domain.HasPermission(role, domain.PermBookingsManageAny) {
+		booking, err := bookingService.GetBookingByID(bookingID)
+		if err != nil {
+			if err == domain.ErrNotFound {
+				return shared.Response(404, map[string]string{"error": "Booking not found"})
+			}
+			return shared.Response(500, map[string]string{"error": "Internal server error"})
+		}
+		if booking.UserID != userID {
+			return shared.Response(403, map[string]string{"error": "You can only cancel your own bookings"})
+		}
+	}
+
+	if scope := request.QueryStringParameters["scope"]; scope != "" {
+		if !domain.IsValidSeriesScope(scope) {
+			return shared.Response(400, map[string]string{"error": "Invalid scope, use this, following or all"})
+		}
+		cancelled, err := seriesService.CancelOccurrences(bookingID, scope, userID, req.Reason)
+		if err != nil {
+			switch err {
+			case domain.ErrNotFound:
+				return shared.Response(404, map[string]string{"error": "Booking not found"})
+			case domain.ErrBookingNotActive:
+				return shared.Response(409, map[string]string{"error": err.Error()})
+			}
+			return shared.Response(400, map[string]string{"error": err.Error()})
+		}
+		return shared.Response(200, shared.SeriesResponse(&domain.SeriesResult{Bookings: cancelled}).Bookings)
+	}
+
+	if err := bookingService.CancelBooking(bookingID, userID, req.Reason); err != nil {
+		switch err {
+		case domain.ErrNotFound:
+			return shared.Response(404, map[string]string{"error": "Booking not found"})
+		case domain.ErrBookingNotActive, domain.ErrGroupMember:
+			return shared.Response(409, map[string]string{"error": err.Error()})
+		}
 		return shared.Response(400, map[string]string{"error": err.Error()})
 	}
 
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/service"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+	"github.com/amangirdhar210/meeting-room/internal/lambda/shared"
+)
+
+var checkInService service.CheckInService
+var authService service.AuthService
+
+func init() {
+	dynamoClient, tableName, err := shared.InitDynamoDB()
+	if err != nil {
+		panic(err)
+	}
+
+	checkInService = shared.CheckInService(dynamoClient, tableName)
+	authService, err = shared.AuthService(dynamoClient, tableName)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// handler sits behind a route without the authorizer because room kiosks
+// check in with their kiosk token instead of a JWT; everyone else is
+// authenticated here.
+func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	bookingID := request.PathParameters["id"]
+	if bookingID == "" {
+		return shared.Response(400, dto.ErrorResponse{Error: "Booking ID is required"})
+	}
+
+	kioskToken := request.Headers["x-kiosk-token"]
+	if kioskToken == "" {
+		kioskToken = request.Headers[dto.KioskTokenHeader]
+	}
+
+	var booking *domain.Booking
+	var err error
+	if kioskToken != "" {
+		booking, err = checkInService.KioskCheckIn(bookingID, kioskToken)
+	} else {
+		claims, authErr := shared.BearerClaims(request, authService)
+		if authErr == domain.ErrUnauthorized {
+			return shared.Response(401, dto.ErrorResponse{Error: "Unauthorized"})
+		}
+		if authErr != nil {
+			log.Printf("Failed to authenticate check-in: %v", authErr)
+			return shared.Response(500, dto.ErrorResponse{Error: "Internal server error"})
+		}
+		booking, err = checkInService.CheckIn(bookingID, claims.UserID)
+	}
+	if err != nil {
+		log.Printf("Error checking in booking %s: %v", bookingID, err)
+		return shared.CheckInError(err)
+	}
+
+	return shared.Response(200, shared.BookingResponses([]domain.Booking{*booking})[0])
+}
+
+func main() {
+	lambda.Start(handler)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/aws/aws-lambda-go/lambda"
 
 	dynamodbRepo "github.com/amangirdhar210/meeting-room/internal/adapters/repositories/dynamoDB"
+	"github.com/amangirdhar210/meeting-room/internal/config"
 	"github.com/amangirdhar210/meeting-room/internal/core/domain"
 	"github.com/amangirdhar210/meeting-room/internal/core/service"
 	"github.com/amangirdhar210/meeting-room/internal/http/dto"
@@ -26,8 +27,9 @@ func init() {
 
 	bookingRepo := dynamodbRepo.NewBookingRepositoryDynamoDB(dynamoClient, tableName)
 	roomRepo := dynamodbRepo.NewRoomRepositoryDynamoDB(dynamoClient, tableName)
+	roomBlockRepo := dynamodbRepo.NewRoomBlockRepositoryDynamoDB(dynamoClient, tableName)
 	userRepo := dynamodbRepo.NewUserRepositoryDynamoDB(dynamoClient, tableName)
-	bookingService = service.NewBookingService(bookingRepo, roomRepo, userRepo)
+	bookingService = service.NewBookingService(bookingRepo, roomRepo, userRepo, roomBlockRepo, dynamodbRepo.NewWaitlistRepositoryDynamoDB(dynamoClient, tableName), shared.Notifier(), config.LoadWaitlistConfig().ClaimWindow, config.LoadApprovalConfig().Timeout, config.LoadBookingPolicy())
 }
 
 func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
@@ -43,11 +45,14 @@ func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 
 	log.Printf("Parsed request: RoomID=%s, StartTime=%s, EndTime=%s, Purpose=%s", req.RoomID, req.StartTime, req.EndTime, req.Purpose)
 
-	var userID string
+	var userID, role string
 	if authContext, ok := request.RequestContext.Authorizer["lambda"].(map[string]any); ok {
 		if uid, exists := authContext["userId"].(string); exists {
 			userID = uid
 		}
+		if r, exists := authContext["role"].(string); exists {
+			role = r
+		}
 	}
 
 	if userID == "" {
@@ -86,24 +91,37 @@ func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 
 	log.Printf("Creating booking: %+v", booking)
 
-	if err := bookingService.CreateBooking(booking); err != nil {
+	if err := bookingService.CreateBooking(booking, role); err != nil {
 		log.Printf("Error creating booking: %v", err)
+		if violations, ok := shared.PolicyViolations(err); ok {
+			return shared.Response(422, violations)
+		}
 		if err == domain.ErrRoomUnavailable {
 			return shared.Response(409, dto.ErrorResponse{Error: "Room is already booked for this time"})
 		}
+		if err == domain.ErrRoomNotBookable || err == domain.ErrRoomBlocked {
+			return shared.Response(409, dto.ErrorResponse{Error: err.Error()})
+		}
+		if err == domain.ErrUserDisabled {
+			return shared.Response(403, dto.ErrorResponse{Error: err.Error()})
+		}
+		if err == domain.ErrTimeRangeInvalid || err == domain.ErrBookingTooLong {
+			return shared.Response(400, dto.ErrorResponse{Error: err.Error()})
+		}
 		return shared.Response(500, dto.ErrorResponse{Error: err.Error()})
 	}
 
 	log.Printf("Booking created successfully with ID: %s", booking.ID)
 
 	return shared.Response(201, dto.BookingDTO{
-		ID:        booking.ID,
-		UserID:    booking.UserID,
-		RoomID:    booking.RoomID,
-		StartTime: booking.StartTime,
-		EndTime:   booking.EndTime,
-		Purpose:   booking.Purpose,
-		Status:    booking.Status,
+		ID:                booking.ID,
+		UserID:            booking.UserID,
+		RoomID:            booking.RoomID,
+		StartTime:         booking.StartTime,
+		EndTime:           booking.EndTime,
+		Purpose:           booking.Purpose,
+		Status:            booking.Status,
+		ApprovalExpiresAt: booking.ApprovalExpiresAt,
 	})
 }
 
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+
+	dynamodbRepo "github.com/amangirdhar210/meeting-room/internal/adapters/repositories/dynamoDB"
+	"github.com/amangirdhar210/meeting-room/internal/config"
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/service"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+	"github.com/amangirdhar210/meeting-room/internal/lambda/shared"
+)
+
+var groupService service.BookingGroupService
+
+func init() {
+	dynamoClient, tableName, err := shared.InitDynamoDB()
+	if err != nil {
+		panic(err)
+	}
+
+	bookingRepo := dynamodbRepo.NewBookingRepositoryDynamoDB(dynamoClient, tableName)
+	roomRepo := dynamodbRepo.NewRoomRepositoryDynamoDB(dynamoClient, tableName)
+	roomBlockRepo := dynamodbRepo.NewRoomBlockRepositoryDynamoDB(dynamoClient, tableName)
+	userRepo := dynamodbRepo.NewUserRepositoryDynamoDB(dynamoClient, tableName)
+	bookingService := service.NewBookingService(bookingRepo, roomRepo, userRepo, roomBlockRepo, dynamodbRepo.NewWaitlistRepositoryDynamoDB(dynamoClient, tableName), shared.Notifier(), config.LoadWaitlistConfig().ClaimWindow, config.LoadApprovalConfig().Timeout, config.LoadBookingPolicy())
+	groupService = service.NewBookingGroupService(bookingRepo, roomRepo, userRepo, roomBlockRepo, bookingService, config.LoadApprovalConfig().Timeout, config.LoadBookingPolicy())
+}
+
+func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var userID string
+	if authContext, ok := request.RequestContext.Authorizer["lambda"].(map[string]any); ok {
+		if uid, exists := authContext["userId"].(string); exists {
+			userID = uid
+		}
+	}
+
+	if userID == "" {
+		return shared.Response(401, dto.ErrorResponse{Error: "Unauthorized"})
+	}
+
+	var req dto.CreateBookingGroupRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return shared.Response(400, dto.ErrorResponse{Error: "Invalid request body"})
+	}
+
+	startTime, err := time.Parse(time.RFC3339, req.StartTime)
+	if err != nil {
+		return shared.Response(400, dto.ErrorResponse{Error: "Invalid start_time format"})
+	}
+	endTime, err := time.Parse(time.RFC3339, req.EndTime)
+	if err != nil {
+		return shared.Response(400, dto.ErrorResponse{Error: "Invalid end_time format"})
+	}
+
+	group := &domain.BookingGroup{
+		UserID:    userID,
+		RoomIDs:   req.RoomIDs,
+		StartTime: startTime.Unix(),
+		EndTime:   endTime.Unix(),
+		Purpose:   req.Purpose,
+	}
+
+	result, err := groupService.CreateGroup(group)
+	if err != nil {
+		log.Printf("Error creating booking group: %v", err)
+		if violations, ok := shared.PolicyViolations(err); ok {
+			return shared.Response(422, violations)
+		}
+		switch err {
+		case domain.ErrRoomUnavailable:
+			if result != nil {
+				return shared.Response(409, shared.GroupResponse(result))
+			}
+			return shared.Response(409, dto.ErrorResponse{Error: "Room is already booked for this time"})
+		case domain.ErrUserDisabled:
+			return shared.Response(403, dto.ErrorResponse{Error: err.Error()})
+		case domain.ErrNotFound:
+			return shared.Response(404, dto.ErrorResponse{Error: "User or room not found"})
+		case domain.ErrInvalidInput, domain.ErrTimeRangeInvalid, domain.ErrBookingTooLong, domain.ErrInvalidGroup:
+			return shared.Response(400, dto.ErrorResponse{Error: err.Error()})
+		}
+		return shared.Response(500, dto.ErrorResponse{Error: "Internal server error"})
+	}
+
+	return shared.Response(201, shared.GroupResponse(result))
+}
+
+func main() {
+	lambda.Start(handler)
+}
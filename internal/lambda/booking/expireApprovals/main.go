@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/service"
+	"github.com/amangirdhar210/meeting-room/internal/lambda/shared"
+)
+
+var approvalService service.ApprovalService
+
+func init() {
+	dynamoClient, tableName, err := shared.InitDynamoDB()
+	if err != nil {
+		panic(err)
+	}
+
+	approvalService = shared.ApprovalService(dynamoClient, tableName)
+}
+
+// handler runs on a schedule and expires pending bookings their approvers
+// did not answer in time.
+func handler(ctx context.Context, event events.CloudWatchEvent) error {
+	expired, err := approvalService.ExpireOverdue()
+	if err != nil {
+		log.Printf("Failed to expire pending approvals: %v", err)
+		return err
+	}
+	log.Printf("Expired %d unanswered booking requests", expired)
+	return nil
+}
+
+func main() {
+	lambda.Start(handler)
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/service"
+	"github.com/amangirdhar210/meeting-room/internal/lambda/shared"
+)
+
+var waitlistService service.WaitlistService
+
+func init() {
+	dynamoClient, tableName, err := shared.InitDynamoDB()
+	if err != nil {
+		panic(err)
+	}
+
+	waitlistService = shared.WaitlistService(dynamoClient, tableName)
+}
+
+// handler runs on a schedule and passes waitlist offers nobody claimed in
+// time on to the next entry.
+func handler(ctx context.Context, event events.CloudWatchEvent) error {
+	expired, err := waitlistService.ExpireOffers()
+	if err != nil {
+		log.Printf("Failed to expire waitlist offers: %v", err)
+		return err
+	}
+	log.Printf("Expired %d unclaimed waitlist offers", expired)
+	return nil
+}
+
+func main() {
+	lambda.Start(handler)
+}
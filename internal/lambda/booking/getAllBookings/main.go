@@ -5,6 +5,8 @@ import (
 	"log"
 
 	dynamodbRepo "github.com/amangirdhar210/meeting-room/internal/adapters/repositories/dynamoDB"
+	"github.com/amangirdhar210/meeting-room/internal/config"
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
 	"github.com/amangirdhar210/meeting-room/internal/core/service"
 	"github.com/amangirdhar210/meeting-room/internal/lambda/shared"
 	"github.com/aws/aws-lambda-go/events"
@@ -21,8 +23,9 @@ func init() {
 
 	bookingRepo := dynamodbRepo.NewBookingRepositoryDynamoDB(dynamoClient, tableName)
 	roomRepo := dynamodbRepo.NewRoomRepositoryDynamoDB(dynamoClient, tableName)
+	roomBlockRepo := dynamodbRepo.NewRoomBlockRepositoryDynamoDB(dynamoClient, tableName)
 	userRepo := dynamodbRepo.NewUserRepositoryDynamoDB(dynamoClient, tableName)
-	bookingService = service.NewBookingService(bookingRepo, roomRepo, userRepo)
+	bookingService = service.NewBookingService(bookingRepo, roomRepo, userRepo, roomBlockRepo, dynamodbRepo.NewWaitlistRepositoryDynamoDB(dynamoClient, tableName), shared.Notifier(), config.LoadWaitlistConfig().ClaimWindow, config.LoadApprovalConfig().Timeout, config.LoadBookingPolicy())
 }
 
 func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
@@ -41,8 +44,22 @@ func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 
 	log.Printf("User: %s, Role: %s", userID, role)
 
-	if role == "admin" {
-		log.Println("Admin role detected, fetching all bookings")
+	status := request.QueryStringParameters["status"]
+	if status != "" && !domain.IsValidBookingStatus(status) {
+		return shared.Response(400, map[string]string{"error": "Invalid status, use confirmed, pending, cancelled, completed, no_show, rejected or expired"})
+	}
+
+	if domain.HasPermission(role, domain.PermBookingsViewAny) && status != "" {
+		statusBookings, getErr := bookingService.GetBookingsByStatus(status)
+		if getErr != nil {
+			log.Printf("Error getting bookings by status: %v", getErr)
+			return shared.Response(500, map[string]string{"error": "Internal server error"})
+		}
+		return shared.Response(200, statusBookings)
+	}
+
+	if domain.HasPermission(role, domain.PermBookingsViewAny) {
+		log.Printf("Role %s may view all bookings, fetching them", role)
 		allBookings, getErr := bookingService.GetAllBookings()
 		if getErr != nil {
 			log.Printf("Error getting all bookings: %v", getErr)
@@ -53,6 +70,15 @@ func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 	}
 
 	log.Println("User role detected, fetching user bookings")
+	if status != "" {
+		statusBookings, getErr := bookingService.GetBookingsByUserIDAndStatus(userID, status)
+		if getErr != nil {
+			log.Printf("Error getting user bookings by status: %v", getErr)
+			return shared.Response(500, map[string]string{"error": "Internal server error"})
+		}
+		return shared.Response(200, statusBookings)
+	}
+
 	userBookings, getErr := bookingService.GetBookingsByUserID(userID)
 	if getErr != nil {
 		log.Printf("Error getting user bookings: %v", getErr)
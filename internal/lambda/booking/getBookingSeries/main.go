@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+
+	dynamodbRepo "github.com/amangirdhar210/meeting-room/internal/adapters/repositories/dynamoDB"
+	"github.com/amangirdhar210/meeting-room/internal/config"
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/service"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+	"github.com/amangirdhar210/meeting-room/internal/lambda/shared"
+)
+
+var seriesService service.BookingSeriesService
+
+func init() {
+	dynamoClient, tableName, err := shared.InitDynamoDB()
+	if err != nil {
+		panic(err)
+	}
+
+	seriesRepo := dynamodbRepo.NewBookingSeriesRepositoryDynamoDB(dynamoClient, tableName)
+	bookingRepo := dynamodbRepo.NewBookingRepositoryDynamoDB(dynamoClient, tableName)
+	roomRepo := dynamodbRepo.NewRoomRepositoryDynamoDB(dynamoClient, tableName)
+	roomBlockRepo := dynamodbRepo.NewRoomBlockRepositoryDynamoDB(dynamoClient, tableName)
+	userRepo := dynamodbRepo.NewUserRepositoryDynamoDB(dynamoClient, tableName)
+	seriesService = service.NewBookingSeriesService(seriesRepo, bookingRepo, roomRepo, userRepo, roomBlockRepo, shared.BookingService(dynamoClient, tableName), config.LoadApprovalConfig().Timeout, config.LoadBookingPolicy())
+}
+
+func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	seriesID := request.PathParameters["id"]
+	if seriesID == "" {
+		return shared.Response(400, dto.ErrorResponse{Error: "Series ID is required"})
+	}
+
+	var userID, role string
+	if authContext, ok := request.RequestContext.Authorizer["lambda"].(map[string]any); ok {
+		if uid, exists := authContext["userId"].(string); exists {
+			userID = uid
+		}
+		if r, exists := authContext["role"].(string); exists {
+			role = r
+		}
+	}
+
+	if userID == "" {
+		return shared.Response(401, dto.ErrorResponse{Error: "Unauthorized"})
+	}
+
+	series, occurrences, err := seriesService.GetSeries(seriesID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return shared.Response(404, dto.ErrorResponse{Error: "Series not found"})
+		}
+		return shared.Response(500, dto.ErrorResponse{Error: "Internal server error"})
+	}
+	if !domain.HasPermission(role, domain.PermBookingsViewAny) && series.UserID != userID {
+		return shared.Response(403, dto.ErrorResponse{Error: "You can only view your own series"})
+	}
+
+	return shared.Response(200, shared.SeriesResponse(&domain.SeriesResult{Series: series, Bookings: occurrences}))
+}
+
+func main() {
+	lambda.Start(handler)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 
 	dynamodbRepo "github.com/amangirdhar210/meeting-room/internal/adapters/repositories/dynamoDB"
+	"github.com/amangirdhar210/meeting-room/internal/config"
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
 	"github.com/amangirdhar210/meeting-room/internal/core/service"
 	"github.com/amangirdhar210/meeting-room/internal/lambda/shared"
 	"github.com/aws/aws-lambda-go/events"
@@ -20,8 +22,9 @@ func init() {
 
 	bookingRepo := dynamodbRepo.NewBookingRepositoryDynamoDB(dynamoClient, tableName)
 	roomRepo := dynamodbRepo.NewRoomRepositoryDynamoDB(dynamoClient, tableName)
+	roomBlockRepo := dynamodbRepo.NewRoomBlockRepositoryDynamoDB(dynamoClient, tableName)
 	userRepo := dynamodbRepo.NewUserRepositoryDynamoDB(dynamoClient, tableName)
-	bookingService = service.NewBookingService(bookingRepo, roomRepo, userRepo)
+	bookingService = service.NewBookingService(bookingRepo, roomRepo, userRepo, roomBlockRepo, dynamodbRepo.NewWaitlistRepositoryDynamoDB(dynamoClient, tableName), shared.Notifier(), config.LoadWaitlistConfig().ClaimWindow, config.LoadApprovalConfig().Timeout, config.LoadBookingPolicy())
 }
 
 func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
@@ -36,7 +39,18 @@ func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 		return shared.Response(401, map[string]string{"error": "Unauthorized"})
 	}
 
-	bookings, err := bookingService.GetBookingsByUserID(userID)
+	status := request.QueryStringParameters["status"]
+	if status != "" && !domain.IsValidBookingStatus(status) {
+		return shared.Response(400, map[string]string{"error": "Invalid status, use confirmed, pending, cancelled, completed, no_show, rejected or expired"})
+	}
+
+	var bookings []domain.Booking
+	var err error
+	if status != "" {
+		bookings, err = bookingService.GetBookingsByUserIDAndStatus(userID, status)
+	} else {
+		bookings, err = bookingService.GetBookingsByUserID(userID)
+	}
 	if err != nil {
 		return shared.Response(500, map[string]string{"error": "Internal server error"})
 	}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/service"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+	"github.com/amangirdhar210/meeting-room/internal/lambda/shared"
+)
+
+var approvalService service.ApprovalService
+
+func init() {
+	dynamoClient, tableName, err := shared.InitDynamoDB()
+	if err != nil {
+		panic(err)
+	}
+
+	approvalService = shared.ApprovalService(dynamoClient, tableName)
+}
+
+func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var userID string
+	if authContext, ok := request.RequestContext.Authorizer["lambda"].(map[string]any); ok {
+		if uid, exists := authContext["userId"].(string); exists {
+			userID = uid
+		}
+	}
+
+	if userID == "" {
+		return shared.Response(401, dto.ErrorResponse{Error: "Unauthorized"})
+	}
+
+	pending, err := approvalService.GetPending(userID)
+	if err != nil {
+		return shared.ApprovalError(err)
+	}
+
+	resp := make([]dto.PendingApprovalDTO, 0, len(pending))
+	for _, booking := range pending {
+		resp = append(resp, dto.PendingApprovalDTO{
+			DetailedBookingDTO: dto.DetailedBookingDTO{
+				ID:         booking.ID,
+				UserID:     booking.UserID,
+				UserName:   booking.UserName,
+				UserEmail:  booking.UserEmail,
+				RoomID:     booking.RoomID,
+				RoomName:   booking.RoomName,
+				RoomNumber: booking.RoomNumber,
+				StartTime:  booking.StartTime,
+				EndTime:    booking.EndTime,
+				Duration:   int((booking.EndTime - booking.StartTime) / 60),
+				Purpose:    booking.Purpose,
+				Status:     booking.Status,
+			},
+			ApprovalExpiresAt: booking.ApprovalExpiresAt,
+		})
+	}
+	return shared.Response(200, resp)
+}
+
+func main() {
+	lambda.Start(handler)
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+
+	dynamodbRepo "github.com/amangirdhar210/meeting-room/internal/adapters/repositories/dynamoDB"
+	"github.com/amangirdhar210/meeting-room/internal/config"
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/service"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+	"github.com/amangirdhar210/meeting-room/internal/lambda/shared"
+)
+
+var importService service.BookingImportService
+
+func init() {
+	dynamoClient, tableName, err := shared.InitDynamoDB()
+	if err != nil {
+		panic(err)
+	}
+
+	bookingRepo := dynamodbRepo.NewBookingRepositoryDynamoDB(dynamoClient, tableName)
+	roomRepo := dynamodbRepo.NewRoomRepositoryDynamoDB(dynamoClient, tableName)
+	roomBlockRepo := dynamodbRepo.NewRoomBlockRepositoryDynamoDB(dynamoClient, tableName)
+	userRepo := dynamodbRepo.NewUserRepositoryDynamoDB(dynamoClient, tableName)
+	bookingService := service.NewBookingService(bookingRepo, roomRepo, userRepo, roomBlockRepo, dynamodbRepo.NewWaitlistRepositoryDynamoDB(dynamoClient, tableName), shared.Notifier(), config.LoadWaitlistConfig().ClaimWindow, config.LoadApprovalConfig().Timeout, config.LoadBookingPolicy())
+	importService = service.NewBookingImportService(bookingService, bookingRepo, roomRepo, userRepo, roomBlockRepo)
+}
+
+// calendarData returns the uploaded .ics content, either the raw body or the
+// "file" part of a multipart form.
+func calendarData(request events.APIGatewayProxyRequest) (io.Reader, error) {
+	body := []byte(request.Body)
+	if request.IsBase64Encoded {
+		decoded, err := base64.StdEncoding.DecodeString(request.Body)
+		if err != nil {
+			return nil, err
+		}
+		body = decoded
+	}
+
+	contentType := request.Headers["content-type"]
+	if contentType == "" {
+		contentType = request.Headers["Content-Type"]
+	}
+	mediaType, params, _ := mime.ParseMediaType(contentType)
+	if mediaType != "multipart/form-data" {
+		return bytes.NewReader(body), nil
+	}
+
+	reader := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			return nil, err
+		}
+		if part.FormName() == "file" {
+			return part, nil
+		}
+	}
+}
+
+func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var role string
+	if authContext, ok := request.RequestContext.Authorizer["lambda"].(map[string]any); ok {
+		role, _ = authContext["role"].(string)
+	}
+
+	dryRun := false
+	if value := request.QueryStringParameters["dry_run"]; value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return shared.Response(400, dto.ErrorResponse{Error: "Invalid dry_run value"})
+		}
+		dryRun = parsed
+	}
+
+	if strings.TrimSpace(request.Body) == "" {
+		return shared.Response(400, dto.ErrorResponse{Error: "Request body must contain an .ics file"})
+	}
+	data, err := calendarData(request)
+	if err != nil {
+		return shared.Response(400, dto.ErrorResponse{Error: "Missing .ics file in form field \"file\""})
+	}
+
+	report, err := importService.ImportICS(data, dryRun, role)
+	if err != nil {
+		log.Printf("Error importing bookings: %v", err)
+		if err == domain.ErrInvalidInput {
+			return shared.Response(400, dto.ErrorResponse{Error: "Invalid iCalendar file"})
+		}
+		return shared.Response(500, dto.ErrorResponse{Error: "Internal server error"})
+	}
+
+	resp := dto.ImportReportDTO{
+		DryRun:  report.DryRun,
+		Summary: report.Counts,
+		Results: make([]dto.ImportResultDTO, 0, len(report.Results)),
+	}
+	for _, result := range report.Results {
+		resp.Results = append(resp.Results, dto.ImportResultDTO{
+			UID:       result.UID,
+			Summary:   result.Summary,
+			StartTime: result.StartTime,
+			EndTime:   result.EndTime,
+			RoomID:    result.RoomID,
+			UserID:    result.UserID,
+			BookingID: result.BookingID,
+			Status:    result.Status,
+			Message:   result.Message,
+		})
+	}
+
+	return shared.Response(200, resp)
+}
+
+func main() {
+	lambda.Start(handler)
+}
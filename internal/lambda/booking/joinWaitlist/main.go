@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/service"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+	"github.com/amangirdhar210/meeting-room/internal/lambda/shared"
+)
+
+var waitlistService service.WaitlistService
+
+func init() {
+	dynamoClient, tableName, err := shared.InitDynamoDB()
+	if err != nil {
+		panic(err)
+	}
+
+	waitlistService = shared.WaitlistService(dynamoClient, tableName)
+}
+
+func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var userID string
+	if authContext, ok := request.RequestContext.Authorizer["lambda"].(map[string]any); ok {
+		if uid, exists := authContext["userId"].(string); exists {
+			userID = uid
+		}
+	}
+
+	if userID == "" {
+		return shared.Response(401, dto.ErrorResponse{Error: "Unauthorized"})
+	}
+
+	var req dto.JoinWaitlistRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return shared.Response(400, dto.ErrorResponse{Error: "Invalid request body"})
+	}
+
+	startTime, err := time.Parse(time.RFC3339, req.StartTime)
+	if err != nil {
+		return shared.Response(400, dto.ErrorResponse{Error: "Invalid start_time format"})
+	}
+	endTime, err := time.Parse(time.RFC3339, req.EndTime)
+	if err != nil {
+		return shared.Response(400, dto.ErrorResponse{Error: "Invalid end_time format"})
+	}
+
+	entry := &domain.WaitlistEntry{
+		UserID:    userID,
+		RoomID:    req.RoomID,
+		StartTime: startTime.Unix(),
+		EndTime:   endTime.Unix(),
+		Purpose:   req.Purpose,
+		AutoBook:  req.AutoBook,
+	}
+	if err := waitlistService.Join(entry); err != nil {
+		return shared.WaitlistError(err)
+	}
+
+	return shared.Response(201, shared.WaitlistEntryResponse(*entry))
+}
+
+func main() {
+	lambda.Start(handler)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/service"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+	"github.com/amangirdhar210/meeting-room/internal/lambda/shared"
+)
+
+var waitlistService service.WaitlistService
+
+func init() {
+	dynamoClient, tableName, err := shared.InitDynamoDB()
+	if err != nil {
+		panic(err)
+	}
+
+	waitlistService = shared.WaitlistService(dynamoClient, tableName)
+}
+
+func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var userID string
+	if authContext, ok := request.RequestContext.Authorizer["lambda"].(map[string]any); ok {
+		if uid, exists := authContext["userId"].(string); exists {
+			userID = uid
+		}
+	}
+
+	if userID == "" {
+		return shared.Response(401, dto.ErrorResponse{Error: "Unauthorized"})
+	}
+
+	entryID := request.PathParameters["id"]
+	if entryID == "" {
+		return shared.Response(400, dto.ErrorResponse{Error: "Waitlist entry ID is required"})
+	}
+
+	if err := waitlistService.Leave(entryID, userID); err != nil {
+		return shared.WaitlistError(err)
+	}
+
+	return shared.Response(200, dto.GenericResponse{Message: "Left the waitlist"})
+}
+
+func main() {
+	lambda.Start(handler)
+}
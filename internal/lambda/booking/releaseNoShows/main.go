@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/service"
+	"github.com/amangirdhar210/meeting-room/internal/lambda/shared"
+)
+
+var checkInService service.CheckInService
+
+func init() {
+	dynamoClient, tableName, err := shared.InitDynamoDB()
+	if err != nil {
+		panic(err)
+	}
+
+	checkInService = shared.CheckInService(dynamoClient, tableName)
+}
+
+// handler runs on a schedule, frees the rooms of bookings nobody checked in
+// to and marks the bookings that are over as completed.
+func handler(ctx context.Context, event events.CloudWatchEvent) error {
+	released, err := checkInService.ReleaseNoShows()
+	if err != nil {
+		log.Printf("Failed to release no-show bookings: %v", err)
+		return err
+	}
+	log.Printf("Released %d no-show bookings", released)
+
+	completed, err := checkInService.CompleteEnded()
+	if err != nil {
+		log.Printf("Failed to complete ended bookings: %v", err)
+		return err
+	}
+	log.Printf("Completed %d ended bookings", completed)
+	return nil
+}
+
+func main() {
+	lambda.Start(handler)
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+
+	dynamodbRepo "github.com/amangirdhar210/meeting-room/internal/adapters/repositories/dynamoDB"
+	"github.com/amangirdhar210/meeting-room/internal/config"
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/service"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+	"github.com/amangirdhar210/meeting-room/internal/lambda/shared"
+)
+
+var groupService service.BookingGroupService
+
+func init() {
+	dynamoClient, tableName, err := shared.InitDynamoDB()
+	if err != nil {
+		panic(err)
+	}
+
+	bookingRepo := dynamodbRepo.NewBookingRepositoryDynamoDB(dynamoClient, tableName)
+	roomRepo := dynamodbRepo.NewRoomRepositoryDynamoDB(dynamoClient, tableName)
+	roomBlockRepo := dynamodbRepo.NewRoomBlockRepositoryDynamoDB(dynamoClient, tableName)
+	userRepo := dynamodbRepo.NewUserRepositoryDynamoDB(dynamoClient, tableName)
+	bookingService := service.NewBookingService(bookingRepo, roomRepo, userRepo, roomBlockRepo, dynamodbRepo.NewWaitlistRepositoryDynamoDB(dynamoClient, tableName), shared.Notifier(), config.LoadWaitlistConfig().ClaimWindow, config.LoadApprovalConfig().Timeout, config.LoadBookingPolicy())
+	groupService = service.NewBookingGroupService(bookingRepo, roomRepo, userRepo, roomBlockRepo, bookingService, config.LoadApprovalConfig().Timeout, config.LoadBookingPolicy())
+}
+
+func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	groupID := request.PathParameters["id"]
+	if groupID == "" {
+		return shared.Response(400, dto.ErrorResponse{Error: "Group ID is required"})
+	}
+
+	var userID, role string
+	if authContext, ok := request.RequestContext.Authorizer["lambda"].(map[string]any); ok {
+		if uid, exists := authContext["userId"].(string); exists {
+			userID = uid
+		}
+		if r, exists := authContext["role"].(string); exists {
+			role = r
+		}
+	}
+
+	if userID == "" {
+		return shared.Response(401, dto.ErrorResponse{Error: "Unauthorized"})
+	}
+
+	var req dto.UpdateBookingGroupRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return shared.Response(400, dto.ErrorResponse{Error: "Invalid request body"})
+	}
+
+	update := domain.BookingUpdate{Purpose: req.Purpose}
+	if req.StartTime != nil {
+		startTime, err := time.Parse(time.RFC3339, *req.StartTime)
+		if err != nil {
+			return shared.Response(400, dto.ErrorResponse{Error: "Invalid start_time format"})
+		}
+		start := startTime.Unix()
+		update.StartTime = &start
+	}
+	if req.EndTime != nil {
+		endTime, err := time.Parse(time.RFC3339, *req.EndTime)
+		if err != nil {
+			return shared.Response(400, dto.ErrorResponse{Error: "Invalid end_time format"})
+		}
+		end := endTime.Unix()
+		update.EndTime = &end
+	}
+
+	bookings, err := groupService.GetGroup(groupID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return shared.Response(404, dto.ErrorResponse{Error: "Group booking not found"})
+		}
+		return shared.Response(500, dto.ErrorResponse{Error: "Internal server error"})
+	}
+	if !domain.HasPermission(role, domain.PermBookingsManageAny) && bookings[0].UserID != userID {
+		return shared.Response(403, dto.ErrorResponse{Error: "You can only modify your own group bookings"})
+	}
+
+	result, err := groupService.UpdateGroup(groupID, update, role)
+	if err != nil {
+		log.Printf("Error updating booking group %s: %v", groupID, err)
+		if violations, ok := shared.PolicyViolations(err); ok {
+			return shared.Response(422, violations)
+		}
+		switch err {
+		case domain.ErrRoomUnavailable:
+			if result != nil {
+				return shared.Response(409, shared.GroupResponse(result))
+			}
+			return shared.Response(409, dto.ErrorResponse{Error: "Room is already booked for this time"})
+		case domain.ErrBookingNotActive:
+			return shared.Response(409, dto.ErrorResponse{Error: err.Error()})
+		case domain.ErrNotFound:
+			return shared.Response(404, dto.ErrorResponse{Error: "Group booking not found"})
+		case domain.ErrInvalidInput, domain.ErrTimeRangeInvalid, domain.ErrBookingTooLong:
+			return shared.Response(400, dto.ErrorResponse{Error: err.Error()})
+		}
+		return shared.Response(500, dto.ErrorResponse{Error: "Internal server error"})
+	}
+
+	return shared.Response(200, shared.GroupResponse(result))
+}
+
+func main() {
+	lambda.Start(handler)
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+
+	dynamodbRepo "github.com/amangirdhar210/meeting-room/internal/adapters/repositories/dynamoDB"
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/service"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+	"github.com/amangirdhar210/meeting-room/internal/lambda/shared"
+)
+
+var calendarService service.CalendarService
+
+func init() {
+	dynamoClient, tableName, err := shared.InitDynamoDB()
+	if err != nil {
+		panic(err)
+	}
+
+	tokenRepo := dynamodbRepo.NewCalendarTokenRepositoryDynamoDB(dynamoClient, tableName)
+	bookingRepo := dynamodbRepo.NewBookingRepositoryDynamoDB(dynamoClient, tableName)
+	roomRepo := dynamodbRepo.NewRoomRepositoryDynamoDB(dynamoClient, tableName)
+	userRepo := dynamodbRepo.NewUserRepositoryDynamoDB(dynamoClient, tableName)
+	calendarService = service.NewCalendarService(tokenRepo, bookingRepo, roomRepo, userRepo)
+}
+
+func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var userID string
+	if authContext, ok := request.RequestContext.Authorizer["lambda"].(map[string]any); ok {
+		if uid, exists := authContext["userId"].(string); exists {
+			userID = uid
+		}
+	}
+
+	if userID == "" {
+		return shared.Response(401, dto.ErrorResponse{Error: "Unauthorized"})
+	}
+
+	secret, token, err := calendarService.CreateToken(userID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return shared.Response(404, dto.ErrorResponse{Error: "User not found"})
+		}
+		return shared.Response(500, dto.ErrorResponse{Error: "Internal server error"})
+	}
+
+	return shared.Response(201, dto.CreateCalendarTokenResponse{
+		ID:        token.ID,
+		Token:     secret,
+		UserFeed:  fmt.Sprintf("/api/users/%s/calendar.ics?token=%s", url.PathEscape(userID), url.QueryEscape(secret)),
+		CreatedAt: token.CreatedAt,
+	})
+}
+
+func main() {
+	lambda.Start(handler)
+}
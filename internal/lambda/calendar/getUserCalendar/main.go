@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+
+	dynamodbRepo "github.com/amangirdhar210/meeting-room/internal/adapters/repositories/dynamoDB"
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/service"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+	"github.com/amangirdhar210/meeting-room/internal/lambda/shared"
+)
+
+var calendarService service.CalendarService
+
+func init() {
+	dynamoClient, tableName, err := shared.InitDynamoDB()
+	if err != nil {
+		panic(err)
+	}
+
+	tokenRepo := dynamodbRepo.NewCalendarTokenRepositoryDynamoDB(dynamoClient, tableName)
+	bookingRepo := dynamodbRepo.NewBookingRepositoryDynamoDB(dynamoClient, tableName)
+	roomRepo := dynamodbRepo.NewRoomRepositoryDynamoDB(dynamoClient, tableName)
+	userRepo := dynamodbRepo.NewUserRepositoryDynamoDB(dynamoClient, tableName)
+	calendarService = service.NewCalendarService(tokenRepo, bookingRepo, roomRepo, userRepo)
+}
+
+func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID := request.PathParameters["id"]
+	if userID == "" {
+		return shared.Response(400, dto.ErrorResponse{Error: "User ID is required"})
+	}
+
+	feed, err := calendarService.GetUserFeed(userID, request.QueryStringParameters["token"])
+	if err != nil {
+		switch err {
+		case domain.ErrUnauthorized:
+			return shared.Response(401, dto.ErrorResponse{Error: "Invalid or revoked calendar token"})
+		case domain.ErrNotFound:
+			return shared.Response(404, dto.ErrorResponse{Error: "User not found"})
+		}
+		return shared.Response(500, dto.ErrorResponse{Error: "Internal server error"})
+	}
+
+	return shared.CalendarResponse(feed)
+}
+
+func main() {
+	lambda.Start(handler)
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+
+	dynamodbRepo "github.com/amangirdhar210/meeting-room/internal/adapters/repositories/dynamoDB"
+	"github.com/amangirdhar210/meeting-room/internal/core/service"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+	"github.com/amangirdhar210/meeting-room/internal/lambda/shared"
+)
+
+var calendarService service.CalendarService
+
+func init() {
+	dynamoClient, tableName, err := shared.InitDynamoDB()
+	if err != nil {
+		panic(err)
+	}
+
+	tokenRepo := dynamodbRepo.NewCalendarTokenRepositoryDynamoDB(dynamoClient, tableName)
+	bookingRepo := dynamodbRepo.NewBookingRepositoryDynamoDB(dynamoClient, tableName)
+	roomRepo := dynamodbRepo.NewRoomRepositoryDynamoDB(dynamoClient, tableName)
+	userRepo := dynamodbRepo.NewUserRepositoryDynamoDB(dynamoClient, tableName)
+	calendarService = service.NewCalendarService(tokenRepo, bookingRepo, roomRepo, userRepo)
+}
+
+func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var userID string
+	if authContext, ok := request.RequestContext.Authorizer["lambda"].(map[string]any); ok {
+		if uid, exists := authContext["userId"].(string); exists {
+			userID = uid
+		}
+	}
+
+	if userID == "" {
+		return shared.Response(401, dto.ErrorResponse{Error: "Unauthorized"})
+	}
+
+	tokens, err := calendarService.ListTokens(userID)
+	if err != nil {
+		return shared.Response(500, dto.ErrorResponse{Error: "Internal server error"})
+	}
+
+	resp := make([]dto.CalendarTokenDTO, 0, len(tokens))
+	for _, token := range tokens {
+		resp = append(resp, dto.CalendarTokenDTO{ID: token.ID, CreatedAt: token.CreatedAt})
+	}
+	return shared.Response(200, resp)
+}
+
+func main() {
+	lambda.Start(handler)
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"context"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+
+	"github.com/amangirdhar210/meeting-room/internal/adapters/auth"
+	"github.com/amangirdhar210/meeting-room/internal/lambda/shared"
+)
+
+var jwtGenerator *auth.JWTGenerator
+
+func init() {
+	var err error
+	jwtGenerator, err = shared.JWTGenerator()
+	if err != nil {
+		panic(err)
+	}
+}
+
+func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	resp, err := shared.Response(200, jwtGenerator.JWKS())
+	resp.Headers["Cache-Control"] = "public, max-age=300"
+	return resp, err
+}
+
+func main() {
+	lambda.Start(handler)
+}
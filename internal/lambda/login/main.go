@@ -4,22 +4,17 @@ import (
 	"context"
 	"encoding/json"
 	"log"
-	"time"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
 
-	"github.com/amangirdhar210/meeting-room/internal/adapters/auth"
-	dynamoRepo "github.com/amangirdhar210/meeting-room/internal/adapters/repositories/dynamoDB"
-	"github.com/amangirdhar210/meeting-room/internal/core/ports"
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
 	"github.com/amangirdhar210/meeting-room/internal/core/service"
 	"github.com/amangirdhar210/meeting-room/internal/http/dto"
 	"github.com/amangirdhar210/meeting-room/internal/lambda/shared"
 )
 
 var authService service.AuthService
-var jwtGen ports.TokenGenerator
-var hasher ports.PasswordHasher
 
 func init() {
 	dynamoClient, tableName, err := shared.InitDynamoDB()
@@ -27,11 +22,10 @@ func init() {
 		panic(err)
 	}
 
-	userRepo := dynamoRepo.NewUserRepositoryDynamoDB(dynamoClient, tableName)
-
-	jwtGen = auth.NewJWTGenerator(shared.GetJWTSecret(), 24*time.Hour)
-	hasher = auth.NewBcryptHasher()
-	authService = service.NewAuthService(userRepo, jwtGen, hasher)
+	authService, err = shared.AuthService(dynamoClient, tableName)
+	if err != nil {
+		panic(err)
+	}
 }
 
 func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
@@ -42,13 +36,18 @@ func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 
 	log.Printf("%+v", loginReq)
 
-	token, user, err := authService.Login(loginReq.Email, loginReq.Password)
+	tokens, user, err := authService.Login(loginReq.Email, loginReq.Password)
+	if err == domain.ErrUserDisabled {
+		return shared.Response(403, dto.ErrorResponse{Error: "User account is disabled"})
+	}
 	if err != nil {
 		return shared.Response(401, dto.ErrorResponse{Error: "Invalid credentials"})
 	}
 
 	return shared.Response(200, dto.LoginUserResponse{
-		Token: token,
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    tokens.ExpiresIn,
 		User: dto.UserDTO{
 			ID:    user.ID,
 			Name:  user.Name,
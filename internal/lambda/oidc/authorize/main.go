@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/service"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+	"github.com/amangirdhar210/meeting-room/internal/lambda/shared"
+)
+
+var oidcService service.OIDCService
+
+func init() {
+	dynamoClient, tableName, err := shared.InitDynamoDB()
+	if err != nil {
+		panic(err)
+	}
+
+	oidcService, err = shared.OIDCService(dynamoClient, tableName)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if oidcService == nil {
+		return shared.Response(404, dto.ErrorResponse{Error: "Single sign-on is not configured"})
+	}
+
+	authURL, state, err := oidcService.BeginLogin()
+	if err != nil {
+		log.Printf("Failed to start single sign-on: %v", err)
+		return shared.Response(502, dto.ErrorResponse{Error: "Identity provider is unavailable"})
+	}
+
+	return shared.Response(200, dto.OIDCAuthorizeResponse{
+		AuthorizationURL: authURL,
+		State:            state,
+	})
+}
+
+func main() {
+	lambda.Start(handler)
+}
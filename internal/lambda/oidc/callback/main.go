@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/service"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+	"github.com/amangirdhar210/meeting-room/internal/lambda/shared"
+)
+
+var oidcService service.OIDCService
+
+func init() {
+	dynamoClient, tableName, err := shared.InitDynamoDB()
+	if err != nil {
+		panic(err)
+	}
+
+	oidcService, err = shared.OIDCService(dynamoClient, tableName)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if oidcService == nil {
+		return shared.Response(404, dto.ErrorResponse{Error: "Single sign-on is not configured"})
+	}
+
+	var req dto.OIDCCallbackRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return shared.Response(400, dto.ErrorResponse{Error: "Invalid request body"})
+	}
+
+	tokens, user, err := oidcService.CompleteLogin(req.State, req.Code)
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidInput:
+			return shared.Response(400, dto.ErrorResponse{Error: "Code and state are required"})
+		case domain.ErrInvalidOIDCState:
+			return shared.Response(400, dto.ErrorResponse{Error: "Single sign-on request is invalid or expired"})
+		case domain.ErrOIDCLoginFailed:
+			return shared.Response(401, dto.ErrorResponse{Error: "Single sign-on login failed"})
+		case domain.ErrUserDisabled:
+			return shared.Response(403, dto.ErrorResponse{Error: "User account is disabled"})
+		}
+		log.Printf("Failed to complete single sign-on: %v", err)
+		return shared.Response(500, dto.ErrorResponse{Error: "Failed to complete single sign-on"})
+	}
+
+	return shared.Response(200, dto.LoginUserResponse{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    tokens.ExpiresIn,
+		User: dto.UserDTO{
+			ID:    user.ID,
+			Name:  user.Name,
+			Email: user.Email,
+			Role:  user.Role,
+		},
+	})
+}
+
+func main() {
+	lambda.Start(handler)
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+
+	"github.com/amangirdhar210/meeting-room/internal/adapters/auth"
+	dynamoRepo "github.com/amangirdhar210/meeting-room/internal/adapters/repositories/dynamoDB"
+	"github.com/amangirdhar210/meeting-room/internal/config"
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/service"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+	"github.com/amangirdhar210/meeting-room/internal/lambda/shared"
+)
+
+var resetService service.PasswordResetService
+
+func init() {
+	dynamoClient, tableName, err := shared.InitDynamoDB()
+	if err != nil {
+		panic(err)
+	}
+
+	userRepo := dynamoRepo.NewUserRepositoryDynamoDB(dynamoClient, tableName)
+	tokenRepo := dynamoRepo.NewPasswordResetTokenRepositoryDynamoDB(dynamoClient, tableName)
+
+	authService, err := shared.AuthService(dynamoClient, tableName)
+	if err != nil {
+		panic(err)
+	}
+
+	resetCfg := config.LoadPasswordResetConfig()
+	resetService = service.NewPasswordResetService(tokenRepo, userRepo, auth.NewBcryptHasher(), shared.Notifier(), authService, resetCfg.TokenTTL, resetCfg.ResetURL)
+}
+
+func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var req dto.ForgotPasswordRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return shared.Response(400, dto.ErrorResponse{Error: "Invalid request body"})
+	}
+
+	if err := resetService.RequestReset(req.Email); err != nil {
+		if err == domain.ErrInvalidInput {
+			return shared.Response(400, dto.ErrorResponse{Error: "Email is required"})
+		}
+		log.Printf("Failed to request password reset: %v", err)
+		return shared.Response(500, dto.ErrorResponse{Error: "Failed to request password reset"})
+	}
+
+	return shared.Response(202, dto.GenericResponse{Message: "If an account exists for that email, a reset link has been sent"})
+}
+
+func main() {
+	lambda.Start(handler)
+}
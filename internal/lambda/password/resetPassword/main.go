@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+
+	"github.com/amangirdhar210/meeting-room/internal/adapters/auth"
+	dynamoRepo "github.com/amangirdhar210/meeting-room/internal/adapters/repositories/dynamoDB"
+	"github.com/amangirdhar210/meeting-room/internal/config"
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/service"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+	"github.com/amangirdhar210/meeting-room/internal/lambda/shared"
+)
+
+var resetService service.PasswordResetService
+
+func init() {
+	dynamoClient, tableName, err := shared.InitDynamoDB()
+	if err != nil {
+		panic(err)
+	}
+
+	userRepo := dynamoRepo.NewUserRepositoryDynamoDB(dynamoClient, tableName)
+	tokenRepo := dynamoRepo.NewPasswordResetTokenRepositoryDynamoDB(dynamoClient, tableName)
+
+	authService, err := shared.AuthService(dynamoClient, tableName)
+	if err != nil {
+		panic(err)
+	}
+
+	resetCfg := config.LoadPasswordResetConfig()
+	resetService = service.NewPasswordResetService(tokenRepo, userRepo, auth.NewBcryptHasher(), shared.Notifier(), authService, resetCfg.TokenTTL, resetCfg.ResetURL)
+}
+
+func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var req dto.ResetPasswordRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return shared.Response(400, dto.ErrorResponse{Error: "Invalid request body"})
+	}
+
+	if err := resetService.ResetPassword(req.Token, req.NewPassword); err != nil {
+		switch err {
+		case domain.ErrInvalidInput:
+			return shared.Response(400, dto.ErrorResponse{Error: "Token and a new password of at least 6 characters are required"})
+		case domain.ErrInvalidResetToken:
+			return shared.Response(400, dto.ErrorResponse{Error: "Password reset token is invalid or expired"})
+		}
+		log.Printf("Failed to reset password: %v", err)
+		return shared.Response(500, dto.ErrorResponse{Error: "Failed to reset password"})
+	}
+
+	return shared.Response(200, dto.GenericResponse{Message: "Password has been reset"})
+}
+
+func main() {
+	lambda.Start(handler)
+}
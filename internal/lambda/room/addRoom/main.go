@@ -6,6 +6,7 @@ import (
 	"log"
 
 	dynamodbRepo "github.com/amangirdhar210/meeting-room/internal/adapters/repositories/dynamoDB"
+	"github.com/amangirdhar210/meeting-room/internal/config"
 	"github.com/amangirdhar210/meeting-room/internal/core/domain"
 	"github.com/amangirdhar210/meeting-room/internal/core/service"
 	"github.com/amangirdhar210/meeting-room/internal/http/dto"
@@ -23,8 +24,11 @@ func init() {
 	}
 
 	roomRepo := dynamodbRepo.NewRoomRepositoryDynamoDB(dynamoClient, tableName)
+	roomBlockRepo := dynamodbRepo.NewRoomBlockRepositoryDynamoDB(dynamoClient, tableName)
+	bookingRepo := dynamodbRepo.NewBookingRepositoryDynamoDB(dynamoClient, tableName)
+	userRepo := dynamodbRepo.NewUserRepositoryDynamoDB(dynamoClient, tableName)
 
-	roomService = service.NewRoomService(roomRepo)
+	roomService = service.NewRoomService(roomRepo, bookingRepo, roomBlockRepo, userRepo, shared.BookingService(dynamoClient, tableName), shared.Notifier(), config.LoadSchedulingRules())
 }
 
 func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
@@ -47,6 +51,12 @@ func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 		Status:      req.Status,
 		Location:    req.Location,
 		Description: req.Description,
+
+		RequiresApproval: req.RequiresApproval,
+		Approvers:        req.Approvers,
+	}
+	if req.BookingPolicy != nil {
+		room.BookingPolicy = domain.RoomPolicy(*req.BookingPolicy)
 	}
 
 	err = roomService.AddRoom(room)
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	dynamodbRepo "github.com/amangirdhar210/meeting-room/internal/adapters/repositories/dynamoDB"
+	"github.com/amangirdhar210/meeting-room/internal/config"
 	"github.com/amangirdhar210/meeting-room/internal/core/domain"
 	"github.com/amangirdhar210/meeting-room/internal/core/service"
 	"github.com/amangirdhar210/meeting-room/internal/http/dto"
@@ -24,7 +25,10 @@ func init() {
 	}
 
 	roomRepo := dynamodbRepo.NewRoomRepositoryDynamoDB(dynamoClient, tableName)
-	roomService = service.NewRoomService(roomRepo)
+	roomBlockRepo := dynamodbRepo.NewRoomBlockRepositoryDynamoDB(dynamoClient, tableName)
+	bookingRepo := dynamodbRepo.NewBookingRepositoryDynamoDB(dynamoClient, tableName)
+	userRepo := dynamodbRepo.NewUserRepositoryDynamoDB(dynamoClient, tableName)
+	roomService = service.NewRoomService(roomRepo, bookingRepo, roomBlockRepo, userRepo, shared.BookingService(dynamoClient, tableName), shared.Notifier(), config.LoadSchedulingRules())
 }
 
 func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
@@ -49,12 +53,15 @@ func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 		return shared.Response(400, dto.ErrorResponse{Error: "Invalid end_time format"})
 	}
 
-	isAvailable, conflictingBookings, err := roomService.CheckAvailability(req.RoomID, startTime.Unix(), endTime.Unix())
+	availability, err := roomService.CheckAvailability(req.RoomID, startTime.Unix(), endTime.Unix())
 	if err != nil {
 		log.Printf("Error checking availability: %v", err)
 		if err == domain.ErrNotFound {
 			return shared.Response(404, dto.ErrorResponse{Error: "Room not found"})
 		}
+		if err == domain.ErrInvalidInput || err == domain.ErrTimeRangeInvalid {
+			return shared.Response(400, dto.ErrorResponse{Error: err.Error()})
+		}
 		return shared.Response(500, dto.ErrorResponse{Error: "Internal server error"})
 	}
 
@@ -65,7 +72,7 @@ func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 	}
 
 	var conflictingSlots []dto.ConflictingBookingDTO
-	for _, conflictBooking := range conflictingBookings {
+	for _, conflictBooking := range availability.ConflictingBookings {
 		conflictingSlots = append(conflictingSlots, dto.ConflictingBookingDTO{
 			BookingID: conflictBooking.ID,
 			StartTime: conflictBooking.StartTime,
@@ -74,17 +81,32 @@ func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 		})
 	}
 
+	suggestedSlots := []dto.TimeSlotDTO{}
+	for _, slot := range availability.SuggestedSlots {
+		suggestedSlots = append(suggestedSlots, dto.TimeSlotDTO{
+			StartTime: slot.StartTime,
+			EndTime:   slot.EndTime,
+			Duration:  slot.Duration,
+		})
+	}
+
+	var conflictingBlocks []dto.RoomBlockDTO
+	for _, block := range availability.ConflictingBlocks {
+		conflictingBlocks = append(conflictingBlocks, shared.RoomBlockResponse(block))
+	}
+
 	response := dto.AvailabilityCheckResponse{
-		Available:        isAvailable,
-		RoomID:           req.RoomID,
-		RoomName:         room.Name,
-		RequestedStart:   startTime.Unix(),
-		RequestedEnd:     endTime.Unix(),
-		ConflictingSlots: conflictingSlots,
-		SuggestedSlots:   []dto.TimeSlotDTO{},
+		Available:         availability.Available,
+		RoomID:            req.RoomID,
+		RoomName:          room.Name,
+		RequestedStart:    startTime.Unix(),
+		RequestedEnd:      endTime.Unix(),
+		ConflictingSlots:  conflictingSlots,
+		ConflictingBlocks: conflictingBlocks,
+		SuggestedSlots:    suggestedSlots,
 	}
 
-	log.Printf("Availability check completed - Available: %v", isAvailable)
+	log.Printf("Availability check completed - Available: %v", availability.Available)
 	return shared.Response(200, response)
 }
 
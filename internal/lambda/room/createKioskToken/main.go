@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/service"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+	"github.com/amangirdhar210/meeting-room/internal/lambda/shared"
+)
+
+var checkInService service.CheckInService
+
+func init() {
+	dynamoClient, tableName, err := shared.InitDynamoDB()
+	if err != nil {
+		panic(err)
+	}
+
+	checkInService = shared.CheckInService(dynamoClient, tableName)
+}
+
+func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	claims, ok := shared.AccessTokenClaims(request)
+	if !ok {
+		return shared.Response(401, dto.ErrorResponse{Error: "Unauthorized"})
+	}
+
+	roomID := request.PathParameters["id"]
+	if roomID == "" {
+		return shared.Response(400, dto.ErrorResponse{Error: "Room ID is required"})
+	}
+
+	var req dto.CreateKioskTokenRequest
+	if request.Body != "" {
+		if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+			return shared.Response(400, dto.ErrorResponse{Error: "Invalid request body"})
+		}
+	}
+
+	secret, token, err := checkInService.CreateKioskToken(roomID, req.Name, claims.UserID)
+	if err != nil {
+		log.Printf("Error creating kiosk token for room %s: %v", roomID, err)
+		return shared.CheckInError(err)
+	}
+
+	return shared.Response(201, dto.CreateKioskTokenResponse{
+		KioskTokenDTO: shared.KioskTokenResponse(*token),
+		Token:         secret,
+	})
+}
+
+func main() {
+	lambda.Start(handler)
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	dynamodbRepo "github.com/amangirdhar210/meeting-room/internal/adapters/repositories/dynamoDB"
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/service"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+	"github.com/amangirdhar210/meeting-room/internal/lambda/shared"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+var blockService service.RoomBlockService
+
+func init() {
+	dynamoClient, tableName, err := shared.InitDynamoDB()
+	if err != nil {
+		panic(err)
+	}
+
+	roomBlockRepo := dynamodbRepo.NewRoomBlockRepositoryDynamoDB(dynamoClient, tableName)
+	roomRepo := dynamodbRepo.NewRoomRepositoryDynamoDB(dynamoClient, tableName)
+	bookingRepo := dynamodbRepo.NewBookingRepositoryDynamoDB(dynamoClient, tableName)
+	userRepo := dynamodbRepo.NewUserRepositoryDynamoDB(dynamoClient, tableName)
+
+	blockService = service.NewRoomBlockService(roomBlockRepo, roomRepo, bookingRepo, userRepo)
+}
+
+func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	log.Println("CreateRoomBlock handler invoked")
+
+	roomID := request.PathParameters["id"]
+	if roomID == "" {
+		return shared.Response(400, dto.ErrorResponse{Error: "Room ID is required"})
+	}
+
+	var userID string
+	if authContext, ok := request.RequestContext.Authorizer["lambda"].(map[string]any); ok {
+		if uid, exists := authContext["userId"].(string); exists {
+			userID = uid
+		}
+	}
+	if userID == "" {
+		return shared.Response(401, dto.ErrorResponse{Error: "Unauthorized"})
+	}
+
+	var req dto.CreateRoomBlockRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		log.Printf("Error unmarshalling request body: %v", err)
+		return shared.Response(400, dto.ErrorResponse{Error: "Invalid request body"})
+	}
+	startTime, err := time.Parse(time.RFC3339, req.StartTime)
+	if err != nil {
+		return shared.Response(400, dto.ErrorResponse{Error: "Invalid start_time format"})
+	}
+	endTime, err := time.Parse(time.RFC3339, req.EndTime)
+	if err != nil {
+		return shared.Response(400, dto.ErrorResponse{Error: "Invalid end_time format"})
+	}
+
+	block := &domain.RoomBlock{
+		RoomID:    roomID,
+		StartTime: startTime.Unix(),
+		EndTime:   endTime.Unix(),
+		Reason:    req.Reason,
+		CreatedBy: userID,
+	}
+	colliding, err := blockService.CreateBlock(block)
+	if err != nil {
+		log.Printf("Error creating block for room %s: %v", roomID, err)
+		switch err {
+		case domain.ErrNotFound:
+			return shared.Response(404, dto.ErrorResponse{Error: "Room not found"})
+		case domain.ErrInvalidInput, domain.ErrTimeRangeInvalid:
+			return shared.Response(400, dto.ErrorResponse{Error: err.Error()})
+		}
+		return shared.Response(500, dto.ErrorResponse{Error: "Internal server error"})
+	}
+
+	response := dto.CreateRoomBlockResponse{
+		Block:             shared.RoomBlockResponse(*block),
+		CollidingBookings: []dto.DetailedBookingDTO{},
+	}
+	for _, booking := range colliding {
+		response.CollidingBookings = append(response.CollidingBookings, dto.DetailedBookingDTO{
+			ID:         booking.ID,
+			UserID:     booking.UserID,
+			UserName:   booking.UserName,
+			UserEmail:  booking.UserEmail,
+			RoomID:     booking.RoomID,
+			RoomName:   booking.RoomName,
+			RoomNumber: booking.RoomNumber,
+			StartTime:  booking.StartTime,
+			EndTime:    booking.EndTime,
+			Duration:   int((booking.EndTime - booking.StartTime) / 60),
+			Purpose:    booking.Purpose,
+			Status:     booking.Status,
+		})
+	}
+
+	log.Printf("Room block %s created with %d colliding bookings", block.ID, len(colliding))
+	return shared.Response(201, response)
+}
+
+func main() {
+	lambda.Start(handler)
+}
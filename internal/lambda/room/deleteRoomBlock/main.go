@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	dynamodbRepo "github.com/amangirdhar210/meeting-room/internal/adapters/repositories/dynamoDB"
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/service"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+	"github.com/amangirdhar210/meeting-room/internal/lambda/shared"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+var blockService service.RoomBlockService
+
+func init() {
+	dynamoClient, tableName, err := shared.InitDynamoDB()
+	if err != nil {
+		panic(err)
+	}
+
+	roomBlockRepo := dynamodbRepo.NewRoomBlockRepositoryDynamoDB(dynamoClient, tableName)
+	roomRepo := dynamodbRepo.NewRoomRepositoryDynamoDB(dynamoClient, tableName)
+	bookingRepo := dynamodbRepo.NewBookingRepositoryDynamoDB(dynamoClient, tableName)
+	userRepo := dynamodbRepo.NewUserRepositoryDynamoDB(dynamoClient, tableName)
+
+	blockService = service.NewRoomBlockService(roomBlockRepo, roomRepo, bookingRepo, userRepo)
+}
+
+func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	log.Println("DeleteRoomBlock handler invoked")
+
+	roomID := request.PathParameters["id"]
+	blockID := request.PathParameters["blockId"]
+	if roomID == "" || blockID == "" {
+		return shared.Response(400, dto.ErrorResponse{Error: "Room ID and block ID are required"})
+	}
+
+	if err := blockService.DeleteBlock(roomID, blockID); err != nil {
+		log.Printf("Error deleting block %s of room %s: %v", blockID, roomID, err)
+		if err == domain.ErrNotFound {
+			return shared.Response(404, dto.ErrorResponse{Error: "Room block not found"})
+		}
+		return shared.Response(500, dto.ErrorResponse{Error: "Internal server error"})
+	}
+
+	return shared.Response(200, dto.GenericResponse{Message: "Room block deleted successfully"})
+}
+
+func main() {
+	lambda.Start(handler)
+}
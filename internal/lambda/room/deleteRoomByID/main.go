@@ -2,9 +2,12 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"log"
+	"strconv"
 
 	dynamodbRepo "github.com/amangirdhar210/meeting-room/internal/adapters/repositories/dynamoDB"
+	"github.com/amangirdhar210/meeting-room/internal/config"
 	"github.com/amangirdhar210/meeting-room/internal/core/domain"
 	"github.com/amangirdhar210/meeting-room/internal/core/service"
 	"github.com/amangirdhar210/meeting-room/internal/http/dto"
@@ -22,8 +25,11 @@ func init() {
 	}
 
 	roomRepo := dynamodbRepo.NewRoomRepositoryDynamoDB(dynamoClient, tableName)
+	roomBlockRepo := dynamodbRepo.NewRoomBlockRepositoryDynamoDB(dynamoClient, tableName)
+	bookingRepo := dynamodbRepo.NewBookingRepositoryDynamoDB(dynamoClient, tableName)
+	userRepo := dynamodbRepo.NewUserRepositoryDynamoDB(dynamoClient, tableName)
 
-	roomService = service.NewRoomService(roomRepo)
+	roomService = service.NewRoomService(roomRepo, bookingRepo, roomBlockRepo, userRepo, shared.BookingService(dynamoClient, tableName), shared.Notifier(), config.LoadSchedulingRules())
 }
 
 func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
@@ -35,9 +41,42 @@ func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 		return shared.Response(400, dto.ErrorResponse{Error: "Room ID is required"})
 	}
 
-	err := roomService.DeleteRoomByID(roomID)
+	var userID string
+	if authContext, ok := request.RequestContext.Authorizer["lambda"].(map[string]any); ok {
+		if uid, exists := authContext["userId"].(string); exists {
+			userID = uid
+		}
+	}
+
+	force := false
+	if forceStr := request.QueryStringParameters["force"]; forceStr != "" {
+		val, err := strconv.ParseBool(forceStr)
+		if err != nil {
+			return shared.Response(400, dto.ErrorResponse{Error: "force must be true or false"})
+		}
+		force = val
+	}
+
+	var req dto.DeleteRoomRequest
+	if request.Body != "" {
+		if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+			return shared.Response(400, dto.ErrorResponse{Error: "Invalid request body"})
+		}
+	}
+
+	bookings, err := roomService.DeleteRoomByID(roomID, domain.RoomDeletion{
+		Force:     force,
+		DeletedBy: userID,
+		Reason:    req.Reason,
+	})
 	if err != nil {
 		log.Printf("Error deleting room with ID %s: %v", roomID, err)
+		if err == domain.ErrRoomHasBookings {
+			return shared.Response(409, dto.RoomHasBookingsResponse{
+				Error:            "Room has upcoming bookings, retry with force=true to cancel them",
+				UpcomingBookings: shared.BookingResponses(bookings),
+			})
+		}
 		if err == domain.ErrNotFound {
 			return shared.Response(404, dto.ErrorResponse{Error: "Room not found"})
 		}
@@ -47,8 +86,11 @@ func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 		return shared.Response(500, dto.ErrorResponse{Error: "Internal server error"})
 	}
 
-	log.Printf("Room deleted successfully with ID: %s", roomID)
-	return shared.Response(200, dto.GenericResponse{Message: "Room deleted successfully"})
+	log.Printf("Room deleted successfully with ID: %s, cancelled %d bookings", roomID, len(bookings))
+	return shared.Response(200, dto.DeleteRoomResponse{
+		Message:           "Room deleted successfully",
+		CancelledBookings: shared.BookingResponses(bookings),
+	})
 }
 
 func main() {
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	dynamodbRepo "github.com/amangirdhar210/meeting-room/internal/adapters/repositories/dynamoDB"
+	"github.com/amangirdhar210/meeting-room/internal/config"
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/service"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+	"github.com/amangirdhar210/meeting-room/internal/lambda/shared"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+const defaultSlotDuration = 30
+
+var roomService service.RoomService
+
+func init() {
+	dynamoClient, tableName, err := shared.InitDynamoDB()
+	if err != nil {
+		panic(err)
+	}
+
+	roomRepo := dynamodbRepo.NewRoomRepositoryDynamoDB(dynamoClient, tableName)
+	roomBlockRepo := dynamodbRepo.NewRoomBlockRepositoryDynamoDB(dynamoClient, tableName)
+	bookingRepo := dynamodbRepo.NewBookingRepositoryDynamoDB(dynamoClient, tableName)
+	userRepo := dynamodbRepo.NewUserRepositoryDynamoDB(dynamoClient, tableName)
+	roomService = service.NewRoomService(roomRepo, bookingRepo, roomBlockRepo, userRepo, shared.BookingService(dynamoClient, tableName), shared.Notifier(), config.LoadSchedulingRules())
+}
+
+func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	log.Println("GetAvailableSlots handler invoked")
+
+	roomID := request.PathParameters["id"]
+	if roomID == "" {
+		log.Println("Room ID is missing")
+		return shared.Response(400, dto.ErrorResponse{Error: "Invalid room id"})
+	}
+
+	dateStr := request.QueryStringParameters["date"]
+	if dateStr == "" {
+		log.Println("Date parameter is missing")
+		return shared.Response(400, dto.ErrorResponse{Error: "Date parameter is required (format: YYYY-MM-DD)"})
+	}
+
+	targetDate, err := time.Parse("2006-01-02", dateStr)
+	if err != nil {
+		log.Printf("Error parsing date: %v", err)
+		return shared.Response(400, dto.ErrorResponse{Error: "Invalid date format, use YYYY-MM-DD"})
+	}
+
+	duration := defaultSlotDuration
+	if durationStr := request.QueryStringParameters["duration"]; durationStr != "" {
+		val, err := strconv.Atoi(durationStr)
+		if err != nil || val <= 0 {
+			log.Printf("Invalid duration: %s", durationStr)
+			return shared.Response(400, dto.ErrorResponse{Error: "Duration must be a positive number of minutes"})
+		}
+		duration = val
+	}
+
+	slots, err := roomService.GetAvailableSlots(roomID, targetDate.Unix(), duration)
+	if err != nil {
+		log.Printf("Error getting available slots: %v", err)
+		if err == domain.ErrNotFound {
+			return shared.Response(404, dto.ErrorResponse{Error: "Room not found"})
+		}
+		if err == domain.ErrInvalidInput {
+			return shared.Response(400, dto.ErrorResponse{Error: err.Error()})
+		}
+		return shared.Response(500, dto.ErrorResponse{Error: "Internal server error"})
+	}
+
+	room, err := roomService.GetRoomByID(roomID)
+	if err != nil {
+		log.Printf("Error getting room details: %v", err)
+		return shared.Response(404, dto.ErrorResponse{Error: "Room not found"})
+	}
+
+	response := dto.AvailableSlotsResponse{
+		RoomID:   room.ID,
+		RoomName: room.Name,
+		Date:     dateStr,
+		Duration: duration,
+		Slots:    []dto.TimeSlotDTO{},
+	}
+	for _, slot := range slots {
+		response.Slots = append(response.Slots, dto.TimeSlotDTO{
+			StartTime: slot.StartTime,
+			EndTime:   slot.EndTime,
+			Duration:  slot.Duration,
+		})
+	}
+
+	log.Printf("Found %d available slots for room %s on %s", len(response.Slots), roomID, dateStr)
+	return shared.Response(200, response)
+}
+
+func main() {
+	lambda.Start(handler)
+}
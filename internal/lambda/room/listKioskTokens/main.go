@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/service"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+	"github.com/amangirdhar210/meeting-room/internal/lambda/shared"
+)
+
+var checkInService service.CheckInService
+
+func init() {
+	dynamoClient, tableName, err := shared.InitDynamoDB()
+	if err != nil {
+		panic(err)
+	}
+
+	checkInService = shared.CheckInService(dynamoClient, tableName)
+}
+
+func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	roomID := request.PathParameters["id"]
+	if roomID == "" {
+		return shared.Response(400, dto.ErrorResponse{Error: "Room ID is required"})
+	}
+
+	tokens, err := checkInService.ListKioskTokens(roomID)
+	if err != nil {
+		log.Printf("Error listing kiosk tokens of room %s: %v", roomID, err)
+		return shared.CheckInError(err)
+	}
+
+	resp := make([]dto.KioskTokenDTO, 0, len(tokens))
+	for _, token := range tokens {
+		resp = append(resp, shared.KioskTokenResponse(token))
+	}
+	return shared.Response(200, resp)
+}
+
+func main() {
+	lambda.Start(handler)
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/service"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+	"github.com/amangirdhar210/meeting-room/internal/lambda/shared"
+)
+
+var checkInService service.CheckInService
+
+func init() {
+	dynamoClient, tableName, err := shared.InitDynamoDB()
+	if err != nil {
+		panic(err)
+	}
+
+	checkInService = shared.CheckInService(dynamoClient, tableName)
+}
+
+func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	roomID := request.PathParameters["id"]
+	tokenID := request.PathParameters["tokenId"]
+	if roomID == "" || tokenID == "" {
+		return shared.Response(400, dto.ErrorResponse{Error: "Room ID and token ID are required"})
+	}
+
+	if err := checkInService.RevokeKioskToken(roomID, tokenID); err != nil {
+		log.Printf("Error revoking kiosk token %s of room %s: %v", tokenID, roomID, err)
+		return shared.CheckInError(err)
+	}
+
+	return shared.Response(200, dto.GenericResponse{Message: "Kiosk token revoked successfully"})
+}
+
+func main() {
+	lambda.Start(handler)
+}
@@ -4,8 +4,12 @@ import (
 	"context"
 	"log"
 	"strconv"
+	"strings"
+	"time"
 
 	dynamodbRepo "github.com/amangirdhar210/meeting-room/internal/adapters/repositories/dynamoDB"
+	"github.com/amangirdhar210/meeting-room/internal/config"
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
 	"github.com/amangirdhar210/meeting-room/internal/core/service"
 	"github.com/amangirdhar210/meeting-room/internal/http/dto"
 	"github.com/amangirdhar210/meeting-room/internal/lambda/shared"
@@ -22,7 +26,10 @@ func init() {
 	}
 
 	roomRepo := dynamodbRepo.NewRoomRepositoryDynamoDB(dynamoClient, tableName)
-	roomService = service.NewRoomService(roomRepo)
+	roomBlockRepo := dynamodbRepo.NewRoomBlockRepositoryDynamoDB(dynamoClient, tableName)
+	bookingRepo := dynamodbRepo.NewBookingRepositoryDynamoDB(dynamoClient, tableName)
+	userRepo := dynamodbRepo.NewUserRepositoryDynamoDB(dynamoClient, tableName)
+	roomService = service.NewRoomService(roomRepo, bookingRepo, roomBlockRepo, userRepo, shared.BookingService(dynamoClient, tableName), shared.Notifier(), config.LoadSchedulingRules())
 }
 
 func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
@@ -30,35 +37,96 @@ func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 
 	queryParams := request.QueryStringParameters
 
-	minCapacity := 0
+	var filters dto.RoomSearchFilters
 	if minCapStr, ok := queryParams["minCapacity"]; ok && minCapStr != "" {
 		if val, err := strconv.Atoi(minCapStr); err == nil {
-			minCapacity = val
+			filters.MinCapacity = val
 		}
 	}
-
-	maxCapacity := 0
 	if maxCapStr, ok := queryParams["maxCapacity"]; ok && maxCapStr != "" {
 		if val, err := strconv.Atoi(maxCapStr); err == nil {
-			maxCapacity = val
+			filters.MaxCapacity = val
 		}
 	}
-
-	var floor *int
 	if floorStr, ok := queryParams["floor"]; ok && floorStr != "" {
 		if val, err := strconv.Atoi(floorStr); err == nil {
-			floor = &val
+			filters.Floor = &val
+		}
+	}
+	for _, amenity := range strings.Split(queryParams["amenities"], ",") {
+		if amenity = strings.TrimSpace(amenity); amenity != "" {
+			filters.Amenities = append(filters.Amenities, amenity)
 		}
 	}
+	if availableStr, ok := queryParams["available"]; ok && availableStr != "" {
+		val, err := strconv.ParseBool(availableStr)
+		if err != nil {
+			log.Printf("Invalid available parameter: %s", availableStr)
+			return shared.Response(400, dto.ErrorResponse{Error: "available must be true or false"})
+		}
+		filters.Available = &val
+	}
+	filters.Status = strings.TrimSpace(queryParams["status"])
+	filters.StartTime = queryParams["startTime"]
+	filters.EndTime = queryParams["endTime"]
 
-	rooms, err := roomService.SearchRooms(minCapacity, maxCapacity, floor, nil, nil)
+	searchFilter := domain.RoomSearchFilter{
+		MinCapacity: filters.MinCapacity,
+		MaxCapacity: filters.MaxCapacity,
+		Floor:       filters.Floor,
+		Amenities:   filters.Amenities,
+		Status:      filters.Status,
+		Available:   filters.Available,
+	}
+	if filters.StartTime != "" {
+		t, err := time.Parse(time.RFC3339, filters.StartTime)
+		if err != nil {
+			log.Printf("Error parsing start time: %v", err)
+			return shared.Response(400, dto.ErrorResponse{Error: "Invalid startTime format"})
+		}
+		unix := t.Unix()
+		searchFilter.StartTime = &unix
+	}
+	if filters.EndTime != "" {
+		t, err := time.Parse(time.RFC3339, filters.EndTime)
+		if err != nil {
+			log.Printf("Error parsing end time: %v", err)
+			return shared.Response(400, dto.ErrorResponse{Error: "Invalid endTime format"})
+		}
+		unix := t.Unix()
+		searchFilter.EndTime = &unix
+	}
+
+	rooms, err := roomService.SearchRooms(searchFilter)
 	if err != nil {
 		log.Printf("Error searching rooms: %v", err)
+		if err == domain.ErrInvalidInput || err == domain.ErrTimeRangeInvalid {
+			return shared.Response(400, dto.ErrorResponse{Error: err.Error()})
+		}
 		return shared.Response(500, dto.ErrorResponse{Error: "Internal server error"})
 	}
 
-	log.Printf("Found %d rooms matching search criteria", len(rooms))
-	return shared.Response(200, rooms)
+	response := []dto.RoomWithAvailabilityDTO{}
+	for _, room := range rooms {
+		response = append(response, dto.RoomWithAvailabilityDTO{
+			ID:               room.ID,
+			Name:             room.Name,
+			RoomNumber:       room.RoomNumber,
+			Capacity:         room.Capacity,
+			Floor:            room.Floor,
+			Amenities:        room.Amenities,
+			Status:           room.Status,
+			Location:         room.Location,
+			Description:      room.Description,
+			RequiresApproval: room.RequiresApproval,
+			IsAvailable:      room.IsAvailable,
+			NextAvailableAt:  room.NextAvailableAt,
+			CurrentBookingID: room.CurrentBookingID,
+		})
+	}
+
+	log.Printf("Found %d rooms matching search criteria", len(response))
+	return shared.Response(200, response)
 }
 
 func main() {
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/service"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+	"github.com/amangirdhar210/meeting-room/internal/lambda/shared"
+)
+
+var authService service.AuthService
+
+func init() {
+	dynamoClient, tableName, err := shared.InitDynamoDB()
+	if err != nil {
+		panic(err)
+	}
+
+	authService, err = shared.AuthService(dynamoClient, tableName)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	claims, ok := shared.AccessTokenClaims(request)
+	if !ok {
+		return shared.Response(401, dto.ErrorResponse{Error: "Unauthorized"})
+	}
+
+	if err := authService.Logout(claims); err != nil {
+		log.Printf("Failed to log out: %v", err)
+		return shared.Response(500, dto.ErrorResponse{Error: "Failed to log out"})
+	}
+
+	return shared.Response(200, dto.GenericResponse{Message: "Logged out"})
+}
+
+func main() {
+	lambda.Start(handler)
+}
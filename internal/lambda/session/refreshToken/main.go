@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/service"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+	"github.com/amangirdhar210/meeting-room/internal/lambda/shared"
+)
+
+var authService service.AuthService
+
+func init() {
+	dynamoClient, tableName, err := shared.InitDynamoDB()
+	if err != nil {
+		panic(err)
+	}
+
+	authService, err = shared.AuthService(dynamoClient, tableName)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var req dto.RefreshTokenRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return shared.Response(400, dto.ErrorResponse{Error: "Invalid request body"})
+	}
+
+	tokens, err := authService.Refresh(req.RefreshToken)
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidInput:
+			return shared.Response(400, dto.ErrorResponse{Error: "Refresh token is required"})
+		case domain.ErrInvalidRefresh:
+			return shared.Response(401, dto.ErrorResponse{Error: "Refresh token is invalid or expired"})
+		case domain.ErrUserDisabled:
+			return shared.Response(403, dto.ErrorResponse{Error: "User account is disabled"})
+		}
+		log.Printf("Failed to refresh token: %v", err)
+		return shared.Response(500, dto.ErrorResponse{Error: "Failed to refresh token"})
+	}
+
+	return shared.Response(200, dto.RefreshTokenResponse{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    tokens.ExpiresIn,
+	})
+}
+
+func main() {
+	lambda.Start(handler)
+}
@@ -0,0 +1,69 @@
+package shared
+
+import (
+	"encoding/json"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+	dynamoRepo "github.com/amangirdhar210/meeting-room/internal/adapters/repositories/dynamoDB"
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/service"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+)
+
+func ApprovalService(client *dynamodb.Client, tableName string) service.ApprovalService {
+	bookingRepo := dynamoRepo.NewBookingRepositoryDynamoDB(client, tableName)
+	roomRepo := dynamoRepo.NewRoomRepositoryDynamoDB(client, tableName)
+	userRepo := dynamoRepo.NewUserRepositoryDynamoDB(client, tableName)
+
+	bookingService := BookingService(client, tableName)
+	return service.NewApprovalService(bookingRepo, roomRepo, userRepo, bookingService, Notifier())
+}
+
+// ReviewBooking serves both the approve and reject Lambdas.
+func ReviewBooking(request events.APIGatewayProxyRequest, decide func(bookingID, approverID, note string) (*domain.Booking, error)) (events.APIGatewayProxyResponse, error) {
+	var userID string
+	if authContext, ok := request.RequestContext.Authorizer["lambda"].(map[string]any); ok {
+		if uid, exists := authContext["userId"].(string); exists {
+			userID = uid
+		}
+	}
+
+	if userID == "" {
+		return Response(401, dto.ErrorResponse{Error: "Unauthorized"})
+	}
+
+	bookingID := request.PathParameters["id"]
+	if bookingID == "" {
+		return Response(400, dto.ErrorResponse{Error: "Booking ID is required"})
+	}
+
+	var req dto.ReviewBookingRequest
+	if request.Body != "" {
+		if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+			return Response(400, dto.ErrorResponse{Error: "Invalid request body"})
+		}
+	}
+
+	booking, err := decide(bookingID, userID, req.Note)
+	if err != nil {
+		return ApprovalError(err)
+	}
+	return Response(200, BookingResponses([]domain.Booking{*booking})[0])
+}
+
+// ApprovalError maps approval failures to responses.
+func ApprovalError(err error) (events.APIGatewayProxyResponse, error) {
+	switch err {
+	case domain.ErrNotFound:
+		return Response(404, dto.ErrorResponse{Error: "Booking, room or user not found"})
+	case domain.ErrInvalidInput:
+		return Response(400, dto.ErrorResponse{Error: err.Error()})
+	case domain.ErrUserDisabled, domain.ErrNotApprover:
+		return Response(403, dto.ErrorResponse{Error: err.Error()})
+	case domain.ErrBookingNotPending:
+		return Response(409, dto.ErrorResponse{Error: err.Error()})
+	}
+	return Response(500, dto.ErrorResponse{Error: "Internal server error"})
+}
@@ -0,0 +1,138 @@
+package shared
+
+import (
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+	"github.com/amangirdhar210/meeting-room/internal/adapters/auth"
+	"github.com/amangirdhar210/meeting-room/internal/adapters/oidc"
+	dynamoRepo "github.com/amangirdhar210/meeting-room/internal/adapters/repositories/dynamoDB"
+	"github.com/amangirdhar210/meeting-room/internal/config"
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/service"
+)
+
+var jwtGenerator *auth.JWTGenerator
+
+// JWTGenerator loads the signing keys once per container and fails when
+// none are configured.
+func JWTGenerator() (*auth.JWTGenerator, error) {
+	if jwtGenerator != nil {
+		return jwtGenerator, nil
+	}
+
+	generator, err := auth.NewJWTGenerator(config.LoadJWTConfig())
+	if err != nil {
+		return nil, err
+	}
+	jwtGenerator = generator
+	return jwtGenerator, nil
+}
+
+func AuthService(client *dynamodb.Client, tableName string) (service.AuthService, error) {
+	generator, err := JWTGenerator()
+	if err != nil {
+		return nil, err
+	}
+
+	return service.NewAuthService(
+		dynamoRepo.NewUserRepositoryDynamoDB(client, tableName),
+		dynamoRepo.NewRefreshTokenRepositoryDynamoDB(client, tableName),
+		dynamoRepo.NewTokenRevocationStoreDynamoDB(client, tableName),
+		generator,
+		auth.NewBcryptHasher(),
+		config.LoadJWTConfig().RefreshTokenTTL,
+	), nil
+}
+
+// OIDCService returns nil when single sign-on is not configured.
+func OIDCService(client *dynamodb.Client, tableName string) (service.OIDCService, error) {
+	cfg := config.LoadOIDCConfig()
+	if !cfg.Enabled() {
+		return nil, nil
+	}
+
+	authService, err := AuthService(client, tableName)
+	if err != nil {
+		return nil, err
+	}
+	return service.NewOIDCService(
+		oidc.NewProvider(cfg),
+		dynamoRepo.NewOIDCLoginStateRepositoryDynamoDB(client, tableName),
+		dynamoRepo.NewUserIdentityRepositoryDynamoDB(client, tableName),
+		dynamoRepo.NewUserRepositoryDynamoDB(client, tableName),
+		authService,
+		cfg.DefaultRole,
+		cfg.GroupRoles,
+	), nil
+}
+
+// AuthorizerContext is what the authorizers hand to the functions behind
+// them; AccessTokenClaims reads it back.
+func AuthorizerContext(claims *auth.Claims) map[string]any {
+	return map[string]any{
+		"userId":    claims.UserID,
+		"role":      claims.Role,
+		"tokenId":   claims.ID,
+		"sessionId": claims.SessionID,
+		"expiresAt": claims.ExpiresAt.Unix(),
+	}
+}
+
+func AccessTokenClaims(request events.APIGatewayProxyRequest) (domain.AccessTokenClaims, bool) {
+	authContext, ok := request.RequestContext.Authorizer["lambda"].(map[string]any)
+	if !ok {
+		return domain.AccessTokenClaims{}, false
+	}
+
+	var claims domain.AccessTokenClaims
+	claims.UserID, _ = authContext["userId"].(string)
+	claims.TokenID, _ = authContext["tokenId"].(string)
+	claims.SessionID, _ = authContext["sessionId"].(string)
+	switch expiresAt := authContext["expiresAt"].(type) {
+	case float64:
+		claims.ExpiresAt = int64(expiresAt)
+	case int64:
+		claims.ExpiresAt = expiresAt
+	}
+	return claims, claims.UserID != "" && claims.TokenID != "" && claims.SessionID != ""
+}
+
+// BearerClaims checks the access token of a request on a route the
+// authorizer does not guard. Missing, invalid and revoked tokens are all
+// reported as ErrUnauthorized.
+func BearerClaims(request events.APIGatewayProxyRequest, authService service.AuthService) (*auth.Claims, error) {
+	authHeader := request.Headers["authorization"]
+	if authHeader == "" {
+		authHeader = request.Headers["Authorization"]
+	}
+	token := strings.TrimPrefix(authHeader, "Bearer ")
+	if token == "" || token == authHeader {
+		return nil, domain.ErrUnauthorized
+	}
+
+	generator, err := JWTGenerator()
+	if err != nil {
+		return nil, err
+	}
+	claims, err := generator.ValidateToken(token)
+	if err != nil || claims.UserID == "" {
+		return nil, domain.ErrUnauthorized
+	}
+
+	revoked, err := authService.IsRevoked(domain.AccessTokenClaims{
+		UserID:    claims.UserID,
+		TokenID:   claims.ID,
+		SessionID: claims.SessionID,
+		ExpiresAt: claims.ExpiresAt.Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, domain.ErrUnauthorized
+	}
+	return claims, nil
+}
@@ -0,0 +1,68 @@
+package shared
+
+import (
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+	dynamoRepo "github.com/amangirdhar210/meeting-room/internal/adapters/repositories/dynamoDB"
+	"github.com/amangirdhar210/meeting-room/internal/config"
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/service"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+)
+
+// BookingService is for Lambdas whose services hand freed time to the
+// waitlist.
+func BookingService(client *dynamodb.Client, tableName string) service.BookingService {
+	return service.NewBookingService(
+		dynamoRepo.NewBookingRepositoryDynamoDB(client, tableName),
+		dynamoRepo.NewRoomRepositoryDynamoDB(client, tableName),
+		dynamoRepo.NewUserRepositoryDynamoDB(client, tableName),
+		dynamoRepo.NewRoomBlockRepositoryDynamoDB(client, tableName),
+		dynamoRepo.NewWaitlistRepositoryDynamoDB(client, tableName),
+		Notifier(),
+		config.LoadWaitlistConfig().ClaimWindow,
+		config.LoadApprovalConfig().Timeout,
+		config.LoadBookingPolicy(),
+	)
+}
+
+func BookingResponses(bookings []domain.Booking) []dto.BookingDTO {
+	result := make([]dto.BookingDTO, 0, len(bookings))
+	for _, b := range bookings {
+		result = append(result, dto.BookingDTO{
+			ID:                 b.ID,
+			UserID:             b.UserID,
+			RoomID:             b.RoomID,
+			StartTime:          b.StartTime,
+			EndTime:            b.EndTime,
+			Purpose:            b.Purpose,
+			Status:             b.Status,
+			CancelledAt:        b.CancelledAt,
+			CancelledBy:        b.CancelledBy,
+			CancellationReason: b.CancellationReason,
+			SeriesID:           b.SeriesID,
+			GroupID:            b.GroupID,
+			ApprovalExpiresAt:  b.ApprovalExpiresAt,
+			ReviewedBy:         b.ReviewedBy,
+			ReviewedAt:         b.ReviewedAt,
+			ReviewNote:         b.ReviewNote,
+			CheckedInAt:        b.CheckedInAt,
+			ReleasedAt:         b.ReleasedAt,
+		})
+	}
+	return result
+}
+
+// PolicyViolations describes err for a 422 response when it is a booking
+// policy violation.
+func PolicyViolations(err error) (dto.PolicyViolationResponse, bool) {
+	policyErr, ok := domain.AsPolicyError(err)
+	if !ok {
+		return dto.PolicyViolationResponse{}, false
+	}
+	resp := dto.PolicyViolationResponse{Error: "Booking violates the booking policy"}
+	for _, v := range policyErr.Violations {
+		resp.Violations = append(resp.Violations, dto.PolicyViolationDTO(v))
+	}
+	return resp, true
+}
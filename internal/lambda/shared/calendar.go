@@ -0,0 +1,49 @@
+package shared
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/pkg/ical"
+)
+
+func CalendarResponse(feed *domain.CalendarFeed) (events.APIGatewayProxyResponse, error) {
+	cal := ical.Calendar{ProdID: "-//Meeting Room Booking//EN", Name: feed.Name}
+	for _, b := range feed.Bookings {
+		event := ical.Event{
+			UID:          b.ID + "@meeting-room",
+			Sequence:     b.Sequence,
+			Start:        time.Unix(b.StartTime, 0),
+			End:          time.Unix(b.EndTime, 0),
+			Summary:      b.Purpose,
+			Status:       ical.StatusConfirmed,
+			Created:      time.Unix(b.CreatedAt, 0),
+			LastModified: time.Unix(b.UpdatedAt, 0),
+		}
+		if event.Summary == "" {
+			event.Summary = "Meeting room booking"
+		}
+		if b.Status == domain.BookingStatusCancelled {
+			event.Status = ical.StatusCancelled
+		}
+		if b.RoomName != "" {
+			event.Location = fmt.Sprintf("%s (Room %d)", b.RoomName, b.RoomNumber)
+		}
+		if b.UserName != "" {
+			event.Description = fmt.Sprintf("Booked by %s <%s>", b.UserName, b.UserEmail)
+		}
+		cal.Events = append(cal.Events, event)
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: 200,
+		Headers: map[string]string{
+			"Content-Type":                "text/calendar; charset=utf-8",
+			"Access-Control-Allow-Origin": "*",
+		},
+		Body: string(cal.Marshal()),
+	}, nil
+}
@@ -0,0 +1,50 @@
+package shared
+
+import (
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+	dynamoRepo "github.com/amangirdhar210/meeting-room/internal/adapters/repositories/dynamoDB"
+	"github.com/amangirdhar210/meeting-room/internal/config"
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/service"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+)
+
+func CheckInService(client *dynamodb.Client, tableName string) service.CheckInService {
+	bookingRepo := dynamoRepo.NewBookingRepositoryDynamoDB(client, tableName)
+	roomRepo := dynamoRepo.NewRoomRepositoryDynamoDB(client, tableName)
+	userRepo := dynamoRepo.NewUserRepositoryDynamoDB(client, tableName)
+	kioskTokenRepo := dynamoRepo.NewKioskTokenRepositoryDynamoDB(client, tableName)
+
+	bookingService := BookingService(client, tableName)
+	cfg := config.LoadCheckInConfig()
+	return service.NewCheckInService(bookingRepo, roomRepo, userRepo, kioskTokenRepo, bookingService, Notifier(), cfg.OpensBefore, cfg.Grace)
+}
+
+func KioskTokenResponse(token domain.KioskToken) dto.KioskTokenDTO {
+	return dto.KioskTokenDTO{
+		ID:        token.ID,
+		RoomID:    token.RoomID,
+		Name:      token.Name,
+		CreatedBy: token.CreatedBy,
+		CreatedAt: token.CreatedAt,
+	}
+}
+
+// CheckInError maps check-in and kiosk token failures to responses.
+func CheckInError(err error) (events.APIGatewayProxyResponse, error) {
+	switch err {
+	case domain.ErrNotFound:
+		return Response(404, dto.ErrorResponse{Error: "Booking, room or kiosk token not found"})
+	case domain.ErrInvalidInput:
+		return Response(400, dto.ErrorResponse{Error: err.Error()})
+	case domain.ErrUnauthorized:
+		return Response(401, dto.ErrorResponse{Error: "Invalid or revoked kiosk token"})
+	case domain.ErrUserDisabled, domain.ErrNotBookingOwner:
+		return Response(403, dto.ErrorResponse{Error: err.Error()})
+	case domain.ErrBookingNotActive, domain.ErrCheckInNotOpen, domain.ErrCheckInClosed, domain.ErrAlreadyCheckedIn:
+		return Response(409, dto.ErrorResponse{Error: err.Error()})
+	}
+	return Response(500, dto.ErrorResponse{Error: "Internal server error"})
+}
@@ -10,7 +10,6 @@ import (
 
 var dynamoClient *dynamodb.Client
 var tableName string
-var jwtSecret string
 
 func InitDynamoDB() (*dynamodb.Client, string, error) {
 	if dynamoClient != nil {
@@ -22,11 +21,6 @@ func InitDynamoDB() (*dynamodb.Client, string, error) {
 		tableName = "MeetingRoomSystem"
 	}
 
-	jwtSecret = os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		jwtSecret = "your-secret-key"
-	}
-
 	cfg, err := config.LoadDefaultConfig(context.Background())
 	if err != nil {
 		return nil, "", err
@@ -35,7 +29,3 @@ func InitDynamoDB() (*dynamodb.Client, string, error) {
 	dynamoClient = dynamodb.NewFromConfig(cfg)
 	return dynamoClient, tableName, nil
 }
-
-func GetJWTSecret() string {
-	return jwtSecret
-}
@@ -0,0 +1,23 @@
+package shared
+
+import (
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+)
+
+func GroupResponse(result *domain.GroupResult) dto.BookingGroupResponse {
+	resp := dto.BookingGroupResponse{
+		GroupID:   result.GroupID,
+		Bookings:  BookingResponses(result.Bookings),
+		Conflicts: make([]dto.RoomConflictDTO, 0, len(result.Conflicts)),
+	}
+	for _, c := range result.Conflicts {
+		resp.Conflicts = append(resp.Conflicts, dto.RoomConflictDTO{
+			RoomID:                c.RoomID,
+			Reason:                c.Reason.Error(),
+			ConflictingBookingIDs: c.ConflictingBookingIDs,
+			ConflictingBlockIDs:   c.ConflictingBlockIDs,
+		})
+	}
+	return resp
+}
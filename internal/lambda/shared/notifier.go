@@ -0,0 +1,20 @@
+package shared
+
+import (
+	"log"
+
+	"github.com/amangirdhar210/meeting-room/internal/adapters/notification"
+	"github.com/amangirdhar210/meeting-room/internal/config"
+	"github.com/amangirdhar210/meeting-room/internal/core/ports"
+)
+
+// Notifier returns the notifier selected by MAIL_DRIVER, falling back to the
+// application log when the mail settings are invalid.
+func Notifier() ports.Notifier {
+	notifier, err := notification.NewNotifier(config.LoadMailConfig())
+	if err != nil {
+		log.Printf("Falling back to log notifications: %v", err)
+		return notification.NewLogNotifier()
+	}
+	return notifier
+}
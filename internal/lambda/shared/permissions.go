@@ -0,0 +1,29 @@
+package shared
+
+import "github.com/amangirdhar210/meeting-room/internal/core/domain"
+
+// routePermissions holds the permission each API Gateway route needs,
+// keyed by route key. Routes not listed are open to every signed-in user.
+var routePermissions = map[string]domain.Permission{
+	"GET /api/users":                                domain.PermUsersAdmin,
+	"GET /api/users/{id}":                           domain.PermUsersAdmin,
+	"POST /api/users/register":                      domain.PermUsersAdmin,
+	"PATCH /api/users/{id}":                         domain.PermUsersAdmin,
+	"DELETE /api/users/{id}":                        domain.PermUsersAdmin,
+	"POST /api/rooms":                               domain.PermRoomsWrite,
+	"PUT /api/rooms/{id}":                           domain.PermRoomsWrite,
+	"PATCH /api/rooms/{id}":                         domain.PermRoomsWrite,
+	"DELETE /api/rooms/{id}":                        domain.PermRoomsWrite,
+	"POST /api/rooms/{id}/blocks":                   domain.PermRoomsWrite,
+	"DELETE /api/rooms/{id}/blocks/{blockId}":       domain.PermRoomsWrite,
+	"POST /api/rooms/{id}/kiosk-tokens":             domain.PermRoomsWrite,
+	"GET /api/rooms/{id}/kiosk-tokens":              domain.PermRoomsWrite,
+	"DELETE /api/rooms/{id}/kiosk-tokens/{tokenId}": domain.PermRoomsWrite,
+	"POST /api/admin/bookings/import":               domain.PermBookingsImport,
+}
+
+// CanAccessRoute reports whether role may call the route with routeKey.
+func CanAccessRoute(role, routeKey string) bool {
+	permission, ok := routePermissions[routeKey]
+	return !ok || domain.HasPermission(role, permission)
+}
@@ -0,0 +1,94 @@
+package shared
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/service"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// UpdateRoom serves both the PUT and PATCH room Lambdas. With replace set the
+// core fields are required and omitted amenities/description are cleared.
+func UpdateRoom(roomService service.RoomService, request events.APIGatewayProxyRequest, replace bool) (events.APIGatewayProxyResponse, error) {
+	roomID := request.PathParameters["id"]
+	if roomID == "" {
+		return Response(400, dto.ErrorResponse{Error: "Room ID is required"})
+	}
+
+	var req dto.UpdateRoomRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		log.Printf("Error unmarshalling request body: %v", err)
+		return Response(400, dto.ErrorResponse{Error: "Invalid request body"})
+	}
+
+	if replace {
+		if req.Name == nil || req.RoomNumber == nil || req.Capacity == nil || req.Floor == nil || req.Location == nil {
+			return Response(400, dto.ErrorResponse{Error: "name, roomNumber, capacity, floor and location are required"})
+		}
+		if req.Amenities == nil {
+			req.Amenities = &[]string{}
+		}
+		if req.Description == nil {
+			req.Description = new(string)
+		}
+		if req.RequiresApproval == nil {
+			req.RequiresApproval = new(bool)
+		}
+		if req.Approvers == nil {
+			req.Approvers = &[]string{}
+		}
+		if req.BookingPolicy == nil {
+			req.BookingPolicy = &dto.RoomPolicyDTO{}
+		}
+	}
+
+	var bookingPolicy *domain.RoomPolicy
+	if req.BookingPolicy != nil {
+		policy := domain.RoomPolicy(*req.BookingPolicy)
+		bookingPolicy = &policy
+	}
+
+	room, err := roomService.UpdateRoom(roomID, domain.RoomUpdate{
+		Name:        req.Name,
+		RoomNumber:  req.RoomNumber,
+		Capacity:    req.Capacity,
+		Floor:       req.Floor,
+		Amenities:   req.Amenities,
+		Status:      req.Status,
+		Location:    req.Location,
+		Description: req.Description,
+
+		RequiresApproval: req.RequiresApproval,
+		Approvers:        req.Approvers,
+		BookingPolicy:    bookingPolicy,
+	})
+	if err != nil {
+		log.Printf("Error updating room %s: %v", roomID, err)
+		switch err {
+		case domain.ErrNotFound:
+			return Response(404, dto.ErrorResponse{Error: "Room not found"})
+		case domain.ErrInvalidInput:
+			return Response(400, dto.ErrorResponse{Error: err.Error()})
+		default:
+			return Response(500, dto.ErrorResponse{Error: "Internal server error"})
+		}
+	}
+
+	log.Printf("Room updated successfully with ID: %s", room.ID)
+	return Response(200, room)
+}
+
+func RoomBlockResponse(block domain.RoomBlock) dto.RoomBlockDTO {
+	return dto.RoomBlockDTO{
+		ID:        block.ID,
+		RoomID:    block.RoomID,
+		StartTime: block.StartTime,
+		EndTime:   block.EndTime,
+		Reason:    block.Reason,
+		CreatedBy: block.CreatedBy,
+		CreatedAt: block.CreatedAt,
+	}
+}
@@ -0,0 +1,49 @@
+package shared
+
+import (
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+)
+
+func SeriesResponse(result *domain.SeriesResult) dto.BookingSeriesResponse {
+	resp := dto.BookingSeriesResponse{
+		Bookings:  make([]dto.BookingDTO, 0, len(result.Bookings)),
+		Conflicts: make([]dto.OccurrenceConflictDTO, 0, len(result.Conflicts)),
+	}
+	if series := result.Series; series != nil && series.ID != "" {
+		resp.Series = &dto.BookingSeriesDTO{
+			ID:        series.ID,
+			UserID:    series.UserID,
+			RoomID:    series.RoomID,
+			Purpose:   series.Purpose,
+			RRule:     series.RRule,
+			Timezone:  series.Timezone,
+			StartTime: series.StartTime,
+			EndTime:   series.EndTime,
+			ExDates:   series.ExDates,
+			Status:    series.Status,
+		}
+	}
+	for _, b := range result.Bookings {
+		resp.Bookings = append(resp.Bookings, dto.BookingDTO{
+			ID:                b.ID,
+			UserID:            b.UserID,
+			RoomID:            b.RoomID,
+			StartTime:         b.StartTime,
+			EndTime:           b.EndTime,
+			Purpose:           b.Purpose,
+			Status:            b.Status,
+			SeriesID:          b.SeriesID,
+			ApprovalExpiresAt: b.ApprovalExpiresAt,
+		})
+	}
+	for _, c := range result.Conflicts {
+		resp.Conflicts = append(resp.Conflicts, dto.OccurrenceConflictDTO{
+			StartTime:             c.StartTime,
+			EndTime:               c.EndTime,
+			ConflictingBookingIDs: c.ConflictingBookingIDs,
+			ConflictingBlockIDs:   c.ConflictingBlockIDs,
+		})
+	}
+	return resp
+}
@@ -0,0 +1,40 @@
+package shared
+
+import (
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/service"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+)
+
+func UserResponse(user domain.User) dto.UserDTO {
+	return dto.UserDTO{
+		ID:         user.ID,
+		Name:       user.Name,
+		Email:      user.Email,
+		Role:       user.Role,
+		Status:     user.Status,
+		DisabledAt: user.DisabledAt,
+		CreatedAt:  user.CreatedAt,
+		UpdatedAt:  user.UpdatedAt,
+	}
+}
+
+// UpdateUser applies a profile update and maps the result to a response; it
+// backs both the admin and the self-service endpoints.
+func UpdateUser(userService service.UserService, userID string, update domain.UserUpdate) (events.APIGatewayProxyResponse, error) {
+	user, err := userService.UpdateUser(userID, update)
+	if err != nil {
+		switch err {
+		case domain.ErrNotFound:
+			return Response(404, dto.ErrorResponse{Error: "User not found"})
+		case domain.ErrInvalidInput:
+			return Response(400, dto.ErrorResponse{Error: "Name, a valid email and a role of admin or user are required"})
+		case domain.ErrConflict:
+			return Response(409, dto.ErrorResponse{Error: "Email is already in use"})
+		}
+		return Response(500, dto.ErrorResponse{Error: "Failed to update user"})
+	}
+	return Response(200, UserResponse(*user))
+}
@@ -0,0 +1,58 @@
+package shared
+
+import (
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+	dynamoRepo "github.com/amangirdhar210/meeting-room/internal/adapters/repositories/dynamoDB"
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/service"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+)
+
+func WaitlistService(client *dynamodb.Client, tableName string) service.WaitlistService {
+	waitlistRepo := dynamoRepo.NewWaitlistRepositoryDynamoDB(client, tableName)
+	bookingRepo := dynamoRepo.NewBookingRepositoryDynamoDB(client, tableName)
+	roomRepo := dynamoRepo.NewRoomRepositoryDynamoDB(client, tableName)
+	userRepo := dynamoRepo.NewUserRepositoryDynamoDB(client, tableName)
+	roomBlockRepo := dynamoRepo.NewRoomBlockRepositoryDynamoDB(client, tableName)
+
+	bookingService := BookingService(client, tableName)
+	return service.NewWaitlistService(waitlistRepo, bookingService, bookingRepo, roomRepo, userRepo, roomBlockRepo)
+}
+
+func WaitlistEntryResponse(e domain.WaitlistEntry) dto.WaitlistEntryDTO {
+	return dto.WaitlistEntryDTO{
+		ID:             e.ID,
+		UserID:         e.UserID,
+		RoomID:         e.RoomID,
+		StartTime:      e.StartTime,
+		EndTime:        e.EndTime,
+		Purpose:        e.Purpose,
+		AutoBook:       e.AutoBook,
+		Status:         e.Status,
+		BookingID:      e.BookingID,
+		OfferExpiresAt: e.OfferExpiresAt,
+		CreatedAt:      e.CreatedAt,
+	}
+}
+
+// WaitlistError maps waitlist failures to responses.
+func WaitlistError(err error) (events.APIGatewayProxyResponse, error) {
+	if violations, ok := PolicyViolations(err); ok {
+		return Response(422, violations)
+	}
+	switch err {
+	case domain.ErrNotFound:
+		return Response(404, dto.ErrorResponse{Error: "Waitlist entry, room or user not found"})
+	case domain.ErrInvalidInput, domain.ErrTimeRangeInvalid, domain.ErrBookingTooLong:
+		return Response(400, dto.ErrorResponse{Error: err.Error()})
+	case domain.ErrUserDisabled:
+		return Response(403, dto.ErrorResponse{Error: err.Error()})
+	case domain.ErrConflict:
+		return Response(409, dto.ErrorResponse{Error: "Waitlist entry already exists or is closed"})
+	case domain.ErrRoomAvailable, domain.ErrRoomUnavailable, domain.ErrRoomNotBookable, domain.ErrRoomBlocked, domain.ErrWaitlistNotOffered:
+		return Response(409, dto.ErrorResponse{Error: err.Error()})
+	}
+	return Response(500, dto.ErrorResponse{Error: "Internal server error"})
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+
+	"github.com/amangirdhar210/meeting-room/internal/adapters/auth"
+	dynamoRepo "github.com/amangirdhar210/meeting-room/internal/adapters/repositories/dynamoDB"
+	"github.com/amangirdhar210/meeting-room/internal/core/domain"
+	"github.com/amangirdhar210/meeting-room/internal/core/service"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+	"github.com/amangirdhar210/meeting-room/internal/lambda/shared"
+)
+
+var userService service.UserService
+
+func init() {
+	dynamoClient, tableName, err := shared.InitDynamoDB()
+	if err != nil {
+		panic(err)
+	}
+
+	userRepo := dynamoRepo.NewUserRepositoryDynamoDB(dynamoClient, tableName)
+	bookingRepo := dynamoRepo.NewBookingRepositoryDynamoDB(dynamoClient, tableName)
+	roomRepo := dynamoRepo.NewRoomRepositoryDynamoDB(dynamoClient, tableName)
+	seriesRepo := dynamoRepo.NewBookingSeriesRepositoryDynamoDB(dynamoClient, tableName)
+
+	hasher := auth.NewBcryptHasher()
+	authService, err := shared.AuthService(dynamoClient, tableName)
+	if err != nil {
+		panic(err)
+	}
+	userService = service.NewUserService(userRepo, bookingRepo, roomRepo, seriesRepo, hasher, shared.BookingService(dynamoClient, tableName), shared.Notifier(), authService)
+}
+
+func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	claims, ok := shared.AccessTokenClaims(request)
+	if !ok {
+		return shared.Response(401, dto.ErrorResponse{Error: "Unauthorized"})
+	}
+
+	var req dto.ChangePasswordRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return shared.Response(400, dto.ErrorResponse{Error: "Invalid request body"})
+	}
+
+	if err := userService.ChangePassword(claims.UserID, claims.SessionID, req.CurrentPassword, req.NewPassword); err != nil {
+		switch err {
+		case domain.ErrInvalidInput:
+			return shared.Response(400, dto.ErrorResponse{Error: "Current password and a new password of at least 6 characters are required"})
+		case domain.ErrIncorrectPassword:
+			return shared.Response(403, dto.ErrorResponse{Error: "Current password is incorrect"})
+		case domain.ErrNotFound:
+			return shared.Response(404, dto.ErrorResponse{Error: "User not found"})
+		}
+		return shared.Response(500, dto.ErrorResponse{Error: "Failed to change password"})
+	}
+
+	return shared.Response(200, dto.GenericResponse{Message: "Password changed successfully"})
+}
+
+func main() {
+	lambda.Start(handler)
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
@@ -23,9 +24,16 @@ func init() {
 	}
 
 	userRepo := dynamoRepo.NewUserRepositoryDynamoDB(dynamoClient, tableName)
+	bookingRepo := dynamoRepo.NewBookingRepositoryDynamoDB(dynamoClient, tableName)
+	roomRepo := dynamoRepo.NewRoomRepositoryDynamoDB(dynamoClient, tableName)
+	seriesRepo := dynamoRepo.NewBookingSeriesRepositoryDynamoDB(dynamoClient, tableName)
 
 	hasher := auth.NewBcryptHasher()
-	userService = service.NewUserService(userRepo, hasher)
+	authService, err := shared.AuthService(dynamoClient, tableName)
+	if err != nil {
+		panic(err)
+	}
+	userService = service.NewUserService(userRepo, bookingRepo, roomRepo, seriesRepo, hasher, shared.BookingService(dynamoClient, tableName), shared.Notifier(), authService)
 }
 
 func Handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
@@ -45,15 +53,37 @@ func Handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 		return shared.Response(403, dto.ErrorResponse{Error: "Cannot delete yourself"})
 	}
 
-	err := userService.DeleteUserByID(idToDelete)
+	var req dto.DeactivateUserRequest
+	if request.Body != "" {
+		if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+			return shared.Response(400, dto.ErrorResponse{Error: "Invalid request body"})
+		}
+	}
+
+	result, err := userService.DeactivateUser(idToDelete, domain.UserDeactivation{
+		DelegateID: req.DelegateID,
+		DisabledBy: currentUserID,
+		Reason:     req.Reason,
+	})
 	if err != nil {
-		if err == domain.ErrNotFound {
+		switch err {
+		case domain.ErrNotFound:
 			return shared.Response(404, dto.ErrorResponse{Error: "User not found"})
+		case domain.ErrInvalidInput:
+			return shared.Response(400, dto.ErrorResponse{Error: "Delegate must be a different user"})
+		case domain.ErrConflict:
+			return shared.Response(409, dto.ErrorResponse{Error: "User is already disabled"})
+		case domain.ErrUserDisabled:
+			return shared.Response(403, dto.ErrorResponse{Error: "Delegate account is disabled"})
 		}
 		return shared.Response(500, dto.ErrorResponse{Error: "Failed to delete user"})
 	}
 
-	return shared.Response(200, dto.GenericResponse{Message: "User deleted successfully"})
+	return shared.Response(200, dto.DeactivateUserResponse{
+		Message:            "User disabled successfully",
+		CancelledBookings:  shared.BookingResponses(result.Cancelled),
+		ReassignedBookings: shared.BookingResponses(result.Reassigned),
+	})
 }
 
 func main() {
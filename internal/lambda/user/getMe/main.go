@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+
+	"github.com/amangirdhar210/meeting-room/internal/adapters/auth"
+	dynamoRepo "github.com/amangirdhar210/meeting-room/internal/adapters/repositories/dynamoDB"
+	"github.com/amangirdhar210/meeting-room/internal/core/service"
+	"github.com/amangirdhar210/meeting-room/internal/http/dto"
+	"github.com/amangirdhar210/meeting-room/internal/lambda/shared"
+)
+
+var userService service.UserService
+
+func init() {
+	dynamoClient, tableName, err := shared.InitDynamoDB()
+	if err != nil {
+		panic(err)
+	}
+
+	userRepo := dynamoRepo.NewUserRepositoryDynamoDB(dynamoClient, tableName)
+	bookingRepo := dynamoRepo.NewBookingRepositoryDynamoDB(dynamoClient, tableName)
+	roomRepo := dynamoRepo.NewRoomRepositoryDynamoDB(dynamoClient, tableName)
+	seriesRepo := dynamoRepo.NewBookingSeriesRepositoryDynamoDB(dynamoClient, tableName)
+
+	hasher := auth.NewBcryptHasher()
+	authService, err := shared.AuthService(dynamoClient, tableName)
+	if err != nil {
+		panic(err)
+	}
+	userService = service.NewUserService(userRepo, bookingRepo, roomRepo, seriesRepo, hasher, shared.BookingService(dynamoClient, tableName), shared.Notifier(), authService)
+}
+
+func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var userID string
+	if authContext, ok := request.RequestContext.Authorizer["lambda"].(map[string]any); ok {
+		if uid, exists := authContext["userId"].(string); exists {
+			userID = uid
+		}
+	}
+	if userID == "" {
+		return shared.Response(401, dto.ErrorResponse{Error: "Unauthorized"})
+	}
+
+	user, err := userService.GetUserByID(userID)
+	if err != nil {
+		return shared.Response(404, dto.ErrorResponse{Error: "User not found"})
+	}
+
+	return shared.Response(200, shared.UserResponse(*user))
+}
+
+func main() {
+	lambda.Start(handler)
+}
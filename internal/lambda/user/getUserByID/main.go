@@ -22,9 +22,16 @@ func init() {
 	}
 
 	userRepo := dynamoRepo.NewUserRepositoryDynamoDB(dynamoClient, tableName)
+	bookingRepo := dynamoRepo.NewBookingRepositoryDynamoDB(dynamoClient, tableName)
+	roomRepo := dynamoRepo.NewRoomRepositoryDynamoDB(dynamoClient, tableName)
+	seriesRepo := dynamoRepo.NewBookingSeriesRepositoryDynamoDB(dynamoClient, tableName)
 
 	hasher := auth.NewBcryptHasher()
-	userService = service.NewUserService(userRepo, hasher)
+	authService, err := shared.AuthService(dynamoClient, tableName)
+	if err != nil {
+		panic(err)
+	}
+	userService = service.NewUserService(userRepo, bookingRepo, roomRepo, seriesRepo, hasher, shared.BookingService(dynamoClient, tableName), shared.Notifier(), authService)
 }
 
 func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
@@ -39,12 +46,14 @@ func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 	}
 
 	return shared.Response(200, dto.UserDTO{
-		ID:        user.ID,
-		Name:      user.Name,
-		Email:     user.Email,
-		Role:      user.Role,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+		ID:         user.ID,
+		Name:       user.Name,
+		Email:      user.Email,
+		Role:       user.Role,
+		Status:     user.Status,
+		DisabledAt: user.DisabledAt,
+		CreatedAt:  user.CreatedAt,
+		UpdatedAt:  user.UpdatedAt,
 	})
 }
 
@@ -0,0 +1,137 @@
+// Package ical renders iCalendar (RFC 5545) feeds for calendar subscriptions
+// and reads the events of uploaded calendar files.
+package ical
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	StatusConfirmed = "CONFIRMED"
+	StatusCancelled = "CANCELLED"
+)
+
+// maxLineOctets is the longest content line RFC 5545 allows before it has to
+// be folded.
+const maxLineOctets = 75
+
+const dateTimeFormat = "20060102T150405Z"
+
+type Event struct {
+	UID          string
+	Sequence     int
+	Start        time.Time
+	End          time.Time
+	Summary      string
+	Description  string
+	Location     string
+	Status       string
+	Created      time.Time
+	LastModified time.Time
+
+	// Only filled in by DecodeEvent.
+	OrganizerName  string
+	OrganizerEmail string
+	RRule          string
+}
+
+type Calendar struct {
+	ProdID string
+	Name   string
+	Events []Event
+}
+
+func (c Calendar) Marshal() []byte {
+	var b strings.Builder
+	writeLine(&b, "BEGIN:VCALENDAR")
+	writeLine(&b, "VERSION:2.0")
+	writeLine(&b, "PRODID:"+c.ProdID)
+	writeLine(&b, "CALSCALE:GREGORIAN")
+	writeLine(&b, "METHOD:PUBLISH")
+	if c.Name != "" {
+		writeLine(&b, "X-WR-CALNAME:"+escapeText(c.Name))
+	}
+
+	for _, e := range c.Events {
+		writeLine(&b, "BEGIN:VEVENT")
+		writeLine(&b, "UID:"+e.UID)
+		writeLine(&b, "DTSTAMP:"+formatTime(stamp(e)))
+		writeLine(&b, "DTSTART:"+formatTime(e.Start))
+		writeLine(&b, "DTEND:"+formatTime(e.End))
+		writeLine(&b, "SEQUENCE:"+strconv.Itoa(e.Sequence))
+		if e.Status != "" {
+			writeLine(&b, "STATUS:"+e.Status)
+		}
+		writeLine(&b, "SUMMARY:"+escapeText(e.Summary))
+		if e.Description != "" {
+			writeLine(&b, "DESCRIPTION:"+escapeText(e.Description))
+		}
+		if e.Location != "" {
+			writeLine(&b, "LOCATION:"+escapeText(e.Location))
+		}
+		if !e.Created.IsZero() {
+			writeLine(&b, "CREATED:"+formatTime(e.Created))
+		}
+		if !e.LastModified.IsZero() {
+			writeLine(&b, "LAST-MODIFIED:"+formatTime(e.LastModified))
+		}
+		writeLine(&b, "END:VEVENT")
+	}
+
+	writeLine(&b, "END:VCALENDAR")
+	return []byte(b.String())
+}
+
+// stamp picks DTSTAMP from the event's own timestamps so that an unchanged
+// feed renders byte for byte the same on every poll.
+func stamp(e Event) time.Time {
+	if !e.LastModified.IsZero() {
+		return e.LastModified
+	}
+	if !e.Created.IsZero() {
+		return e.Created
+	}
+	return e.Start
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(dateTimeFormat)
+}
+
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", `\n`,
+)
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+// writeLine folds the line into chunks of at most maxLineOctets octets,
+// never splitting a UTF-8 sequence, and terminates each with CRLF.
+func writeLine(b *strings.Builder, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// continuation lines start with a space, which counts towards the limit
+		limit = maxLineOctets - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+func isRuneStart(c byte) bool {
+	return c&0xC0 != 0x80
+}
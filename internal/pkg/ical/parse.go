@@ -0,0 +1,302 @@
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrMalformed = errors.New("malformed iCalendar data")
+
+// maxContentBytes bounds how much of an upload Parse will read.
+const maxContentBytes = 10 << 20
+
+type Property struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+type Component struct {
+	Name       string
+	Properties []Property
+	Components []Component
+}
+
+// Get returns the first property called name.
+func (c Component) Get(name string) (Property, bool) {
+	for _, p := range c.Properties {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return Property{}, false
+}
+
+func (c Component) Children(name string) []Component {
+	var children []Component
+	for _, child := range c.Components {
+		if child.Name == name {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// Parse reads an iCalendar stream and returns its VCALENDAR component.
+func Parse(r io.Reader) (Component, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxContentBytes+1))
+	if err != nil {
+		return Component{}, err
+	}
+	if len(data) > maxContentBytes {
+		return Component{}, fmt.Errorf("%w: larger than %d bytes", ErrMalformed, maxContentBytes)
+	}
+
+	var stack []Component
+	var root *Component
+	scanner := bufio.NewScanner(strings.NewReader(unfold(string(data))))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxContentBytes)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		prop, err := parseLine(line)
+		if err != nil {
+			return Component{}, fmt.Errorf("%w: line %d: %v", ErrMalformed, lineNo, err)
+		}
+
+		switch prop.Name {
+		case "BEGIN":
+			stack = append(stack, Component{Name: strings.ToUpper(prop.Value)})
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1].Name != strings.ToUpper(prop.Value) {
+				return Component{}, fmt.Errorf("%w: line %d: unexpected END:%s", ErrMalformed, lineNo, prop.Value)
+			}
+			done := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				root = &done
+			} else {
+				parent := &stack[len(stack)-1]
+				parent.Components = append(parent.Components, done)
+			}
+		default:
+			if len(stack) == 0 {
+				return Component{}, fmt.Errorf("%w: line %d: property outside a component", ErrMalformed, lineNo)
+			}
+			current := &stack[len(stack)-1]
+			current.Properties = append(current.Properties, prop)
+		}
+		if root != nil {
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Component{}, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	if root == nil || root.Name != "VCALENDAR" {
+		return Component{}, fmt.Errorf("%w: no VCALENDAR found", ErrMalformed)
+	}
+	return *root, nil
+}
+
+// unfold joins continuation lines, which start with a space or tab, and
+// normalises line endings to LF.
+func unfold(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\n ", "")
+	return strings.ReplaceAll(s, "\n\t", "")
+}
+
+func parseLine(line string) (Property, error) {
+	inQuotes := false
+	colon := -1
+	for i, c := range line {
+		if c == '"' {
+			inQuotes = !inQuotes
+		} else if c == ':' && !inQuotes {
+			colon = i
+			break
+		}
+	}
+	if colon <= 0 {
+		return Property{}, errors.New("missing ':'")
+	}
+
+	head := splitParams(line[:colon])
+	prop := Property{Name: strings.ToUpper(head[0]), Value: line[colon+1:]}
+	for _, param := range head[1:] {
+		name, value, ok := strings.Cut(param, "=")
+		if !ok {
+			return Property{}, fmt.Errorf("invalid parameter %q", param)
+		}
+		if prop.Params == nil {
+			prop.Params = make(map[string]string)
+		}
+		prop.Params[strings.ToUpper(name)] = strings.Trim(value, `"`)
+	}
+	return prop, nil
+}
+
+func splitParams(head string) []string {
+	var parts []string
+	inQuotes := false
+	start := 0
+	for i, c := range head {
+		switch {
+		case c == '"':
+			inQuotes = !inQuotes
+		case c == ';' && !inQuotes:
+			parts = append(parts, head[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, head[start:])
+}
+
+var textUnescaper = strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n")
+
+// Text returns the value with TEXT escapes removed.
+func (p Property) Text() string {
+	return textUnescaper.Replace(p.Value)
+}
+
+// Time parses a DATE-TIME or DATE value. Floating times and dates without a
+// TZID are read in loc. The second result reports a DATE value.
+func (p Property) Time(loc *time.Location) (time.Time, bool, error) {
+	if tzid := p.Params["TZID"]; tzid != "" {
+		tz, err := time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("%s: unknown time zone %q", p.Name, tzid)
+		}
+		loc = tz
+	}
+
+	if p.Params["VALUE"] == "DATE" || len(p.Value) == len("20060102") {
+		t, err := time.ParseInLocation("20060102", p.Value, loc)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("%s: invalid date %q", p.Name, p.Value)
+		}
+		return t, true, nil
+	}
+	if strings.HasSuffix(p.Value, "Z") {
+		t, err := time.Parse(dateTimeFormat, p.Value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("%s: invalid date-time %q", p.Name, p.Value)
+		}
+		return t, false, nil
+	}
+	t, err := time.ParseInLocation("20060102T150405", p.Value, loc)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("%s: invalid date-time %q", p.Name, p.Value)
+	}
+	return t, false, nil
+}
+
+var durationPattern = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+func parseDuration(value string) (time.Duration, error) {
+	m := durationPattern.FindStringSubmatch(value)
+	if m == nil || value == "P" || strings.HasSuffix(value, "T") {
+		return 0, fmt.Errorf("DURATION: invalid value %q", value)
+	}
+
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var d time.Duration
+	for i, unit := range units {
+		if m[i+2] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(m[i+2])
+		if err != nil {
+			return 0, fmt.Errorf("DURATION: invalid value %q", value)
+		}
+		d += time.Duration(n) * unit
+	}
+	if m[1] == "-" {
+		d = -d
+	}
+	return d, nil
+}
+
+// DecodeEvent reads the fields of a VEVENT into an Event. Times without a
+// zone are read in loc.
+func DecodeEvent(c Component, loc *time.Location) (Event, error) {
+	if c.Name != "VEVENT" {
+		return Event{}, fmt.Errorf("%w: %s is not a VEVENT", ErrMalformed, c.Name)
+	}
+
+	var e Event
+	if p, ok := c.Get("UID"); ok {
+		e.UID = p.Value
+	}
+	if p, ok := c.Get("SUMMARY"); ok {
+		e.Summary = p.Text()
+	}
+	if p, ok := c.Get("DESCRIPTION"); ok {
+		e.Description = p.Text()
+	}
+	if p, ok := c.Get("LOCATION"); ok {
+		e.Location = p.Text()
+	}
+	if p, ok := c.Get("STATUS"); ok {
+		e.Status = strings.ToUpper(p.Value)
+	}
+	if p, ok := c.Get("RRULE"); ok {
+		e.RRule = p.Value
+	}
+	if p, ok := c.Get("ORGANIZER"); ok {
+		e.OrganizerName = p.Params["CN"]
+		value := p.Value
+		if len(value) >= len("mailto:") && strings.EqualFold(value[:len("mailto:")], "mailto:") {
+			value = value[len("mailto:"):]
+		}
+		e.OrganizerEmail = value
+	}
+	if p, ok := c.Get("SEQUENCE"); ok {
+		e.Sequence, _ = strconv.Atoi(p.Value)
+	}
+
+	start, ok := c.Get("DTSTART")
+	if !ok {
+		return e, fmt.Errorf("%w: DTSTART is required", ErrMalformed)
+	}
+	var allDay bool
+	var err error
+	e.Start, allDay, err = start.Time(loc)
+	if err != nil {
+		return e, err
+	}
+
+	switch {
+	case hasProperty(c, "DTEND"):
+		end, _ := c.Get("DTEND")
+		if e.End, _, err = end.Time(loc); err != nil {
+			return e, err
+		}
+	case hasProperty(c, "DURATION"):
+		duration, _ := c.Get("DURATION")
+		d, err := parseDuration(duration.Value)
+		if err != nil {
+			return e, err
+		}
+		e.End = e.Start.Add(d)
+	case allDay:
+		e.End = e.Start.AddDate(0, 0, 1)
+	default:
+		e.End = e.Start
+	}
+	return e, nil
+}
+
+func hasProperty(c Component, name string) bool {
+	_, ok := c.Get(name)
+	return ok
+}
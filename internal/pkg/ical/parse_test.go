@@ -0,0 +1,215 @@
+package ical
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func parseFixture(t *testing.T, name string) Component {
+	t.Helper()
+	f, err := os.Open(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("open fixture: %v", err)
+	}
+	defer f.Close()
+
+	cal, err := Parse(f)
+	if err != nil {
+		t.Fatalf("Parse(%s) failed: %v", name, err)
+	}
+	return cal
+}
+
+func decodeEvents(t *testing.T, cal Component, loc *time.Location) []Event {
+	t.Helper()
+	var events []Event
+	for _, c := range cal.Children("VEVENT") {
+		e, err := DecodeEvent(c, loc)
+		if err != nil {
+			t.Fatalf("DecodeEvent(%s) failed: %v", e.UID, err)
+		}
+		events = append(events, e)
+	}
+	return events
+}
+
+func TestParseOutlookExport(t *testing.T) {
+	cal := parseFixture(t, "outlook.ics")
+
+	if got := len(cal.Children("VTIMEZONE")); got != 1 {
+		t.Fatalf("got %d VTIMEZONE components, want 1", got)
+	}
+	events := decodeEvents(t, cal, time.UTC)
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want 2", len(events))
+	}
+
+	e := events[0]
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	if want := time.Date(2025, 3, 10, 9, 30, 0, 0, berlin); !e.Start.Equal(want) {
+		t.Errorf("Start = %v, want %v", e.Start, want)
+	}
+	if want := time.Date(2025, 3, 10, 10, 0, 0, 0, time.UTC); !e.End.Equal(want) {
+		t.Errorf("End = %v, want %v", e.End.UTC(), want)
+	}
+	if want := "Planning, Q3; budget"; e.Summary != want {
+		t.Errorf("Summary = %q, want %q", e.Summary, want)
+	}
+	if want := "Agenda:\nReview the numbers and agree on the hiring plan for the next quarter before the board meets."; e.Description != want {
+		t.Errorf("Description = %q, want %q", e.Description, want)
+	}
+	if e.OrganizerName != "Doe, Jane" || e.OrganizerEmail != "jane.doe@example.com" {
+		t.Errorf("Organizer = %q <%s>, want \"Doe, Jane\" <jane.doe@example.com>", e.OrganizerName, e.OrganizerEmail)
+	}
+	if e.Location != "Room 101" || e.Status != StatusConfirmed || e.Sequence != 2 {
+		t.Errorf("got location %q, status %q, sequence %d", e.Location, e.Status, e.Sequence)
+	}
+
+	cancelled := events[1]
+	if cancelled.Status != StatusCancelled {
+		t.Errorf("Status = %q, want %q", cancelled.Status, StatusCancelled)
+	}
+	if got := cancelled.End.Sub(cancelled.Start); got != 45*time.Minute {
+		t.Errorf("DURATION gave %v, want 45m", got)
+	}
+}
+
+func TestParseAllDayAndFloatingEvents(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+	events := decodeEvents(t, parseFixture(t, "allday.ics"), newYork)
+
+	tests := []struct {
+		uid        string
+		start, end time.Time
+	}{
+		{"offsite", time.Date(2025, 4, 2, 0, 0, 0, 0, newYork), time.Date(2025, 4, 3, 0, 0, 0, 0, newYork)},
+		{"workshop", time.Date(2025, 4, 7, 0, 0, 0, 0, newYork), time.Date(2025, 4, 9, 0, 0, 0, 0, newYork)},
+		{"floating", time.Date(2025, 4, 3, 9, 0, 0, 0, newYork), time.Date(2025, 4, 3, 9, 30, 0, 0, newYork)},
+	}
+	if len(events) != len(tests) {
+		t.Fatalf("got %d events, want %d", len(events), len(tests))
+	}
+	for i, tt := range tests {
+		e := events[i]
+		if e.UID != tt.uid {
+			t.Errorf("event %d: UID = %q, want %q", i, e.UID, tt.uid)
+			continue
+		}
+		if !e.Start.Equal(tt.start) || !e.End.Equal(tt.end) {
+			t.Errorf("%s: got %v - %v, want %v - %v", tt.uid, e.Start, e.End, tt.start, tt.end)
+		}
+	}
+}
+
+func TestParseUnfoldsTabsAndKeepsQuotedColons(t *testing.T) {
+	data := "BEGIN:VCALENDAR\n" +
+		"BEGIN:VEVENT\n" +
+		"DTSTART:20250101T100000Z\n" +
+		"ATTENDEE;CN=\"Room: 101\";ROLE=NON-PARTICIPANT:mailto:room@\n" +
+		"\texample.com\n" +
+		"END:VEVENT\n" +
+		"END:VCALENDAR\n"
+	cal, err := Parse(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	p, ok := cal.Children("VEVENT")[0].Get("ATTENDEE")
+	if !ok {
+		t.Fatal("ATTENDEE missing")
+	}
+	if p.Value != "mailto:room@example.com" {
+		t.Errorf("Value = %q, want mailto:room@example.com", p.Value)
+	}
+	if p.Params["CN"] != "Room: 101" || p.Params["ROLE"] != "NON-PARTICIPANT" {
+		t.Errorf("Params = %v", p.Params)
+	}
+}
+
+func TestParseRejectsMalformedInput(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"empty", ""},
+		{"no calendar", "BEGIN:VEVENT\nEND:VEVENT\n"},
+		{"missing colon", "BEGIN:VCALENDAR\nSUMMARY\nEND:VCALENDAR\n"},
+		{"parameter without value", "BEGIN:VCALENDAR\nDTSTART;TZID:20250101T100000\nEND:VCALENDAR\n"},
+		{"mismatched end", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nEND:VCALENDAR\n"},
+		{"end without begin", "END:VEVENT\n"},
+		{"property outside component", "SUMMARY:x\nBEGIN:VCALENDAR\nEND:VCALENDAR\n"},
+		{"unterminated calendar", "BEGIN:VCALENDAR\nVERSION:2.0\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.data))
+			if !errors.Is(err, ErrMalformed) {
+				t.Fatalf("Parse() error = %v, want ErrMalformed", err)
+			}
+		})
+	}
+}
+
+func TestDecodeEventRejectsBadFields(t *testing.T) {
+	tests := []struct {
+		name  string
+		props []Property
+	}{
+		{"missing DTSTART", []Property{{Name: "SUMMARY", Value: "x"}}},
+		{"unknown TZID", []Property{{Name: "DTSTART", Params: map[string]string{"TZID": "Mars/Olympus"}, Value: "20250101T100000"}}},
+		{"invalid date-time", []Property{{Name: "DTSTART", Value: "2025-01-01T10:00:00Z"}}},
+		{"invalid date", []Property{{Name: "DTSTART", Params: map[string]string{"VALUE": "DATE"}, Value: "20251301"}}},
+		{"invalid duration", []Property{{Name: "DTSTART", Value: "20250101T100000Z"}, {Name: "DURATION", Value: "PT"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeEvent(Component{Name: "VEVENT", Properties: tt.props}, time.UTC); err == nil {
+				t.Fatal("DecodeEvent() succeeded, want an error")
+			}
+		})
+	}
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	start := time.Date(2025, 5, 6, 14, 0, 0, 0, time.UTC)
+	want := Event{
+		UID:      "booking-1@meeting-room",
+		Sequence: 3,
+		Start:    start,
+		End:      start.Add(time.Hour),
+		Summary:  "Design review; naming, \\ escapes",
+		// Long enough to be folded, with multi-byte runes around the fold.
+		Description: strings.Repeat("Überprüfung ", 10) + "\nsecond line",
+		Location:    "Room 101, Floor 1",
+		Status:      StatusConfirmed,
+	}
+	data := Calendar{ProdID: "-//meeting-room//test//EN", Events: []Event{want}}.Marshal()
+
+	for _, line := range strings.Split(string(data), "\r\n") {
+		if len(line) > maxLineOctets {
+			t.Errorf("line longer than %d octets: %q", maxLineOctets, line)
+		}
+	}
+
+	cal, err := Parse(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	events := decodeEvents(t, cal, time.UTC)
+	if len(events) != 1 {
+		t.Fatalf("got %d events, want 1", len(events))
+	}
+	got := events[0]
+	if got.UID != want.UID || got.Sequence != want.Sequence || got.Summary != want.Summary ||
+		got.Description != want.Description || got.Location != want.Location || got.Status != want.Status ||
+		!got.Start.Equal(want.Start) || !got.End.Equal(want.End) {
+		t.Errorf("round trip changed the event:\n got %+v\nwant %+v", got, want)
+	}
+}
@@ -0,0 +1,412 @@
+// Package rrule implements the subset of RFC 5545 recurrence rules used for
+// recurring bookings: FREQ=DAILY|WEEKLY|MONTHLY with INTERVAL, BYDAY, COUNT
+// and UNTIL.
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+)
+
+var (
+	ErrInvalidRule        = errors.New("invalid recurrence rule")
+	ErrUnbounded          = errors.New("recurrence rule needs COUNT or UNTIL")
+	ErrTooManyOccurrences = errors.New("recurrence rule produces too many occurrences")
+)
+
+// maxPeriodsWithoutResult stops expansion of rules that can never match again,
+// such as the 31st of every 12th month starting in a 30-day month.
+const maxPeriodsWithoutResult = 1000
+
+// WeekdayNum is a BYDAY entry. Ordinal selects the nth weekday of the month
+// (negative counts from the end) and is only allowed for MONTHLY rules; zero
+// means every such weekday.
+type WeekdayNum struct {
+	Ordinal int
+	Weekday time.Weekday
+}
+
+type Rule struct {
+	Freq     Frequency
+	Interval int
+	ByDay    []WeekdayNum
+	Count    int
+	Until    time.Time
+
+	// untilDate is set when UNTIL was a DATE value, which covers the whole
+	// day in the local time of DTSTART.
+	untilDate bool
+}
+
+var weekdayCodes = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+func Parse(value string) (Rule, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	if value == "" {
+		return Rule{}, ErrInvalidRule
+	}
+
+	rule := Rule{Interval: 1}
+	for _, part := range strings.Split(value, ";") {
+		name, val, ok := strings.Cut(part, "=")
+		if !ok || val == "" {
+			return Rule{}, fmt.Errorf("%w: malformed part %q", ErrInvalidRule, part)
+		}
+
+		var err error
+		switch strings.ToUpper(name) {
+		case "FREQ":
+			rule.Freq = Frequency(strings.ToUpper(val))
+		case "INTERVAL":
+			rule.Interval, err = strconv.Atoi(val)
+			if err == nil && rule.Interval < 1 {
+				err = errors.New("must be positive")
+			}
+		case "COUNT":
+			rule.Count, err = strconv.Atoi(val)
+			if err == nil && rule.Count < 1 {
+				err = errors.New("must be positive")
+			}
+		case "UNTIL":
+			rule.Until, rule.untilDate, err = parseUntil(val)
+		case "BYDAY":
+			rule.ByDay, err = parseByDay(val)
+		case "WKST":
+			if !strings.EqualFold(val, "MO") {
+				err = errors.New("only MO is supported")
+			}
+		default:
+			err = errors.New("unsupported rule part")
+		}
+		if err != nil {
+			return Rule{}, fmt.Errorf("%w: %s: %v", ErrInvalidRule, name, err)
+		}
+	}
+
+	if err := rule.validate(); err != nil {
+		return Rule{}, err
+	}
+	return rule, nil
+}
+
+func parseUntil(value string) (time.Time, bool, error) {
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t, false, nil
+	}
+	if t, err := time.Parse("20060102", value); err == nil {
+		return t, true, nil
+	}
+	return time.Time{}, false, errors.New("expected YYYYMMDD or YYYYMMDDTHHMMSSZ")
+}
+
+func parseByDay(value string) ([]WeekdayNum, error) {
+	var days []WeekdayNum
+	for _, entry := range strings.Split(strings.ToUpper(value), ",") {
+		if len(entry) < 2 {
+			return nil, fmt.Errorf("invalid weekday %q", entry)
+		}
+		weekday, ok := weekdayCodes[entry[len(entry)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("invalid weekday %q", entry)
+		}
+		day := WeekdayNum{Weekday: weekday}
+		if prefix := entry[:len(entry)-2]; prefix != "" {
+			ordinal, err := strconv.Atoi(prefix)
+			if err != nil || ordinal == 0 || ordinal < -5 || ordinal > 5 {
+				return nil, fmt.Errorf("invalid weekday %q", entry)
+			}
+			day.Ordinal = ordinal
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+func (r Rule) validate() error {
+	switch r.Freq {
+	case Daily, Weekly, Monthly:
+	case "":
+		return fmt.Errorf("%w: FREQ is required", ErrInvalidRule)
+	default:
+		return fmt.Errorf("%w: unsupported FREQ %q", ErrInvalidRule, r.Freq)
+	}
+	if r.Count > 0 && !r.Until.IsZero() {
+		return fmt.Errorf("%w: COUNT and UNTIL are mutually exclusive", ErrInvalidRule)
+	}
+	if r.Freq != Monthly {
+		for _, day := range r.ByDay {
+			if day.Ordinal != 0 {
+				return fmt.Errorf("%w: BYDAY ordinals need FREQ=MONTHLY", ErrInvalidRule)
+			}
+		}
+	}
+	return nil
+}
+
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			code := strings.ToUpper(day.Weekday.String()[:2])
+			if day.Ordinal != 0 {
+				code = strconv.Itoa(day.Ordinal) + code
+			}
+			days[i] = code
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		if r.untilDate {
+			parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+		} else {
+			parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+		}
+	}
+	return strings.Join(parts, ";")
+}
+
+// EndingBefore returns a copy of the rule that stops before t, replacing any
+// COUNT with an UNTIL bound.
+func (r Rule) EndingBefore(t time.Time) Rule {
+	r.Count = 0
+	r.Until = t.Add(-time.Second).UTC().Truncate(time.Second)
+	r.untilDate = false
+	return r
+}
+
+// StartingAt returns a copy of the rule that, expanded from t, yields the
+// occurrences of the rule from dtstart that start at or after t. t should
+// be one of those occurrences; any COUNT is reduced by the ones before it.
+func (r Rule) StartingAt(dtstart, t time.Time) Rule {
+	if r.Count == 0 {
+		return r
+	}
+	occurrences, _ := r.Expand(dtstart, nil, r.Count)
+	for _, occurrence := range occurrences {
+		if occurrence.Before(t) {
+			r.Count--
+		}
+	}
+	return r
+}
+
+// Shift returns a copy of the rule for occurrences that all move by d,
+// which moves their local date by days: BYDAY weekdays and UNTIL move along
+// so that the same occurrences remain. It fails when the weekdays cannot
+// follow, i.e. for ordinal weekdays unless moved by whole weeks, and for
+// weekdays of a WEEKLY rule with an INTERVAL that would move into different
+// weeks.
+func (r Rule) Shift(d time.Duration, days int) (Rule, error) {
+	if days%7 != 0 && len(r.ByDay) > 0 {
+		byDay := make([]WeekdayNum, len(r.ByDay))
+		weeks := map[int]bool{}
+		for i, day := range r.ByDay {
+			if day.Ordinal != 0 {
+				return Rule{}, fmt.Errorf("%w: cannot move ordinal BYDAY weekdays by %d days", ErrInvalidRule, days)
+			}
+			// Days since the Monday the week of day starts on.
+			sinceMonday := (int(day.Weekday)+6)%7 + days
+			weeks[floorDiv(sinceMonday, 7)] = true
+			byDay[i] = WeekdayNum{Weekday: time.Weekday((sinceMonday%7 + 7 + 1) % 7)}
+		}
+		if r.Freq == Weekly && r.Interval > 1 && len(weeks) > 1 {
+			return Rule{}, fmt.Errorf("%w: cannot move BYDAY weekdays across weeks with INTERVAL", ErrInvalidRule)
+		}
+		r.ByDay = byDay
+	}
+	if !r.Until.IsZero() {
+		if r.untilDate {
+			r.Until = r.Until.AddDate(0, 0, days)
+		} else {
+			r.Until = r.Until.Add(d)
+		}
+	}
+	return r, nil
+}
+
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+// Expand returns the start of every occurrence of the rule beginning at
+// dtstart, in dtstart's location so that wall-clock times survive DST
+// changes. Occurrences matching exdates are dropped after COUNT is applied,
+// as RFC 5545 requires. Expansion fails with ErrTooManyOccurrences when more
+// than limit occurrences would be produced.
+func (r Rule) Expand(dtstart time.Time, exdates []time.Time, limit int) ([]time.Time, error) {
+	if r.Count == 0 && r.Until.IsZero() {
+		return nil, ErrUnbounded
+	}
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	excluded := make(map[int64]bool, len(exdates))
+	for _, exdate := range exdates {
+		excluded[exdate.Unix()] = true
+	}
+
+	var occurrences []time.Time
+	generated := 0
+	idlePeriods := 0
+	for period := 0; ; period++ {
+		candidates := r.periodCandidates(dtstart, period*interval)
+		if len(candidates) == 0 {
+			idlePeriods++
+			if idlePeriods > maxPeriodsWithoutResult {
+				return occurrences, nil
+			}
+			continue
+		}
+		idlePeriods = 0
+
+		for _, candidate := range candidates {
+			if candidate.Before(dtstart) {
+				continue
+			}
+			if r.pastUntil(candidate) {
+				return occurrences, nil
+			}
+			generated++
+			if !excluded[candidate.Unix()] {
+				if len(occurrences) == limit {
+					return nil, ErrTooManyOccurrences
+				}
+				occurrences = append(occurrences, candidate)
+			}
+			if r.Count > 0 && generated == r.Count {
+				return occurrences, nil
+			}
+		}
+	}
+}
+
+func (r Rule) pastUntil(t time.Time) bool {
+	if r.Until.IsZero() {
+		return false
+	}
+	if r.untilDate {
+		y, m, d := t.Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC).After(r.Until)
+	}
+	return t.After(r.Until)
+}
+
+// periodCandidates lists the rule's instances in the day, week or month that
+// lies offset periods after the one containing dtstart, in ascending order.
+func (r Rule) periodCandidates(dtstart time.Time, offset int) []time.Time {
+	loc := dtstart.Location()
+	year, month, day := dtstart.Date()
+	hour, min, sec := dtstart.Clock()
+	at := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, hour, min, sec, 0, loc)
+	}
+
+	var candidates []time.Time
+	switch r.Freq {
+	case Daily:
+		candidate := at(year, month, day+offset)
+		if len(r.ByDay) == 0 || r.matchesWeekday(candidate.Weekday()) {
+			candidates = append(candidates, candidate)
+		}
+	case Weekly:
+		monday := day - (int(dtstart.Weekday())+6)%7 + 7*offset
+		if len(r.ByDay) == 0 {
+			return []time.Time{at(year, month, day+7*offset)}
+		}
+		for i := 0; i < 7; i++ {
+			candidate := at(year, month, monday+i)
+			if r.matchesWeekday(candidate.Weekday()) {
+				candidates = append(candidates, candidate)
+			}
+		}
+	case Monthly:
+		first := time.Date(year, month+time.Month(offset), 1, 0, 0, 0, 0, loc)
+		y, m := first.Year(), first.Month()
+		if len(r.ByDay) == 0 {
+			candidate := at(y, m, day)
+			if candidate.Month() == m {
+				candidates = append(candidates, candidate)
+			}
+			return candidates
+		}
+		candidates = r.monthlyByDay(y, m, at)
+	}
+	return candidates
+}
+
+func (r Rule) matchesWeekday(weekday time.Weekday) bool {
+	for _, day := range r.ByDay {
+		if day.Weekday == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Rule) monthlyByDay(year int, month time.Month, at func(int, time.Month, int) time.Time) []time.Time {
+	daysInMonth := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	seen := map[int]bool{}
+	for _, byDay := range r.ByDay {
+		var matching []int
+		for d := 1; d <= daysInMonth; d++ {
+			if time.Date(year, month, d, 0, 0, 0, 0, time.UTC).Weekday() == byDay.Weekday {
+				matching = append(matching, d)
+			}
+		}
+		switch {
+		case byDay.Ordinal == 0:
+			for _, d := range matching {
+				seen[d] = true
+			}
+		case byDay.Ordinal > 0 && byDay.Ordinal <= len(matching):
+			seen[matching[byDay.Ordinal-1]] = true
+		case byDay.Ordinal < 0 && -byDay.Ordinal <= len(matching):
+			seen[matching[len(matching)+byDay.Ordinal]] = true
+		}
+	}
+
+	days := make([]int, 0, len(seen))
+	for d := range seen {
+		days = append(days, d)
+	}
+	sort.Ints(days)
+
+	candidates := make([]time.Time, len(days))
+	for i, d := range days {
+		candidates[i] = at(year, month, d)
+	}
+	return candidates
+}
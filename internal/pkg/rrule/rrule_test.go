@@ -0,0 +1,298 @@
+package rrule
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const layout = "2006-01-02 15:04 MST"
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("load location %s: %v", name, err)
+	}
+	return loc
+}
+
+func format(times []time.Time) []string {
+	formatted := make([]string, len(times))
+	for i, t := range times {
+		formatted[i] = t.Format(layout)
+	}
+	return formatted
+}
+
+func TestExpand(t *testing.T) {
+	newYork := mustLoad(t, "America/New_York")
+	berlin := mustLoad(t, "Europe/Berlin")
+
+	tests := []struct {
+		name    string
+		rule    string
+		dtstart time.Time
+		exdates []time.Time
+		want    []string
+	}{
+		{
+			name:    "daily count",
+			rule:    "FREQ=DAILY;COUNT=3",
+			dtstart: time.Date(2025, 1, 30, 10, 0, 0, 0, time.UTC),
+			want:    []string{"2025-01-30 10:00 UTC", "2025-01-31 10:00 UTC", "2025-02-01 10:00 UTC"},
+		},
+		{
+			name:    "daily interval",
+			rule:    "FREQ=DAILY;INTERVAL=3;COUNT=3",
+			dtstart: time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+			want:    []string{"2025-01-01 10:00 UTC", "2025-01-04 10:00 UTC", "2025-01-07 10:00 UTC"},
+		},
+		{
+			name:    "daily on weekdays",
+			rule:    "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR;COUNT=4",
+			dtstart: time.Date(2025, 1, 9, 10, 0, 0, 0, time.UTC),
+			want:    []string{"2025-01-09 10:00 UTC", "2025-01-10 10:00 UTC", "2025-01-13 10:00 UTC", "2025-01-14 10:00 UTC"},
+		},
+		{
+			name:    "weekly repeats the weekday of dtstart",
+			rule:    "FREQ=WEEKLY;COUNT=3",
+			dtstart: time.Date(2025, 1, 8, 10, 0, 0, 0, time.UTC),
+			want:    []string{"2025-01-08 10:00 UTC", "2025-01-15 10:00 UTC", "2025-01-22 10:00 UTC"},
+		},
+		{
+			name:    "weekly byday skips days before dtstart",
+			rule:    "FREQ=WEEKLY;BYDAY=MO,WE,FR;COUNT=5",
+			dtstart: time.Date(2025, 1, 8, 10, 0, 0, 0, time.UTC),
+			want: []string{
+				"2025-01-08 10:00 UTC", "2025-01-10 10:00 UTC", "2025-01-13 10:00 UTC",
+				"2025-01-15 10:00 UTC", "2025-01-17 10:00 UTC",
+			},
+		},
+		{
+			name:    "weekly interval with byday",
+			rule:    "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH;COUNT=4",
+			dtstart: time.Date(2025, 1, 7, 10, 0, 0, 0, time.UTC),
+			want:    []string{"2025-01-07 10:00 UTC", "2025-01-09 10:00 UTC", "2025-01-21 10:00 UTC", "2025-01-23 10:00 UTC"},
+		},
+		{
+			name:    "monthly by day of month skips short months",
+			rule:    "FREQ=MONTHLY;COUNT=3",
+			dtstart: time.Date(2025, 1, 31, 10, 0, 0, 0, time.UTC),
+			want:    []string{"2025-01-31 10:00 UTC", "2025-03-31 10:00 UTC", "2025-05-31 10:00 UTC"},
+		},
+		{
+			name:    "monthly last friday",
+			rule:    "FREQ=MONTHLY;BYDAY=-1FR;COUNT=3",
+			dtstart: time.Date(2025, 1, 31, 10, 0, 0, 0, time.UTC),
+			want:    []string{"2025-01-31 10:00 UTC", "2025-02-28 10:00 UTC", "2025-03-28 10:00 UTC"},
+		},
+		{
+			name:    "monthly second tuesday every other month",
+			rule:    "FREQ=MONTHLY;INTERVAL=2;BYDAY=2TU;COUNT=3",
+			dtstart: time.Date(2025, 1, 14, 10, 0, 0, 0, time.UTC),
+			want:    []string{"2025-01-14 10:00 UTC", "2025-03-11 10:00 UTC", "2025-05-13 10:00 UTC"},
+		},
+		{
+			name:    "until date-time is inclusive",
+			rule:    "FREQ=DAILY;UNTIL=20250103T100000Z",
+			dtstart: time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+			want:    []string{"2025-01-01 10:00 UTC", "2025-01-02 10:00 UTC", "2025-01-03 10:00 UTC"},
+		},
+		{
+			name:    "until date-time stops before a later start",
+			rule:    "FREQ=DAILY;UNTIL=20250103T095959Z",
+			dtstart: time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+			want:    []string{"2025-01-01 10:00 UTC", "2025-01-02 10:00 UTC"},
+		},
+		{
+			name:    "until date covers the whole local day",
+			rule:    "FREQ=DAILY;UNTIL=20250103",
+			dtstart: time.Date(2025, 1, 1, 23, 0, 0, 0, berlin),
+			want:    []string{"2025-01-01 23:00 CET", "2025-01-02 23:00 CET", "2025-01-03 23:00 CET"},
+		},
+		{
+			name:    "exdates are dropped after count",
+			rule:    "FREQ=DAILY;COUNT=3",
+			dtstart: time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+			exdates: []time.Time{time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)},
+			want:    []string{"2025-01-01 10:00 UTC", "2025-01-03 10:00 UTC"},
+		},
+		{
+			name:    "spring forward keeps the wall clock",
+			rule:    "FREQ=DAILY;COUNT=3",
+			dtstart: time.Date(2025, 3, 8, 9, 0, 0, 0, newYork),
+			want:    []string{"2025-03-08 09:00 EST", "2025-03-09 09:00 EDT", "2025-03-10 09:00 EDT"},
+		},
+		{
+			name:    "fall back keeps the wall clock",
+			rule:    "FREQ=WEEKLY;BYDAY=SA,MO;COUNT=3",
+			dtstart: time.Date(2025, 11, 1, 9, 0, 0, 0, newYork),
+			want:    []string{"2025-11-01 09:00 EDT", "2025-11-03 09:00 EST", "2025-11-08 09:00 EST"},
+		},
+		{
+			name:    "until date-time across a DST change",
+			rule:    "FREQ=WEEKLY;UNTIL=20250331T070000Z",
+			dtstart: time.Date(2025, 3, 17, 9, 0, 0, 0, berlin),
+			want:    []string{"2025-03-17 09:00 CET", "2025-03-24 09:00 CET", "2025-03-31 09:00 CEST"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.rule)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tt.rule, err)
+			}
+			got, err := rule.Expand(tt.dtstart, tt.exdates, 100)
+			if err != nil {
+				t.Fatalf("Expand failed: %v", err)
+			}
+			if !reflect.DeepEqual(format(got), tt.want) {
+				t.Errorf("got  %v\nwant %v", format(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandLimits(t *testing.T) {
+	dtstart := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	rule, _ := Parse("FREQ=DAILY;COUNT=10")
+	if _, err := rule.Expand(dtstart, nil, 9); err != ErrTooManyOccurrences {
+		t.Errorf("Expand over the limit = %v, want ErrTooManyOccurrences", err)
+	}
+
+	rule, _ = Parse("FREQ=WEEKLY;BYDAY=MO")
+	if _, err := rule.Expand(dtstart, nil, 100); err != ErrUnbounded {
+		t.Errorf("Expand without COUNT or UNTIL = %v, want ErrUnbounded", err)
+	}
+}
+
+func TestParseRejectsInvalidRules(t *testing.T) {
+	tests := []string{
+		"",
+		"FREQ=YEARLY;COUNT=1",
+		"COUNT=3",
+		"FREQ=DAILY;COUNT=0",
+		"FREQ=DAILY;INTERVAL=0;COUNT=1",
+		"FREQ=DAILY;COUNT=2;UNTIL=20250101",
+		"FREQ=DAILY;UNTIL=2025-01-01",
+		"FREQ=WEEKLY;BYDAY=XX;COUNT=1",
+		"FREQ=WEEKLY;BYDAY=1MO;COUNT=1",
+		"FREQ=MONTHLY;BYDAY=6MO;COUNT=1",
+		"FREQ=DAILY;WKST=SU;COUNT=1",
+		"FREQ=DAILY;BYMONTH=1;COUNT=1",
+		"FREQ=DAILY;COUNT",
+	}
+	for _, value := range tests {
+		if _, err := Parse(value); !errors.Is(err, ErrInvalidRule) {
+			t.Errorf("Parse(%q) = %v, want ErrInvalidRule", value, err)
+		}
+	}
+}
+
+func TestStringRoundTrip(t *testing.T) {
+	tests := []string{
+		"FREQ=DAILY;COUNT=5",
+		"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH;UNTIL=20250630",
+		"FREQ=MONTHLY;BYDAY=-1FR,2TU;UNTIL=20250630T150000Z",
+	}
+	for _, value := range tests {
+		rule, err := Parse("RRULE:" + value)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", value, err)
+		}
+		if got := rule.String(); got != value {
+			t.Errorf("String() = %q, want %q", got, value)
+		}
+	}
+}
+
+func TestEndingBeforeAndStartingAtSplitTheOccurrences(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    string
+		dtstart time.Time
+	}{
+		{"count", "FREQ=WEEKLY;BYDAY=MO,TH;COUNT=6", time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)},
+		{"until", "FREQ=DAILY;INTERVAL=2;UNTIL=20250315", time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, _ := Parse(tt.rule)
+			all, err := rule.Expand(tt.dtstart, nil, 100)
+			if err != nil {
+				t.Fatalf("Expand failed: %v", err)
+			}
+
+			split := all[3]
+			head, _ := rule.EndingBefore(split).Expand(tt.dtstart, nil, 100)
+			tail, _ := rule.StartingAt(tt.dtstart, split).Expand(split, nil, 100)
+			got := append(format(head), format(tail)...)
+			if !reflect.DeepEqual(got, format(all)) {
+				t.Errorf("split gives %v\nwant %v", got, format(all))
+			}
+			if len(head) != 3 {
+				t.Errorf("head has %d occurrences, want 3", len(head))
+			}
+		})
+	}
+}
+
+func TestShift(t *testing.T) {
+	newYork := mustLoad(t, "America/New_York")
+
+	tests := []struct {
+		name    string
+		rule    string
+		dtstart time.Time
+		shift   time.Duration
+		days    int
+	}{
+		{"weekly byday forward", "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=4", time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC), 24 * time.Hour, 1},
+		{"weekly byday across sunday", "FREQ=WEEKLY;BYDAY=SA,SU;UNTIL=20250316", time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC), 24 * time.Hour, 1},
+		{"daily byday backward", "FREQ=DAILY;BYDAY=TU,FR;UNTIL=20250321T090000Z", time.Date(2025, 3, 4, 9, 0, 0, 0, time.UTC), -24 * time.Hour, -1},
+		{"same day later", "FREQ=DAILY;UNTIL=20250305T090000Z", time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC), 2 * time.Hour, 0},
+		{"into the next day", "FREQ=WEEKLY;BYDAY=TU;COUNT=3", time.Date(2025, 3, 4, 23, 0, 0, 0, time.UTC), 2 * time.Hour, 1},
+		{"whole weeks with ordinals", "FREQ=MONTHLY;BYDAY=2TU;COUNT=3", time.Date(2025, 1, 14, 9, 0, 0, 0, newYork), 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, _ := Parse(tt.rule)
+			original, err := rule.Expand(tt.dtstart, nil, 100)
+			if err != nil {
+				t.Fatalf("Expand failed: %v", err)
+			}
+			shifted, err := rule.Shift(tt.shift, tt.days)
+			if err != nil {
+				t.Fatalf("Shift failed: %v", err)
+			}
+			got, err := shifted.Expand(tt.dtstart.Add(tt.shift), nil, 100)
+			if err != nil {
+				t.Fatalf("Expand of the shifted rule failed: %v", err)
+			}
+
+			want := make([]time.Time, len(original))
+			for i, occurrence := range original {
+				want[i] = occurrence.Add(tt.shift)
+			}
+			if !reflect.DeepEqual(format(got), format(want)) {
+				t.Errorf("shifted rule %q gives %v\nwant %v", shifted, format(got), format(want))
+			}
+		})
+	}
+}
+
+func TestShiftRejectsWeekdaysThatCannotFollow(t *testing.T) {
+	tests := []string{
+		"FREQ=MONTHLY;BYDAY=-1FR;COUNT=3",
+		"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,SU;COUNT=4",
+	}
+	for _, value := range tests {
+		rule, _ := Parse(value)
+		if _, err := rule.Shift(24*time.Hour, 1); !errors.Is(err, ErrInvalidRule) {
+			t.Errorf("Shift of %q = %v, want ErrInvalidRule", value, err)
+		}
+	}
+}